@@ -44,6 +44,11 @@ type BatteryCapacity interface {
 	Capacity() float64
 }
 
+// BatterySocLimiter provides the min and max soc in % the battery is operated within
+type BatterySocLimiter interface {
+	GetSocLimits() (float64, float64)
+}
+
 // MaxACPowerGetter provides max AC power in W
 type MaxACPowerGetter interface {
 	MaxACPower() float64
@@ -191,6 +196,11 @@ type Tariff interface {
 	Type() TariffType
 }
 
+// TariffComponents provides the breakdown of a composed tariff's rates
+type TariffComponents interface {
+	Components() ([]TariffComponent, error)
+}
+
 // AuthProvider is the ability to provide OAuth authentication through the ui
 type AuthProvider interface {
 	Login(state string) string
@@ -224,6 +234,7 @@ type CsvWriter interface {
 type CircuitMeasurements interface {
 	GetChargePower() float64
 	GetMaxPhaseCurrent() float64
+	GetPhaseCurrents() [3]float64 // currents per grid phase L1/L2/L3
 }
 
 // CircuitLoad represents a loadpoint attached to a circuit
@@ -247,6 +258,7 @@ type Circuit interface {
 	SetMaxCurrent(float64)
 	Update([]CircuitLoad) error
 	ValidateCurrent(old, new float64) float64
+	ValidatePhaseCurrent(old [3]float64, new float64, phases [3]bool) float64
 	ValidatePower(old, new float64) float64
 }
 
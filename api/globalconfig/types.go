@@ -12,6 +12,7 @@ import (
 	"github.com/evcc-io/evcc/server/eebus"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/modbus"
+	"github.com/evcc-io/evcc/util/sim"
 )
 
 type All struct {
@@ -22,14 +23,18 @@ type All struct {
 	Telemetry    bool
 	Mcp          bool
 	Metrics      bool
+	MetricsAuth  bool
 	Profile      bool
 	Levels       map[string]string
 	Interval     time.Duration
 	Database     DB
+	History      History
 	Mqtt         Mqtt
 	ModbusProxy  []ModbusProxy
+	ModbusServer ModbusServer
 	Javascript   []Javascript
 	Go           []Go
+	Simulators   []Simulator
 	Influx       Influx
 	EEBus        eebus.Config
 	HEMS         Hems
@@ -53,12 +58,22 @@ type Go struct {
 	Script string
 }
 
+type Simulator struct {
+	Name       string
+	sim.Config `mapstructure:",squash" yaml:",inline,omitempty" json:",omitempty"`
+}
+
 type ModbusProxy struct {
 	Port            int
 	ReadOnly        string `yaml:",omitempty" json:",omitempty"`
 	modbus.Settings `mapstructure:",squash" yaml:",inline,omitempty" json:",omitempty"`
 }
 
+type ModbusServer struct {
+	Port     int
+	ReadOnly string `yaml:",omitempty" json:",omitempty"`
+}
+
 var _ api.Redactor = (*Hems)(nil)
 
 type Hems config.Typed
@@ -83,6 +98,7 @@ func masked(s any) string {
 type Mqtt struct {
 	mqtt.Config `mapstructure:",squash"`
 	Topic       string `json:"topic"`
+	Discovery   string `json:"discovery"` // home assistant discovery prefix, empty to disable
 }
 
 // Redacted implements the redactor interface used by the tee publisher
@@ -98,7 +114,8 @@ func (m Mqtt) Redacted() any {
 			ClientCert: masked(m.ClientCert),
 			ClientKey:  masked(m.ClientKey),
 		},
-		Topic: m.Topic,
+		Topic:     m.Topic,
+		Discovery: m.Discovery,
 	}
 }
 
@@ -131,6 +148,10 @@ type DB struct {
 	Dsn  string
 }
 
+type History struct {
+	Retention map[string]time.Duration // per resolution (15m, hour, day, month), zero keeps entries forever
+}
+
 type Messaging struct {
 	Events   map[string]push.EventTemplateConfig
 	Services []config.Typed
@@ -141,12 +162,23 @@ func (c Messaging) Configured() bool {
 }
 
 type Tariffs struct {
-	Currency string
-	Grid     config.Typed
-	FeedIn   config.Typed
-	Co2      config.Typed
-	Planner  config.Typed
-	Solar    []config.Typed
+	Currency        string
+	Grid            config.Typed
+	FeedIn          config.Typed
+	Co2             config.Typed
+	Planner         config.Typed
+	Solar           []config.Typed
+	GridFee         GridFee
+	Retention       time.Duration // rate history retention, 0 uses default
+	SolarCorrection bool          // apply learned bias correction to solar forecast
+}
+
+// GridFee is the §14a EnWG grid fee module
+type GridFee struct {
+	Module        int
+	NetworkCharge float64      // regular network charge per kWh contained in the grid tariff
+	Reduction     float64      // share of the network charge waived in module 2
+	Variable      config.Typed // time-variable network charge for module 3
 }
 
 type Network struct {
@@ -900,6 +900,20 @@ func (mr *MockCircuitMockRecorder) GetParent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParent", reflect.TypeOf((*MockCircuit)(nil).GetParent))
 }
 
+// GetPhaseCurrents mocks base method.
+func (m *MockCircuit) GetPhaseCurrents() [3]float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhaseCurrents")
+	ret0, _ := ret[0].([3]float64)
+	return ret0
+}
+
+// GetPhaseCurrents indicates an expected call of GetPhaseCurrents.
+func (mr *MockCircuitMockRecorder) GetPhaseCurrents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhaseCurrents", reflect.TypeOf((*MockCircuit)(nil).GetPhaseCurrents))
+}
+
 // GetTitle mocks base method.
 func (m *MockCircuit) GetTitle() string {
 	m.ctrl.T.Helper()
@@ -1004,6 +1018,20 @@ func (mr *MockCircuitMockRecorder) ValidateCurrent(old, new any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCurrent", reflect.TypeOf((*MockCircuit)(nil).ValidateCurrent), old, new)
 }
 
+// ValidatePhaseCurrent mocks base method.
+func (m *MockCircuit) ValidatePhaseCurrent(old [3]float64, new float64, phases [3]bool) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePhaseCurrent", old, new, phases)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// ValidatePhaseCurrent indicates an expected call of ValidatePhaseCurrent.
+func (mr *MockCircuitMockRecorder) ValidatePhaseCurrent(old, new, phases any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePhaseCurrent", reflect.TypeOf((*MockCircuit)(nil).ValidatePhaseCurrent), old, new, phases)
+}
+
 // ValidatePower mocks base method.
 func (m *MockCircuit) ValidatePower(old, new float64) float64 {
 	m.ctrl.T.Helper()
@@ -47,6 +47,12 @@ func (rr Rates) At(ts time.Time) (Rate, error) {
 	return Rate{}, ErrNotAvailable
 }
 
+// TariffComponent is a named share of a composed tariff's rates
+type TariffComponent struct {
+	Name  string `json:"name"`
+	Rates Rates  `json:"rates"`
+}
+
 // MarshalMQTT implements server.MQTTMarshaler
 func (r Rates) MarshalMQTT() ([]byte, error) {
 	return json.Marshal(r)
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -38,6 +39,7 @@ import (
 // OCPP charger implementation
 type OCPP struct {
 	log     *util.Logger
+	mu      sync.Mutex
 	cp      *ocpp.CP
 	conn    *ocpp.Connector
 	phases  int
@@ -45,6 +47,7 @@ type OCPP struct {
 	current float64
 
 	stackLevelZero bool
+	schedule       bool
 	lp             loadpoint.API
 }
 
@@ -74,6 +77,7 @@ func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.C
 		ForcePowerCtrl bool
 		StackLevelZero *bool
 		RemoteStart    bool
+		Schedule       bool // upload offline charging schedule
 	}{
 		Connector:      1,
 		MeterInterval:  10 * time.Second,
@@ -89,7 +93,7 @@ func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.C
 	c, err := NewOCPP(ctx,
 		cc.StationId, cc.Connector, cc.IdTag,
 		cc.MeterValues, cc.MeterInterval,
-		cc.ForcePowerCtrl, stackLevelZero, cc.RemoteStart,
+		cc.ForcePowerCtrl, stackLevelZero, cc.RemoteStart, cc.Schedule,
 		cc.ConnectTimeout)
 	if err != nil {
 		return c, err
@@ -143,7 +147,7 @@ func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.C
 func NewOCPP(ctx context.Context,
 	id string, connector int, idTag string,
 	meterValues string, meterInterval time.Duration,
-	forcePowerCtrl, stackLevelZero, remoteStart bool,
+	forcePowerCtrl, stackLevelZero, remoteStart, schedule bool,
 	connectTimeout time.Duration,
 ) (*OCPP, error) {
 	log := util.NewLogger(fmt.Sprintf("%s-%d", lo.CoalesceOrEmpty(id, "ocpp"), connector))
@@ -188,12 +192,17 @@ func NewOCPP(ctx context.Context,
 		cp:             cp,
 		conn:           conn,
 		stackLevelZero: stackLevelZero,
+		schedule:       schedule,
 	}
 
 	if cp.HasRemoteTriggerFeature {
 		go conn.WatchDog(ctx, 10*time.Second)
 	}
 
+	if schedule {
+		go c.scheduleWatcher(ctx)
+	}
+
 	return c, conn.Initialized()
 }
 
@@ -281,17 +290,25 @@ func (c *OCPP) Enabled() (bool, error) {
 	}
 
 	// fallback to cached value as last resort
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	return c.enabled, nil
 }
 
 // Enable implements the api.Charger interface
 func (c *OCPP) Enable(enable bool) error {
+	sched := c.offlineSchedule()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	var current float64
 	if enable {
 		current = c.current
 	}
 
-	err := c.setCurrent(current)
+	err := c.setCurrent(current, sched)
 	if err == nil {
 		// cache enabled state as last fallback option
 		c.enabled = enable
@@ -300,9 +317,10 @@ func (c *OCPP) Enable(enable bool) error {
 	return err
 }
 
-// setCurrent sets the TxDefaultChargingProfile with given current
-func (c *OCPP) setCurrent(current float64) error {
-	err := c.conn.SetChargingProfileRequest(c.createTxDefaultChargingProfile(math.Trunc(10*current) / 10))
+// setCurrent sets the TxDefaultChargingProfile with given current and optional offline schedule.
+// Must only be called while holding lock.
+func (c *OCPP) setCurrent(current float64, sched *offlineSchedule) error {
+	err := c.conn.SetChargingProfileRequest(c.createTxDefaultChargingProfile(math.Trunc(10*current)/10, sched))
 	if err != nil {
 		err = fmt.Errorf("set charging profile: %w", err)
 	}
@@ -310,18 +328,19 @@ func (c *OCPP) setCurrent(current float64) error {
 	return err
 }
 
-// createTxDefaultChargingProfile returns a TxDefaultChargingProfile with given current
-func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingProfile {
-	phases := c.phases
-	period := types.NewChargingSchedulePeriod(0, current)
-
-	if c.cp.ChargingRateUnit == types.ChargingRateUnitWatts {
-		period = types.NewChargingSchedulePeriod(0, math.Trunc(230.0*current*float64(phases)))
-	} else {
-		// OCPP assumes phases == 3 if not set
-		if phases != 0 {
-			// set explicit phase configuration
-			period.NumberPhases = &phases
+// createTxDefaultChargingProfile returns a TxDefaultChargingProfile with given current.
+// With offline schedule, the current is only held for a limited time followed by the offline schedule.
+func (c *OCPP) createTxDefaultChargingProfile(current float64, sched *offlineSchedule) *types.ChargingProfile {
+	now := time.Now()
+	start := now.Add(-time.Minute)
+
+	periods := []types.ChargingSchedulePeriod{c.schedulePeriod(0, current)}
+
+	if sched != nil {
+		periods = append(periods, c.offlinePeriods(start, now.Add(scheduleHold), sched)...)
+
+		if n := c.cp.MaxSchedulePeriods; n > 0 && len(periods) > n {
+			periods = periods[:n]
 		}
 	}
 
@@ -330,9 +349,9 @@ func (c *OCPP) createTxDefaultChargingProfile(current float64) *types.ChargingPr
 		ChargingProfilePurpose: types.ChargingProfilePurposeTxDefaultProfile,
 		ChargingProfileKind:    types.ChargingProfileKindAbsolute,
 		ChargingSchedule: &types.ChargingSchedule{
-			StartSchedule:          types.NewDateTime(time.Now().Add(-time.Minute)),
+			StartSchedule:          types.NewDateTime(start),
 			ChargingRateUnit:       c.cp.ChargingRateUnit,
-			ChargingSchedulePeriod: []types.ChargingSchedulePeriod{period},
+			ChargingSchedulePeriod: periods,
 		},
 	}
 
@@ -352,7 +371,12 @@ var _ api.ChargerEx = (*OCPP)(nil)
 
 // MaxCurrentMillis implements the api.ChargerEx interface
 func (c *OCPP) MaxCurrentMillis(current float64) error {
-	err := c.setCurrent(current)
+	sched := c.offlineSchedule()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err := c.setCurrent(current, sched)
 	if err == nil {
 		c.current = current
 	}
@@ -361,19 +385,24 @@ func (c *OCPP) MaxCurrentMillis(current float64) error {
 
 // phases1p3p implements the api.PhaseSwitcher interface
 func (c *OCPP) phases1p3p(phases int) error {
-	c.phases = phases
-
 	enabled, err := c.Enabled()
 	if err != nil {
 		return err
 	}
 
+	sched := c.offlineSchedule()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.phases = phases
+
 	var current float64
 	if enabled {
 		current = c.current
 	}
 
-	return c.setCurrent(current)
+	return c.setCurrent(current, sched)
 }
 
 var _ api.Identifier = (*OCPP)(nil)
@@ -421,4 +450,5 @@ var _ loadpoint.Controller = (*OCPP)(nil)
 // LoadpointControl implements loadpoint.Controller
 func (c *OCPP) LoadpointControl(lp loadpoint.API) {
 	c.lp = lp
+	c.conn.SetLoadpoint(lp)
 }
@@ -0,0 +1,76 @@
+package ocpp
+
+import (
+	"sync/atomic"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// authorizer decides whether an id tag is accepted at any of the given loadpoints. All tags are accepted if not set.
+var authorizer atomic.Pointer[func(idTag string, loadpoints []loadpoint.API) bool]
+
+// SetAuthorizer sets the function deciding whether an id tag presented at a charge point is accepted.
+// The loadpoints are those of the charge point's connectors and may be empty if not yet assigned.
+func SetAuthorizer(fun func(idTag string, loadpoints []loadpoint.API) bool) {
+	authorizer.Store(&fun)
+}
+
+// authorized checks if the id tag is accepted at the loadpoints. Tags used for remote start are always accepted.
+func authorized(idTag string, loadpoints []loadpoint.API, remote ...string) bool {
+	fun := authorizer.Load()
+	if fun == nil || *fun == nil {
+		return true
+	}
+
+	for _, r := range remote {
+		if r != "" && idTag == r {
+			return true
+		}
+	}
+
+	return (*fun)(idTag, loadpoints)
+}
+
+// remoteIdTags returns the id tags used by the charge point for remote start
+func (cp *CP) remoteIdTags() []string {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	res := make([]string, 0, len(cp.connectors))
+	for _, conn := range cp.connectors {
+		res = append(res, conn.remoteIdTag)
+	}
+
+	return res
+}
+
+// loadpoints returns the loadpoints of the charge point's connectors
+func (cp *CP) loadpoints() []loadpoint.API {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	var res []loadpoint.API
+	for _, conn := range cp.connectors {
+		conn.mu.Lock()
+		res = append(res, conn.loadpoints()...)
+		conn.mu.Unlock()
+	}
+
+	return res
+}
+
+// SetLoadpoint assigns the loadpoint controlling the connector
+func (conn *Connector) SetLoadpoint(lp loadpoint.API) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	conn.lp = lp
+}
+
+// loadpoints returns the connector's loadpoint if assigned. Requires lock.
+func (conn *Connector) loadpoints() []loadpoint.API {
+	if conn.lp == nil {
+		return nil
+	}
+	return []loadpoint.API{conn.lp}
+}
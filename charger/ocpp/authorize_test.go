@@ -0,0 +1,32 @@
+package ocpp
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorized(t *testing.T) {
+	t.Cleanup(func() { authorizer.Store(nil) })
+
+	assert.True(t, authorized("unknown", nil))
+
+	ctrl := gomock.NewController(t)
+	garage := loadpoint.NewMockAPI(ctrl)
+	carport := loadpoint.NewMockAPI(ctrl)
+
+	SetAuthorizer(func(idTag string, loadpoints []loadpoint.API) bool {
+		if idTag != "known" {
+			return false
+		}
+		return len(loadpoints) == 0 || loadpoints[0] == garage
+	})
+
+	assert.True(t, authorized("known", nil))
+	assert.True(t, authorized("known", []loadpoint.API{garage}))
+	assert.False(t, authorized("known", []loadpoint.API{carport}), "loadpoint not allowed")
+	assert.False(t, authorized("unknown", nil))
+	assert.True(t, authorized("evcc", []loadpoint.API{carport}, "", "evcc"), "remote start")
+}
@@ -10,9 +10,14 @@ import (
 
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/util"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
 )
 
 type Connector struct {
@@ -25,6 +30,8 @@ type Connector struct {
 	status  *core.StatusNotificationRequest
 	statusC chan struct{}
 
+	reconnectC chan struct{}
+
 	meterUpdated time.Time
 	measurements map[types.Measurand]types.SampledValue
 
@@ -32,8 +39,17 @@ type Connector struct {
 	idTag string
 
 	remoteIdTag string
+	lp          loadpoint.API // loadpoint for authorizing id tags
 
 	meterInterval time.Duration
+
+	// OCPP 2.0.1 transaction state
+	txnRef          string
+	connectorStatus availability.ConnectorStatus
+	chargingState   transactions.ChargingState
+	finished        bool
+	needs           *smartcharging.ChargingNeeds
+	profile         *types201.ChargingProfile
 }
 
 func NewConnector(ctx context.Context, log *util.Logger, id int, cp *CP, idTag string, meterInterval time.Duration) (*Connector, error) {
@@ -43,6 +59,7 @@ func NewConnector(ctx context.Context, log *util.Logger, id int, cp *CP, idTag s
 		id:           id,
 		clock:        clock.New(),
 		statusC:      make(chan struct{}, 1),
+		reconnectC:   make(chan struct{}, 1),
 		measurements: make(map[types.Measurand]types.SampledValue),
 
 		remoteIdTag:   idTag,
@@ -93,6 +110,26 @@ func (conn *Connector) IdTag() string {
 	return conn.idTag
 }
 
+// reconnected signals that the charge point has reconnected
+func (conn *Connector) reconnected() {
+	select {
+	case conn.reconnectC <- struct{}{}:
+	default:
+	}
+}
+
+// Reconnected returns a channel signaling charge point reconnects
+func (conn *Connector) Reconnected() <-chan struct{} {
+	return conn.reconnectC
+}
+
+// transactionRef returns the OCPP 2.0.1 transaction id
+func (conn *Connector) transactionRef() string {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	return conn.txnRef
+}
+
 // getScheduleLimit queries the current or power limit the charge point is currently set to offer
 func (conn *Connector) GetScheduleLimit(duration int) (float64, error) {
 	schedule, err := conn.cp.GetCompositeScheduleRequest(conn.id, duration)
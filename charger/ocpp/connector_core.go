@@ -95,9 +95,14 @@ func (conn *Connector) OnStartTransaction(request *core.StartTransactionRequest)
 	conn.txnId = int(instance.txnId.Add(1))
 	conn.idTag = request.IdTag
 
+	status := types.AuthorizationStatusAccepted
+	if !authorized(request.IdTag, conn.loadpoints(), conn.remoteIdTag) {
+		status = types.AuthorizationStatusInvalid
+	}
+
 	res := &core.StartTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted,
+			Status: status,
 		},
 		TransactionId: conn.txnId,
 	}
@@ -0,0 +1,299 @@
+package ocpp
+
+import (
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// statusV201 maps OCPP 2.0.1 connector status and charging state onto the OCPP 1.6 charge point status
+func statusV201(status availability.ConnectorStatus, state transactions.ChargingState, finished bool) core.ChargePointStatus {
+	switch status {
+	case availability.ConnectorStatusAvailable:
+		return core.ChargePointStatusAvailable
+	case availability.ConnectorStatusReserved:
+		return core.ChargePointStatusReserved
+	case availability.ConnectorStatusUnavailable:
+		return core.ChargePointStatusUnavailable
+	case availability.ConnectorStatusFaulted:
+		return core.ChargePointStatusFaulted
+	}
+
+	// occupied
+	switch state {
+	case transactions.ChargingStateCharging:
+		return core.ChargePointStatusCharging
+	case transactions.ChargingStateSuspendedEV:
+		return core.ChargePointStatusSuspendedEV
+	case transactions.ChargingStateSuspendedEVSE:
+		return core.ChargePointStatusSuspendedEVSE
+	}
+
+	if finished {
+		return core.ChargePointStatusFinishing
+	}
+
+	return core.ChargePointStatusPreparing
+}
+
+// statusRequestV201 converts an OCPP 2.0.1 status notification without transaction context
+func statusRequestV201(request *availability.StatusNotificationRequest) *core.StatusNotificationRequest {
+	return newStatusRequest(request.EvseID, statusV201(request.ConnectorStatus, "", false), request.Timestamp)
+}
+
+func newStatusRequest(connector int, status core.ChargePointStatus, ts *types201.DateTime) *core.StatusNotificationRequest {
+	res := &core.StatusNotificationRequest{
+		ConnectorId: connector,
+		ErrorCode:   core.NoError,
+		Status:      status,
+	}
+
+	if status == core.ChargePointStatusFaulted {
+		res.ErrorCode = core.OtherError
+	}
+
+	if ts != nil {
+		res.Timestamp = types.NewDateTime(ts.Time)
+	}
+
+	return res
+}
+
+// statusRequest returns the connector's OCPP 1.6 status.
+// Must only be called while holding lock.
+func (conn *Connector) statusRequest(ts *types201.DateTime) *core.StatusNotificationRequest {
+	return newStatusRequest(conn.id, statusV201(conn.connectorStatus, conn.chargingState, conn.finished), ts)
+}
+
+func (conn *Connector) OnConnectorStatusV201(request *availability.StatusNotificationRequest) {
+	conn.mu.Lock()
+
+	conn.connectorStatus = request.ConnectorStatus
+
+	if request.ConnectorStatus == availability.ConnectorStatusAvailable {
+		conn.chargingState = ""
+		conn.finished = false
+	}
+
+	status := conn.statusRequest(request.Timestamp)
+	conn.mu.Unlock()
+
+	conn.OnStatusNotification(status)
+}
+
+// isAuthorizedV201 checks if the transaction event indicates an authorized transaction
+func isAuthorizedV201(request *transactions.TransactionEventRequest) bool {
+	return request.IDToken != nil ||
+		request.TriggerReason == transactions.TriggerReasonAuthorized ||
+		request.TriggerReason == transactions.TriggerReasonRemoteStart ||
+		request.TransactionInfo.ChargingState == transactions.ChargingStateCharging
+}
+
+func (conn *Connector) OnTransactionEvent(request *transactions.TransactionEventRequest) (*transactions.TransactionEventResponse, error) {
+	// meter values precede the transaction state update since ending the transaction resets the meter
+	if len(request.MeterValue) > 0 {
+		conn.cp.OnMeterValues(&core.MeterValuesRequest{
+			ConnectorId: conn.id,
+			MeterValue:  meterValuesV201(request.MeterValue),
+		})
+	}
+
+	conn.mu.Lock()
+
+	info := request.TransactionInfo
+
+	switch {
+	case request.EventType == transactions.TransactionEventStarted:
+		conn.txnRef = info.TransactionID
+		conn.finished = false
+
+	case conn.txnRef == "" && request.EventType == transactions.TransactionEventUpdated:
+		conn.log.DEBUG.Printf("recovered transaction: %s", info.TransactionID)
+		conn.txnRef = info.TransactionID
+	}
+
+	if info.ChargingState != "" {
+		conn.chargingState = info.ChargingState
+	}
+
+	if request.IDToken != nil {
+		conn.idTag = request.IDToken.IdToken
+	}
+
+	if request.EventType == transactions.TransactionEventEnded {
+		conn.txnId = 0
+		conn.txnRef = ""
+		conn.idTag = ""
+		conn.needs = nil
+		conn.chargingState = ""
+		conn.finished = true
+
+		conn.assumeMeterStopped()
+	} else {
+		// transaction events imply a connected vehicle
+		if conn.connectorStatus == "" || conn.connectorStatus == availability.ConnectorStatusAvailable {
+			conn.connectorStatus = availability.ConnectorStatusOccupied
+		}
+
+		// OCPP 1.6 transactions only start after authorization
+		if conn.txnId == 0 && isAuthorizedV201(request) {
+			conn.txnId = int(instance.txnId.Add(1))
+		}
+	}
+
+	status := conn.statusRequest(request.Timestamp)
+	loadpoints := conn.loadpoints()
+	conn.mu.Unlock()
+
+	conn.OnStatusNotification(status)
+
+	return transactionEventResponse(request, loadpoints, conn.remoteIdTag), nil
+}
+
+func (conn *Connector) OnNotifyEVChargingNeeds(request *smartcharging.NotifyEVChargingNeedsRequest) (*smartcharging.NotifyEVChargingNeedsResponse, error) {
+	conn.mu.Lock()
+
+	needs := request.ChargingNeeds
+	conn.needs = &needs
+
+	if dc := needs.DCChargingParameters; dc != nil && dc.StateOfCharge != nil {
+		conn.measurements[types.MeasurandSoC] = types.SampledValue{
+			Value: strconv.Itoa(*dc.StateOfCharge),
+			Unit:  types.UnitOfMeasurePercent,
+		}
+	}
+
+	profile := conn.profile
+	conn.mu.Unlock()
+
+	// ISO 15118 expects a new charging profile matching the charging needs
+	if profile != nil {
+		go func() {
+			if err := conn.SetChargingProfileRequestV201(profile); err != nil {
+				conn.log.ERROR.Printf("set charging profile: %v", err)
+			}
+		}()
+	}
+
+	res := &smartcharging.NotifyEVChargingNeedsResponse{
+		Status: smartcharging.EVChargingNeedsStatusAccepted,
+	}
+
+	return res, nil
+}
+
+// phasesV201 returns the number of phases of the requested energy transfer mode
+func phasesV201(mode smartcharging.EnergyTransferMode) int {
+	switch mode {
+	case smartcharging.EnergyTransferModeAC1Phase:
+		return 1
+	case smartcharging.EnergyTransferModeAC2Phase:
+		return 2
+	case smartcharging.EnergyTransferModeAC3Phase:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// chargingProfileV201 returns a transaction profile applying the ISO 15118 charging needs of the running transaction
+// to the charging profile or nil if no charging needs are known.
+// Must only be called while holding lock.
+func (conn *Connector) chargingProfileV201(profile *types201.ChargingProfile) *types201.ChargingProfile {
+	if conn.needs == nil || conn.txnRef == "" || len(profile.ChargingSchedule) == 0 {
+		return nil
+	}
+
+	res := *profile
+	res.ID = profile.ID + 1 // keep default profile
+	res.ChargingProfilePurpose = types201.ChargingProfilePurposeTxProfile
+	res.TransactionID = conn.txnRef
+	res.ChargingSchedule = slices.Clone(profile.ChargingSchedule)
+
+	schedule := &res.ChargingSchedule[0]
+	schedule.ChargingSchedulePeriod = slices.Clone(schedule.ChargingSchedulePeriod)
+
+	if t := conn.needs.DepartureTime; t != nil {
+		if d := t.Time.Sub(conn.clock.Now()); d > 0 {
+			duration := int(d.Seconds())
+			schedule.Duration = &duration
+		}
+	}
+
+	ac, dc := conn.needs.ACChargingParameters, conn.needs.DCChargingParameters
+	phases := phasesV201(conn.needs.RequestedEnergyTransfer)
+
+	for i := range schedule.ChargingSchedulePeriod {
+		period := &schedule.ChargingSchedulePeriod[i]
+
+		if period.NumberPhases == nil && phases > 0 {
+			period.NumberPhases = &phases
+		}
+
+		switch {
+		case schedule.ChargingRateUnit == types201.ChargingRateUnitAmperes && ac != nil && ac.EVMaxCurrent > 0:
+			period.Limit = math.Min(period.Limit, float64(ac.EVMaxCurrent))
+		case schedule.ChargingRateUnit == types201.ChargingRateUnitWatts && dc != nil && dc.EVMaxPower != nil:
+			period.Limit = math.Min(period.Limit, float64(*dc.EVMaxPower))
+		}
+	}
+
+	if schedule.ChargingRateUnit == types201.ChargingRateUnitAmperes && ac != nil && ac.EVMinCurrent > 0 {
+		rate := float64(ac.EVMinCurrent)
+		schedule.MinChargingRate = &rate
+	}
+
+	return &res
+}
+
+// meterValuesV201 converts OCPP 2.0.1 meter values to OCPP 1.6
+func meterValuesV201(values []types201.MeterValue) []types.MeterValue {
+	res := make([]types.MeterValue, 0, len(values))
+
+	for _, mv := range values {
+		samples := make([]types.SampledValue, 0, len(mv.SampledValue))
+		for _, s := range mv.SampledValue {
+			samples = append(samples, sampledValueV201(s))
+		}
+
+		res = append(res, types.MeterValue{
+			Timestamp:    types.NewDateTime(mv.Timestamp.Time),
+			SampledValue: samples,
+		})
+	}
+
+	return res
+}
+
+func sampledValueV201(s types201.SampledValue) types.SampledValue {
+	value := s.Value
+
+	var unit types.UnitOfMeasure
+	if u := s.UnitOfMeasure; u != nil {
+		unit = types.UnitOfMeasure(u.Unit)
+		if u.Multiplier != nil {
+			value *= math.Pow10(*u.Multiplier)
+		}
+	}
+
+	measurand := types.Measurand(s.Measurand)
+	if measurand == "" {
+		measurand = types.MeasurandEnergyActiveImportRegister
+	}
+
+	return types.SampledValue{
+		Value:     strconv.FormatFloat(value, 'f', -1, 64),
+		Context:   types.ReadingContext(s.Context),
+		Measurand: measurand,
+		Phase:     types.Phase(s.Phase),
+		Location:  types.Location(s.Location),
+		Unit:      unit,
+	}
+}
@@ -0,0 +1,125 @@
+package ocpp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *connTestSuite) transactionEvent(eventType transactions.TransactionEvent, trigger transactions.TriggerReason, state transactions.ChargingState) *transactions.TransactionEventRequest {
+	return &transactions.TransactionEventRequest{
+		EventType:     eventType,
+		Timestamp:     types201.NewDateTime(suite.clock.Now()),
+		TriggerReason: trigger,
+		TransactionInfo: transactions.Transaction{
+			TransactionID: "txn",
+			ChargingState: state,
+		},
+		Evse: &types201.EVSE{ID: 1},
+	}
+}
+
+func (suite *connTestSuite) TestTransactionEventV201() {
+	// vehicle connected, not authorized
+	_, err := suite.cp.OnTransactionEvent(suite.transactionEvent(transactions.TransactionEventStarted, transactions.TriggerReasonCablePluggedIn, transactions.ChargingStateEVConnected))
+	suite.NoError(err)
+
+	status, err := suite.conn.Status()
+	suite.NoError(err)
+	suite.Equal(core.ChargePointStatusPreparing, status)
+	suite.True(suite.conn.NeedsAuthentication())
+
+	// authorized and charging
+	suite.clock.Add(time.Second)
+	request := suite.transactionEvent(transactions.TransactionEventUpdated, transactions.TriggerReasonAuthorized, transactions.ChargingStateCharging)
+	request.IDToken = &types201.IdToken{IdToken: "tag", Type: types201.IdTokenTypeISO14443}
+	request.Evse = nil // not required for subsequent events
+
+	res, err := suite.cp.OnTransactionEvent(request)
+	suite.NoError(err)
+	suite.Equal(types201.AuthorizationStatusAccepted, res.IDTokenInfo.Status)
+
+	status, err = suite.conn.Status()
+	suite.NoError(err)
+	suite.Equal(core.ChargePointStatusCharging, status)
+	suite.False(suite.conn.NeedsAuthentication())
+	suite.Equal("tag", suite.conn.IdTag())
+
+	txn, err := suite.conn.TransactionID()
+	suite.NoError(err)
+	suite.NotZero(txn)
+
+	// transaction ended
+	suite.clock.Add(time.Second)
+	_, err = suite.cp.OnTransactionEvent(suite.transactionEvent(transactions.TransactionEventEnded, transactions.TriggerReasonStopAuthorized, ""))
+	suite.NoError(err)
+
+	status, err = suite.conn.Status()
+	suite.NoError(err)
+	suite.Equal(core.ChargePointStatusFinishing, status)
+
+	txn, err = suite.conn.TransactionID()
+	suite.NoError(err)
+	suite.Zero(txn)
+}
+
+func (suite *connTestSuite) TestChargingProfileV201() {
+	profile := &types201.ChargingProfile{
+		ID:                     1,
+		ChargingProfilePurpose: types201.ChargingProfilePurposeTxDefaultProfile,
+		ChargingProfileKind:    types201.ChargingProfileKindAbsolute,
+		ChargingSchedule: []types201.ChargingSchedule{{
+			ChargingRateUnit:       types201.ChargingRateUnitAmperes,
+			ChargingSchedulePeriod: []types201.ChargingSchedulePeriod{{Limit: 16}},
+		}},
+	}
+
+	// no charging needs
+	suite.Nil(suite.conn.chargingProfileV201(profile))
+
+	suite.conn.txnRef = "txn"
+	suite.conn.needs = &smartcharging.ChargingNeeds{
+		RequestedEnergyTransfer: smartcharging.EnergyTransferModeAC1Phase,
+		DepartureTime:           types201.NewDateTime(suite.clock.Now().Add(time.Hour)),
+		ACChargingParameters: &smartcharging.ACChargingParameters{
+			EVMinCurrent: 6,
+			EVMaxCurrent: 10,
+		},
+	}
+
+	res := suite.conn.chargingProfileV201(profile)
+	suite.Require().NotNil(res)
+	suite.Equal(2, res.ID)
+	suite.Equal(types201.ChargingProfilePurposeTxProfile, res.ChargingProfilePurpose)
+	suite.Equal("txn", res.TransactionID)
+
+	schedule := res.ChargingSchedule[0]
+	suite.Equal(3600, *schedule.Duration)
+	suite.Equal(6.0, *schedule.MinChargingRate)
+	suite.Equal(10.0, schedule.ChargingSchedulePeriod[0].Limit)
+	suite.Equal(1, *schedule.ChargingSchedulePeriod[0].NumberPhases)
+
+	// default profile unchanged
+	suite.Equal(16.0, profile.ChargingSchedule[0].ChargingSchedulePeriod[0].Limit)
+	suite.Nil(profile.ChargingSchedule[0].ChargingSchedulePeriod[0].NumberPhases)
+}
+
+func TestSampledValueV201(t *testing.T) {
+	multiplier := 3
+
+	res := sampledValueV201(types201.SampledValue{
+		Value: 1.5,
+		UnitOfMeasure: &types201.UnitOfMeasure{
+			Unit:       "Wh",
+			Multiplier: &multiplier,
+		},
+	})
+
+	assert.Equal(t, "1500", res.Value)
+	assert.Equal(t, "Energy.Active.Import.Register", string(res.Measurand))
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
 )
 
 func (conn *Connector) ChangeAvailabilityRequest(availabilityType core.AvailabilityType) error {
@@ -20,9 +21,37 @@ func (conn *Connector) RemoteStartTransactionRequest(idTag string) error {
 }
 
 func (conn *Connector) SetChargingProfileRequest(profile *types.ChargingProfile) error {
+	if conn.cp.Protocol() == ProtocolV201 {
+		profile := chargingProfileToV201(profile)
+
+		conn.mu.Lock()
+		conn.profile = profile
+		conn.mu.Unlock()
+
+		return conn.SetChargingProfileRequestV201(profile)
+	}
+
 	return conn.cp.SetChargingProfileRequest(conn.id, profile)
 }
 
+// SetChargingProfileRequestV201 sets the default charging profile and, if ISO 15118 charging needs are known,
+// a transaction profile limited by the charging needs of the running transaction
+func (conn *Connector) SetChargingProfileRequestV201(profile *types201.ChargingProfile) error {
+	conn.mu.Lock()
+	txProfile := conn.chargingProfileV201(profile)
+	conn.mu.Unlock()
+
+	if err := conn.cp.SetChargingProfileRequestV201(conn.id, profile); err != nil {
+		return err
+	}
+
+	if txProfile != nil {
+		return conn.cp.SetChargingProfileRequestV201(conn.id, txProfile)
+	}
+
+	return nil
+}
+
 func (conn *Connector) TriggerMessageRequest(requestedMessage remotetrigger.MessageTrigger) error {
 	return conn.cp.TriggerMessageRequest(conn.id, requestedMessage)
 }
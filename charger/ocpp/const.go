@@ -16,6 +16,7 @@ const (
 	// SmartCharging profile keys
 	KeyChargeProfileMaxStackLevel              = "ChargeProfileMaxStackLevel"
 	KeyChargingScheduleAllowedChargingRateUnit = "ChargingScheduleAllowedChargingRateUnit"
+	KeyChargingScheduleMaxPeriods              = "ChargingScheduleMaxPeriods"
 	KeyConnectorSwitch3to1PhaseSupported       = "ConnectorSwitch3to1PhaseSupported"
 	KeyMaxChargingProfilesInstalled            = "MaxChargingProfilesInstalled"
 
@@ -17,7 +17,8 @@ type CP struct {
 	onceConnect sync.Once
 	onceBoot    sync.Once
 
-	id string
+	id       string
+	protocol string
 
 	connected bool
 	connectC  chan struct{}
@@ -29,6 +30,7 @@ type CP struct {
 	ChargingRateUnit        types.ChargingRateUnitType
 	ChargingProfileId       int
 	StackLevel              int
+	MaxSchedulePeriods      int
 	NumberOfConnectors      int
 	IdTag                   string
 
@@ -94,6 +96,19 @@ func (cp *CP) connectorByTransactionID(id int) *Connector {
 	return nil
 }
 
+func (cp *CP) connectorByTransactionRef(ref string) *Connector {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	for _, conn := range cp.connectors {
+		if conn.transactionRef() == ref {
+			return conn
+		}
+	}
+
+	return nil
+}
+
 func (cp *CP) ID() string {
 	cp.mu.RLock()
 	defer cp.mu.RUnlock()
@@ -116,12 +131,24 @@ func (cp *CP) connect(connect bool) {
 	cp.mu.Lock()
 	defer cp.mu.Unlock()
 
+	reconnect := connect && !cp.connected
 	cp.connected = connect
 
 	if connect {
+		cp.protocol = instance.Protocol(cp.id)
+
+		first := false
 		cp.onceConnect.Do(func() {
+			first = true
 			close(cp.connectC)
 		})
+
+		// signal connectors to reconcile their state
+		if reconnect && !first {
+			for _, conn := range cp.connectors {
+				conn.reconnected()
+			}
+		}
 	}
 }
 
@@ -132,6 +159,14 @@ func (cp *CP) Connected() bool {
 	return cp.connected
 }
 
+// Protocol returns the protocol version negotiated by the charge point
+func (cp *CP) Protocol() string {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return cp.protocol
+}
+
 func (cp *CP) HasConnected() <-chan struct{} {
 	return cp.connectC
 }
@@ -0,0 +1,57 @@
+package ocpp
+
+import (
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+func (cp *CP) OnTransactionEvent(request *transactions.TransactionEventRequest) (*transactions.TransactionEventResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	// evse is only required for the first event of a transaction
+	conn := cp.connectorByTransactionRef(request.TransactionInfo.TransactionID)
+	if conn == nil && request.Evse != nil {
+		conn = cp.connectorByID(request.Evse.ID)
+	}
+
+	if conn != nil {
+		return conn.OnTransactionEvent(request)
+	}
+
+	return transactionEventResponse(request, cp.loadpoints(), cp.remoteIdTags()...), nil
+}
+
+func (cp *CP) OnNotifyEVChargingNeeds(request *smartcharging.NotifyEVChargingNeedsRequest) (*smartcharging.NotifyEVChargingNeedsResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	if conn := cp.connectorByID(request.EvseID); conn != nil {
+		return conn.OnNotifyEVChargingNeeds(request)
+	}
+
+	res := &smartcharging.NotifyEVChargingNeedsResponse{
+		Status: smartcharging.EVChargingNeedsStatusRejected,
+	}
+
+	return res, nil
+}
+
+// transactionEventResponse accepts the transaction event and authorizes any id token contained
+func transactionEventResponse(request *transactions.TransactionEventRequest, loadpoints []loadpoint.API, remote ...string) *transactions.TransactionEventResponse {
+	res := new(transactions.TransactionEventResponse)
+
+	if request.IDToken != nil {
+		status := types201.AuthorizationStatusAccepted
+		if !authorized(request.IDToken.IdToken, loadpoints, remote...) {
+			status = types201.AuthorizationStatusInvalid
+		}
+		res.IDTokenInfo = types201.NewIdTokenInfo(status)
+	}
+
+	return res
+}
@@ -10,6 +10,10 @@ import (
 )
 
 func (cp *CP) ChangeAvailabilityRequest(connectorId int, availabilityType core.AvailabilityType) error {
+	if cp.Protocol() == ProtocolV201 {
+		return cp.changeAvailabilityRequestV201(connectorId, availabilityType)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().ChangeAvailability(cp.id, func(request *core.ChangeAvailabilityConfirmation, err error) {
@@ -24,6 +28,10 @@ func (cp *CP) ChangeAvailabilityRequest(connectorId int, availabilityType core.A
 }
 
 func (cp *CP) GetCompositeScheduleRequest(connectorId int, duration int) (*smartcharging.GetCompositeScheduleConfirmation, error) {
+	if cp.Protocol() == ProtocolV201 {
+		return cp.getCompositeScheduleRequestV201(connectorId, duration)
+	}
+
 	var res *smartcharging.GetCompositeScheduleConfirmation
 	rc := make(chan error, 1)
 
@@ -41,6 +49,10 @@ func (cp *CP) GetCompositeScheduleRequest(connectorId int, duration int) (*smart
 }
 
 func (cp *CP) RemoteStartTransactionRequest(connectorId int, idTag string) error {
+	if cp.Protocol() == ProtocolV201 {
+		return cp.requestStartTransactionV201(connectorId, idTag)
+	}
+
 	rc := make(chan error, 1)
 	err := Instance().RemoteStartTransaction(cp.id, func(request *core.RemoteStartTransactionConfirmation, err error) {
 		if err == nil && request != nil && request.Status != types.RemoteStartStopStatusAccepted {
@@ -58,6 +70,10 @@ func (cp *CP) RemoteStartTransactionRequest(connectorId int, idTag string) error
 }
 
 func (cp *CP) SetChargingProfileRequest(connectorId int, profile *types.ChargingProfile) error {
+	if cp.Protocol() == ProtocolV201 {
+		return cp.SetChargingProfileRequestV201(connectorId, chargingProfileToV201(profile))
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().SetChargingProfile(cp.id, func(request *smartcharging.SetChargingProfileConfirmation, err error) {
@@ -72,6 +88,10 @@ func (cp *CP) SetChargingProfileRequest(connectorId int, profile *types.Charging
 }
 
 func (cp *CP) TriggerMessageRequest(connectorId int, requestedMessage remotetrigger.MessageTrigger) error {
+	if cp.Protocol() == ProtocolV201 {
+		return cp.triggerMessageRequestV201(connectorId, requestedMessage)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().TriggerMessage(cp.id, func(request *remotetrigger.TriggerMessageConfirmation, err error) {
@@ -90,6 +110,10 @@ func (cp *CP) TriggerMessageRequest(connectorId int, requestedMessage remotetrig
 }
 
 func (cp *CP) ChangeConfigurationRequest(key, value string) error {
+	if cp.Protocol() == ProtocolV201 {
+		return cp.changeConfigurationRequestV201(key, value)
+	}
+
 	rc := make(chan error, 1)
 
 	err := Instance().ChangeConfiguration(cp.id, func(request *core.ChangeConfigurationConfirmation, err error) {
@@ -104,6 +128,10 @@ func (cp *CP) ChangeConfigurationRequest(key, value string) error {
 }
 
 func (cp *CP) GetConfigurationRequest() (*core.GetConfigurationConfirmation, error) {
+	if cp.Protocol() == ProtocolV201 {
+		return cp.getConfigurationRequestV201()
+	}
+
 	rc := make(chan error, 1)
 
 	var res *core.GetConfigurationConfirmation
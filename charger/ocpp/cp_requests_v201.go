@@ -0,0 +1,290 @@
+package ocpp
+
+import (
+	"errors"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
+	smartcharging16 "github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// configVariablesV201 maps OCPP 1.6 configuration keys to OCPP 2.0.1 device model variables
+var configVariablesV201 = map[string]provisioning.GetVariableData{
+	KeyChargeProfileMaxStackLevel: {
+		Component: types201.Component{Name: "SmartChargingCtrlr"},
+		Variable:  types201.Variable{Name: "ProfileStackLevel"},
+	},
+	KeyChargingScheduleAllowedChargingRateUnit: {
+		Component: types201.Component{Name: "SmartChargingCtrlr"},
+		Variable:  types201.Variable{Name: "RateUnit"},
+	},
+	KeyChargingScheduleMaxPeriods: {
+		Component: types201.Component{Name: "SmartChargingCtrlr"},
+		Variable:  types201.Variable{Name: "PeriodsPerSchedule"},
+	},
+	KeyConnectorSwitch3to1PhaseSupported: {
+		Component: types201.Component{Name: "SmartChargingCtrlr"},
+		Variable:  types201.Variable{Name: "Phases3to1"},
+	},
+	KeyMaxChargingProfilesInstalled: {
+		Component: types201.Component{Name: "SmartChargingCtrlr"},
+		Variable:  types201.Variable{Name: "Entries", Instance: "ChargingProfiles"},
+	},
+	KeyMeterValuesSampledData: {
+		Component: types201.Component{Name: "SampledDataCtrlr"},
+		Variable:  types201.Variable{Name: "TxUpdatedMeasurands"},
+	},
+	KeyMeterValueSampleInterval: {
+		Component: types201.Component{Name: "SampledDataCtrlr"},
+		Variable:  types201.Variable{Name: "TxUpdatedInterval"},
+	},
+	KeyWebSocketPingInterval: {
+		Component: types201.Component{Name: "OCPPCommCtrlr"},
+		Variable:  types201.Variable{Name: "WebSocketPingInterval"},
+	},
+}
+
+func (cp *CP) changeAvailabilityRequestV201(evseId int, availabilityType core.AvailabilityType) error {
+	rc := make(chan error, 1)
+
+	status := availability.OperationalStatusOperative
+	if availabilityType == core.AvailabilityTypeInoperative {
+		status = availability.OperationalStatusInoperative
+	}
+
+	err := Instance().csms.ChangeAvailability(cp.id, func(request *availability.ChangeAvailabilityResponse, err error) {
+		if err == nil && request != nil && request.Status == availability.ChangeAvailabilityStatusRejected {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, status, func(request *availability.ChangeAvailabilityRequest) {
+		if evseId > 0 {
+			request.Evse = &types201.EVSE{ID: evseId}
+		}
+	})
+
+	return wait(err, rc)
+}
+
+func (cp *CP) getCompositeScheduleRequestV201(evseId int, duration int) (*smartcharging16.GetCompositeScheduleConfirmation, error) {
+	var res *smartcharging16.GetCompositeScheduleConfirmation
+	rc := make(chan error, 1)
+
+	err := Instance().csms.GetCompositeSchedule(cp.id, func(request *smartcharging.GetCompositeScheduleResponse, err error) {
+		if err == nil && request != nil && request.Status != smartcharging.GetCompositeScheduleStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		if err == nil && request != nil {
+			res = &smartcharging16.GetCompositeScheduleConfirmation{
+				Status:      smartcharging16.GetCompositeScheduleStatusAccepted,
+				ConnectorId: &evseId,
+			}
+
+			if s := request.Schedule; s != nil {
+				if s.StartDateTime != nil {
+					res.ScheduleStart = types.NewDateTime(s.StartDateTime.Time)
+				}
+				if s.ChargingSchedule != nil {
+					res.ChargingSchedule = chargingScheduleToV16(s.ChargingSchedule)
+				}
+			}
+		}
+
+		rc <- err
+	}, duration, evseId)
+
+	return res, wait(err, rc)
+}
+
+func (cp *CP) requestStartTransactionV201(evseId int, idTag string) error {
+	rc := make(chan error, 1)
+
+	idToken := types201.IdToken{
+		IdToken: idTag,
+		Type:    types201.IdTokenTypeCentral,
+	}
+
+	err := Instance().csms.RequestStartTransaction(cp.id, func(request *remotecontrol.RequestStartTransactionResponse, err error) {
+		if err == nil && request != nil && request.Status != remotecontrol.RequestStartStopStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, int(instance.txnId.Add(1)), idToken, func(request *remotecontrol.RequestStartTransactionRequest) {
+		if evseId > 0 {
+			request.EvseID = &evseId
+		}
+	})
+
+	return wait(err, rc)
+}
+
+func (cp *CP) SetChargingProfileRequestV201(evseId int, profile *types201.ChargingProfile) error {
+	rc := make(chan error, 1)
+
+	err := Instance().csms.SetChargingProfile(cp.id, func(request *smartcharging.SetChargingProfileResponse, err error) {
+		if err == nil && request != nil && request.Status != smartcharging.ChargingProfileStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, evseId, profile)
+
+	return wait(err, rc)
+}
+
+func (cp *CP) triggerMessageRequestV201(evseId int, requestedMessage remotetrigger.MessageTrigger) error {
+	rc := make(chan error, 1)
+
+	err := Instance().csms.TriggerMessage(cp.id, func(request *remotecontrol.TriggerMessageResponse, err error) {
+		if err == nil && request != nil && request.Status != remotecontrol.TriggerMessageStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, remotecontrol.MessageTrigger(requestedMessage), func(request *remotecontrol.TriggerMessageRequest) {
+		if evseId > 0 {
+			request.Evse = &types201.EVSE{ID: evseId}
+		}
+	})
+
+	return wait(err, rc)
+}
+
+func (cp *CP) changeConfigurationRequestV201(key, value string) error {
+	v, ok := configVariablesV201[key]
+	if !ok {
+		return errors.New(string(provisioning.SetVariableStatusUnknownVariable))
+	}
+
+	rc := make(chan error, 1)
+
+	err := Instance().csms.SetVariables(cp.id, func(request *provisioning.SetVariablesResponse, err error) {
+		if err == nil && request != nil {
+			for _, r := range request.SetVariableResult {
+				if r.AttributeStatus != provisioning.SetVariableStatusAccepted {
+					err = errors.New(string(r.AttributeStatus))
+				}
+			}
+		}
+
+		rc <- err
+	}, []provisioning.SetVariableData{{
+		AttributeValue: value,
+		Component:      v.Component,
+		Variable:       v.Variable,
+	}})
+
+	return wait(err, rc)
+}
+
+// getConfigurationRequestV201 queries the device model variables matching the OCPP 1.6 configuration keys
+func (cp *CP) getConfigurationRequestV201() (*core.GetConfigurationConfirmation, error) {
+	keys := make(map[provisioning.GetVariableData]string, len(configVariablesV201))
+	data := make([]provisioning.GetVariableData, 0, len(configVariablesV201))
+
+	for key, v := range configVariablesV201 {
+		keys[v] = key
+		data = append(data, v)
+	}
+
+	rc := make(chan error, 1)
+
+	var res *core.GetConfigurationConfirmation
+	err := Instance().csms.GetVariables(cp.id, func(request *provisioning.GetVariablesResponse, err error) {
+		if err == nil && request != nil {
+			res = new(core.GetConfigurationConfirmation)
+
+			for _, r := range request.GetVariableResult {
+				key, ok := keys[provisioning.GetVariableData{Component: r.Component, Variable: r.Variable}]
+				if !ok || r.AttributeStatus != provisioning.GetVariableStatusAccepted {
+					continue
+				}
+
+				value := r.AttributeValue
+				res.ConfigurationKey = append(res.ConfigurationKey, core.ConfigurationKey{
+					Key:   key,
+					Value: &value,
+				})
+			}
+		}
+
+		rc <- err
+	}, data)
+
+	return res, wait(err, rc)
+}
+
+// chargingProfileToV201 converts an OCPP 1.6 charging profile to OCPP 2.0.1
+func chargingProfileToV201(profile *types.ChargingProfile) *types201.ChargingProfile {
+	res := &types201.ChargingProfile{
+		ID:                     profile.ChargingProfileId,
+		StackLevel:             profile.StackLevel,
+		ChargingProfilePurpose: types201.ChargingProfilePurposeType(profile.ChargingProfilePurpose),
+		ChargingProfileKind:    types201.ChargingProfileKindType(profile.ChargingProfileKind),
+		RecurrencyKind:         types201.RecurrencyKindType(profile.RecurrencyKind),
+	}
+
+	if profile.ValidFrom != nil {
+		res.ValidFrom = types201.NewDateTime(profile.ValidFrom.Time)
+	}
+	if profile.ValidTo != nil {
+		res.ValidTo = types201.NewDateTime(profile.ValidTo.Time)
+	}
+
+	if s := profile.ChargingSchedule; s != nil {
+		schedule := types201.ChargingSchedule{
+			ID:               profile.ChargingProfileId,
+			Duration:         s.Duration,
+			ChargingRateUnit: types201.ChargingRateUnitType(s.ChargingRateUnit),
+			MinChargingRate:  s.MinChargingRate,
+		}
+
+		if s.StartSchedule != nil {
+			schedule.StartSchedule = types201.NewDateTime(s.StartSchedule.Time)
+		}
+
+		for _, p := range s.ChargingSchedulePeriod {
+			schedule.ChargingSchedulePeriod = append(schedule.ChargingSchedulePeriod, types201.ChargingSchedulePeriod{
+				StartPeriod:  p.StartPeriod,
+				Limit:        p.Limit,
+				NumberPhases: p.NumberPhases,
+			})
+		}
+
+		res.ChargingSchedule = []types201.ChargingSchedule{schedule}
+	}
+
+	return res
+}
+
+// chargingScheduleToV16 converts an OCPP 2.0.1 charging schedule to OCPP 1.6
+func chargingScheduleToV16(s *types201.ChargingSchedule) *types.ChargingSchedule {
+	res := &types.ChargingSchedule{
+		Duration:         s.Duration,
+		ChargingRateUnit: types.ChargingRateUnitType(s.ChargingRateUnit),
+		MinChargingRate:  s.MinChargingRate,
+	}
+
+	if s.StartSchedule != nil {
+		res.StartSchedule = types.NewDateTime(s.StartSchedule.Time)
+	}
+
+	for _, p := range s.ChargingSchedulePeriod {
+		res.ChargingSchedulePeriod = append(res.ChargingSchedulePeriod, types.ChargingSchedulePeriod{
+			StartPeriod:  p.StartPeriod,
+			Limit:        p.Limit,
+			NumberPhases: p.NumberPhases,
+		})
+	}
+
+	return res
+}
@@ -55,6 +55,11 @@ func (cp *CP) Setup(ctx context.Context, meterValues string, meterInterval time.
 				cp.PhaseSwitching = true // assume phase switching is available for power-based charging
 			}
 
+		case match(KeyChargingScheduleMaxPeriods):
+			if val, err := strconv.Atoi(*opt.Value); err == nil {
+				cp.MaxSchedulePeriods = val
+			}
+
 		case match(KeyConnectorSwitch3to1PhaseSupported) || match(KeyChargeAmpsPhaseSwitchingSupported):
 			var val bool
 			if val, err = strconv.ParseBool(*opt.Value); err == nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
 )
 
 type registration struct {
@@ -24,6 +25,8 @@ func newRegistration() *registration {
 
 type CS struct {
 	ocpp16.CentralSystem
+	csms  ocpp201.CSMS
+	mux   *mux
 	mu    sync.Mutex
 	log   *util.Logger
 	regs  map[string]*registration // guarded by mu mutex
@@ -51,6 +54,11 @@ func (cs *CS) ChargepointByID(id string) (*CP, error) {
 	return reg.cp, nil
 }
 
+// Protocol returns the negotiated protocol version of a connected charge point
+func (cs *CS) Protocol(id string) string {
+	return cs.mux.Protocol(id)
+}
+
 func (cs *CS) WithConnectorStatus(id string, connector int, fun func(status *core.StatusNotificationRequest)) {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
@@ -64,6 +72,18 @@ func (cs *CS) WithConnectorStatus(id string, connector int, fun func(status *cor
 	}
 }
 
+// cacheStatus stores the connector status for applying to connectors created later
+func (cs *CS) cacheStatus(id string, status *core.StatusNotificationRequest) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if reg, ok := cs.regs[id]; ok {
+		reg.mu.Lock()
+		reg.status[status.ConnectorId] = status
+		reg.mu.Unlock()
+	}
+}
+
 // RegisterChargepoint registers a charge point with the central system of returns an already registered charge point
 func (cs *CS) RegisterChargepoint(id string, newfun func() *CP, init func(*CP) error) (*CP, error) {
 	cs.mu.Lock()
@@ -109,7 +129,7 @@ func (cs *CS) RegisterChargepoint(id string, newfun func() *CP, init func(*CP) e
 	return cp, init(cp)
 }
 
-// NewChargePoint implements ocpp16.ChargePointConnectionHandler
+// NewChargePoint implements ocpp16.ChargePointConnectionHandler and ocpp201.ChargingStationConnectionHandler
 func (cs *CS) NewChargePoint(chargePoint ocpp16.ChargePointConnection) {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
@@ -150,7 +170,7 @@ func (cs *CS) NewChargePoint(chargePoint ocpp16.ChargePointConnection) {
 	cs.regs[chargePoint.ID()] = newRegistration()
 }
 
-// ChargePointDisconnected implements ocpp16.ChargePointConnectionHandler
+// ChargePointDisconnected implements ocpp16.ChargePointConnectionHandler and ocpp201.ChargingStationConnectionHandler
 func (cs *CS) ChargePointDisconnected(chargePoint ocpp16.ChargePointConnection) {
 	cs.log.DEBUG.Printf("charge point disconnected: %s", chargePoint.ID())
 
@@ -1,6 +1,7 @@
 package ocpp
 
 import (
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 )
@@ -10,9 +11,23 @@ import (
 func (cs *CS) OnAuthorize(id string, request *core.AuthorizeRequest) (*core.AuthorizeConfirmation, error) {
 	// no cp handler
 
+	var (
+		remote     []string
+		loadpoints []loadpoint.API
+	)
+	if cp, err := cs.ChargepointByID(id); err == nil {
+		remote = cp.remoteIdTags()
+		loadpoints = cp.loadpoints()
+	}
+
+	status := types.AuthorizationStatusAccepted
+	if !authorized(request.IdTag, loadpoints, remote...) {
+		status = types.AuthorizationStatusInvalid
+	}
+
 	res := &core.AuthorizeConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted,
+			Status: status,
 		},
 	}
 
@@ -62,14 +77,10 @@ func (cs *CS) OnMeterValues(id string, request *core.MeterValuesRequest) (*core.
 }
 
 func (cs *CS) OnStatusNotification(id string, request *core.StatusNotificationRequest) (*core.StatusNotificationConfirmation, error) {
-	cs.mu.Lock()
 	// cache status for future cp connection
-	if reg, ok := cs.regs[id]; ok && request != nil {
-		reg.mu.Lock()
-		reg.status[request.ConnectorId] = request
-		reg.mu.Unlock()
+	if request != nil {
+		cs.cacheStatus(id, request)
 	}
-	cs.mu.Unlock()
 
 	if cp, err := cs.ChargepointByID(id); err == nil {
 		return cp.OnStatusNotification(request)
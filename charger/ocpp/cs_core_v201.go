@@ -0,0 +1,217 @@
+package ocpp
+
+import (
+	"github.com/evcc-io/evcc/core/loadpoint"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/data"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/security"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+)
+
+// csmsHandler implements the OCPP 2.0.1 CSMS handlers.
+// Messages are mapped onto the OCPP 1.6 based charge point and connector model.
+type csmsHandler struct {
+	cs *CS
+}
+
+// cs actions
+
+func (h *csmsHandler) OnAuthorize(id string, request *authorization.AuthorizeRequest) (*authorization.AuthorizeResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	// no cp handler
+
+	var (
+		remote     []string
+		loadpoints []loadpoint.API
+	)
+	if cp, err := h.cs.ChargepointByID(id); err == nil {
+		remote = cp.remoteIdTags()
+		loadpoints = cp.loadpoints()
+	}
+
+	status := types201.AuthorizationStatusAccepted
+	if !authorized(request.IdToken.IdToken, loadpoints, remote...) {
+		status = types201.AuthorizationStatusInvalid
+	}
+
+	res := &authorization.AuthorizeResponse{
+		IdTokenInfo: *types201.NewIdTokenInfo(status),
+	}
+
+	return res, nil
+}
+
+func (h *csmsHandler) OnBootNotification(id string, request *provisioning.BootNotificationRequest) (*provisioning.BootNotificationResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	conf, err := h.cs.OnBootNotification(id, &core.BootNotificationRequest{
+		ChargePointVendor:       request.ChargingStation.VendorName,
+		ChargePointModel:        request.ChargingStation.Model,
+		ChargePointSerialNumber: request.ChargingStation.SerialNumber,
+		FirmwareVersion:         request.ChargingStation.FirmwareVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &provisioning.BootNotificationResponse{
+		CurrentTime: types201.Now(),
+		Interval:    conf.Interval,
+		Status:      provisioning.RegistrationStatus(conf.Status),
+	}
+
+	return res, nil
+}
+
+func (h *csmsHandler) OnNotifyReport(id string, request *provisioning.NotifyReportRequest) (*provisioning.NotifyReportResponse, error) {
+	return new(provisioning.NotifyReportResponse), nil
+}
+
+func (h *csmsHandler) OnDataTransfer(id string, request *data.DataTransferRequest) (*data.DataTransferResponse, error) {
+	// no cp handler
+
+	res := &data.DataTransferResponse{
+		Status: data.DataTransferStatusAccepted,
+	}
+
+	return res, nil
+}
+
+func (h *csmsHandler) OnHeartbeat(id string, request *availability.HeartbeatRequest) (*availability.HeartbeatResponse, error) {
+	// no cp handler
+
+	res := &availability.HeartbeatResponse{
+		CurrentTime: *types201.Now(),
+	}
+
+	return res, nil
+}
+
+func (h *csmsHandler) OnStatusNotification(id string, request *availability.StatusNotificationRequest) (*availability.StatusNotificationResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	// cache status for future cp connection
+	h.cs.cacheStatus(id, statusRequestV201(request))
+
+	if cp, err := h.cs.ChargepointByID(id); err == nil {
+		if conn := cp.connectorByID(request.EvseID); conn != nil {
+			conn.OnConnectorStatusV201(request)
+		}
+	}
+
+	return new(availability.StatusNotificationResponse), nil
+}
+
+func (h *csmsHandler) OnMeterValues(id string, request *meter.MeterValuesRequest) (*meter.MeterValuesResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	if _, err := h.cs.OnMeterValues(id, &core.MeterValuesRequest{
+		ConnectorId: request.EvseID,
+		MeterValue:  meterValuesV201(request.MeterValue),
+	}); err != nil {
+		return nil, err
+	}
+
+	return new(meter.MeterValuesResponse), nil
+}
+
+func (h *csmsHandler) OnTransactionEvent(id string, request *transactions.TransactionEventRequest) (*transactions.TransactionEventResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	if cp, err := h.cs.ChargepointByID(id); err == nil {
+		return cp.OnTransactionEvent(request)
+	}
+
+	return transactionEventResponse(request, nil), nil
+}
+
+// smart charging
+
+func (h *csmsHandler) OnNotifyEVChargingNeeds(id string, request *smartcharging.NotifyEVChargingNeedsRequest) (*smartcharging.NotifyEVChargingNeedsResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidRequest
+	}
+
+	if cp, err := h.cs.ChargepointByID(id); err == nil {
+		return cp.OnNotifyEVChargingNeeds(request)
+	}
+
+	res := &smartcharging.NotifyEVChargingNeedsResponse{
+		Status: smartcharging.EVChargingNeedsStatusRejected,
+	}
+
+	return res, nil
+}
+
+func (h *csmsHandler) OnNotifyEVChargingSchedule(id string, request *smartcharging.NotifyEVChargingScheduleRequest) (*smartcharging.NotifyEVChargingScheduleResponse, error) {
+	res := &smartcharging.NotifyEVChargingScheduleResponse{
+		Status: types201.GenericStatusAccepted,
+	}
+
+	return res, nil
+}
+
+func (h *csmsHandler) OnNotifyChargingLimit(id string, request *smartcharging.NotifyChargingLimitRequest) (*smartcharging.NotifyChargingLimitResponse, error) {
+	return new(smartcharging.NotifyChargingLimitResponse), nil
+}
+
+func (h *csmsHandler) OnClearedChargingLimit(id string, request *smartcharging.ClearedChargingLimitRequest) (*smartcharging.ClearedChargingLimitResponse, error) {
+	return new(smartcharging.ClearedChargingLimitResponse), nil
+}
+
+func (h *csmsHandler) OnReportChargingProfiles(id string, request *smartcharging.ReportChargingProfilesRequest) (*smartcharging.ReportChargingProfilesResponse, error) {
+	return new(smartcharging.ReportChargingProfilesResponse), nil
+}
+
+// diagnostics
+
+func (h *csmsHandler) OnLogStatusNotification(id string, request *diagnostics.LogStatusNotificationRequest) (*diagnostics.LogStatusNotificationResponse, error) {
+	return new(diagnostics.LogStatusNotificationResponse), nil
+}
+
+func (h *csmsHandler) OnNotifyCustomerInformation(id string, request *diagnostics.NotifyCustomerInformationRequest) (*diagnostics.NotifyCustomerInformationResponse, error) {
+	return new(diagnostics.NotifyCustomerInformationResponse), nil
+}
+
+func (h *csmsHandler) OnNotifyEvent(id string, request *diagnostics.NotifyEventRequest) (*diagnostics.NotifyEventResponse, error) {
+	return new(diagnostics.NotifyEventResponse), nil
+}
+
+func (h *csmsHandler) OnNotifyMonitoringReport(id string, request *diagnostics.NotifyMonitoringReportRequest) (*diagnostics.NotifyMonitoringReportResponse, error) {
+	return new(diagnostics.NotifyMonitoringReportResponse), nil
+}
+
+// security
+
+func (h *csmsHandler) OnSecurityEventNotification(id string, request *security.SecurityEventNotificationRequest) (*security.SecurityEventNotificationResponse, error) {
+	return new(security.SecurityEventNotificationResponse), nil
+}
+
+func (h *csmsHandler) OnSignCertificate(id string, request *security.SignCertificateRequest) (*security.SignCertificateResponse, error) {
+	// no certificate authority
+
+	res := &security.SignCertificateResponse{
+		Status: types201.GenericStatusRejected,
+	}
+
+	return res, nil
+}
@@ -11,6 +11,23 @@ import (
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/remotetrigger"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/smartcharging"
+	ocpp201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/authorization"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/availability"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/data"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/diagnostics"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/display"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/firmware"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/iso15118"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/meter"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/provisioning"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/remotecontrol"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/reservation"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/security"
+	smartcharging201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/smartcharging"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/tariffcost"
+	"github.com/lorenzodonini/ocpp-go/ocpp2.0.1/transactions"
 	"github.com/lorenzodonini/ocpp-go/ocppj"
 	"github.com/lorenzodonini/ocpp-go/ws"
 )
@@ -27,21 +44,47 @@ func Instance() *CS {
 		server := ws.NewServer()
 		server.SetCheckOriginHandler(func(r *http.Request) bool { return true })
 
-		dispatcher := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
-		dispatcher.SetTimeout(Timeout)
+		// protocol version is negotiated per connection
+		mux := newMux(server)
 
-		endpoint := ocppj.NewServer(server, dispatcher, nil, core.Profile, remotetrigger.Profile, smartcharging.Profile)
-		endpoint.SetInvalidMessageHook(func(client ws.Channel, err *ocpp.Error, rawMessage string, parsedFields []interface{}) *ocpp.Error {
+		invalidMessageHook := func(client ws.Channel, err *ocpp.Error, rawMessage string, parsedFields []interface{}) *ocpp.Error {
 			log.ERROR.Printf("%v (%s)", err, rawMessage)
 			return nil
-		})
+		}
+
+		// ocpp 1.6
+		server16 := mux.Endpoint(ProtocolV16, true)
+
+		dispatcher := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
+		dispatcher.SetTimeout(Timeout)
+
+		endpoint := ocppj.NewServer(server16, dispatcher, nil, core.Profile, remotetrigger.Profile, smartcharging.Profile)
+		endpoint.SetInvalidMessageHook(invalidMessageHook)
 
-		cs := ocpp16.NewCentralSystem(endpoint, server)
+		cs := ocpp16.NewCentralSystem(endpoint, server16)
+
+		// ocpp 2.0.1
+		server201 := mux.Endpoint(ProtocolV201, false)
+
+		dispatcher201 := ocppj.NewDefaultServerDispatcher(ocppj.NewFIFOQueueMap(0))
+		dispatcher201.SetTimeout(Timeout)
+
+		endpoint201 := ocppj.NewServer(server201, dispatcher201, nil,
+			authorization.Profile, availability.Profile, data.Profile, diagnostics.Profile, display.Profile,
+			firmware.Profile, iso15118.Profile, localauth.Profile, meter.Profile, provisioning.Profile,
+			remotecontrol.Profile, reservation.Profile, security.Profile, smartcharging201.Profile,
+			tariffcost.Profile, transactions.Profile,
+		)
+		endpoint201.SetInvalidMessageHook(invalidMessageHook)
+
+		csms := ocpp201.NewCSMS(endpoint201, server201)
 
 		instance = &CS{
 			log:           log,
 			regs:          make(map[string]*registration),
+			mux:           mux,
 			CentralSystem: cs,
+			csms:          csms,
 		}
 
 		instance.txnId.Store(time.Now().UTC().Unix())
@@ -52,12 +95,34 @@ func Instance() *CS {
 		cs.SetNewChargePointHandler(instance.NewChargePoint)
 		cs.SetChargePointDisconnectedHandler(instance.ChargePointDisconnected)
 
+		handler := &csmsHandler{instance}
+
+		csms.SetAuthorizationHandler(handler)
+		csms.SetAvailabilityHandler(handler)
+		csms.SetDataHandler(handler)
+		csms.SetDiagnosticsHandler(handler)
+		csms.SetMeterHandler(handler)
+		csms.SetProvisioningHandler(handler)
+		csms.SetSecurityHandler(handler)
+		csms.SetSmartChargingHandler(handler)
+		csms.SetTransactionsHandler(handler)
+		csms.SetNewChargingStationHandler(func(cs ocpp201.ChargingStationConnection) {
+			instance.NewChargePoint(cs)
+		})
+		csms.SetChargingStationDisconnectedHandler(func(cs ocpp201.ChargingStationConnection) {
+			instance.ChargePointDisconnected(cs)
+		})
+
 		go instance.errorHandler(cs.Errors())
+		go instance.errorHandler(csms.Errors())
+
+		// secondary endpoint does not block, start before accepting connections
+		csms.Start(8887, "/{ws}")
 		go cs.Start(8887, "/{ws}")
 
 		// wait for server to start
 		for range time.Tick(10 * time.Millisecond) {
-			if dispatcher.IsRunning() {
+			if dispatcher.IsRunning() && dispatcher201.IsRunning() {
 				break
 			}
 		}
@@ -0,0 +1,216 @@
+package ocpp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	types16 "github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	types201 "github.com/lorenzodonini/ocpp-go/ocpp2.0.1/types"
+	"github.com/lorenzodonini/ocpp-go/ws"
+)
+
+// Protocol versions as negotiated on the websocket subprotocol
+const (
+	ProtocolV16  = types16.V16Subprotocol
+	ProtocolV201 = types201.V201Subprotocol
+)
+
+// mux shares a single websocket server between the OCPP protocol endpoints.
+// Each connection is routed to the endpoint matching its negotiated subprotocol.
+type mux struct {
+	ws.Server
+	mu        sync.RWMutex
+	endpoints map[string]*muxEndpoint
+	protocols map[string]string // client id -> negotiated protocol
+}
+
+func newMux(server ws.Server) *mux {
+	m := &mux{
+		Server:    server,
+		endpoints: make(map[string]*muxEndpoint),
+		protocols: make(map[string]string),
+	}
+
+	server.SetCheckClientHandler(m.checkClient)
+	server.SetMessageHandler(m.handleMessage)
+	server.SetNewClientHandler(m.handleConnected)
+	server.SetDisconnectedClientHandler(m.handleDisconnected)
+
+	return m
+}
+
+// Endpoint creates a websocket server view for the given protocol.
+// Only the primary endpoint starts and stops the shared server.
+func (m *mux) Endpoint(protocol string, primary bool) ws.Server {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ep := &muxEndpoint{
+		Server:   m.Server,
+		mux:      m,
+		protocol: protocol,
+		primary:  primary,
+	}
+
+	m.endpoints[protocol] = ep
+
+	return ep
+}
+
+// Protocol returns the negotiated protocol of a connected client
+func (m *mux) Protocol(id string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.protocols[id]
+}
+
+// negotiate returns the first client subprotocol supported by any endpoint
+func (m *mux) negotiate(protocols []string) *muxEndpoint {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, p := range protocols {
+		if ep, ok := m.endpoints[p]; ok {
+			return ep
+		}
+	}
+
+	return nil
+}
+
+func (m *mux) endpoint(id string) *muxEndpoint {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.endpoints[m.protocols[id]]
+}
+
+func (m *mux) checkClient(id string, r *http.Request) bool {
+	ep := m.negotiate(subprotocols(r))
+	if ep == nil {
+		// connection is rejected by the server after upgrade
+		return true
+	}
+
+	// duplicate connections are rejected by the server, keep existing protocol
+	if _, exists := m.GetChannel(id); !exists {
+		m.mu.Lock()
+		m.protocols[id] = ep.protocol
+		m.mu.Unlock()
+	}
+
+	m.mu.RLock()
+	check := ep.checkClient
+	m.mu.RUnlock()
+
+	return check == nil || check(id, r)
+}
+
+func (m *mux) handleMessage(c ws.Channel, data []byte) error {
+	ep := m.endpoint(c.ID())
+	if ep == nil {
+		return fmt.Errorf("no protocol negotiated: %s", c.ID())
+	}
+
+	m.mu.RLock()
+	handler := ep.message
+	m.mu.RUnlock()
+
+	if handler == nil {
+		return fmt.Errorf("endpoint not started: %s", ep.protocol)
+	}
+
+	return handler(c, data)
+}
+
+func (m *mux) handleConnected(c ws.Channel) {
+	if ep := m.endpoint(c.ID()); ep != nil {
+		m.mu.RLock()
+		handler := ep.connected
+		m.mu.RUnlock()
+
+		if handler != nil {
+			handler(c)
+		}
+	}
+}
+
+func (m *mux) handleDisconnected(c ws.Channel) {
+	if ep := m.endpoint(c.ID()); ep != nil {
+		m.mu.RLock()
+		handler := ep.disconnected
+		m.mu.RUnlock()
+
+		if handler != nil {
+			handler(c)
+		}
+	}
+
+	m.mu.Lock()
+	delete(m.protocols, c.ID())
+	m.mu.Unlock()
+}
+
+// subprotocols returns the websocket subprotocols requested by the client in order of preference
+func subprotocols(r *http.Request) []string {
+	var res []string
+	for _, h := range r.Header.Values("Sec-Websocket-Protocol") {
+		for _, p := range strings.Split(h, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
+
+// muxEndpoint is the websocket server as seen by a single protocol endpoint
+type muxEndpoint struct {
+	ws.Server
+	mux      *mux
+	protocol string
+	primary  bool
+
+	// guarded by mux mutex
+	checkClient  ws.CheckClientHandler
+	message      ws.MessageHandler
+	connected    ws.ConnectedHandler
+	disconnected func(ws.Channel)
+}
+
+func (ep *muxEndpoint) Start(port int, listenPath string) {
+	if ep.primary {
+		ep.Server.Start(port, listenPath)
+	}
+}
+
+func (ep *muxEndpoint) Stop() {
+	if ep.primary {
+		ep.Server.Stop()
+	}
+}
+
+func (ep *muxEndpoint) SetCheckClientHandler(handler ws.CheckClientHandler) {
+	ep.mux.mu.Lock()
+	defer ep.mux.mu.Unlock()
+	ep.checkClient = handler
+}
+
+func (ep *muxEndpoint) SetMessageHandler(handler ws.MessageHandler) {
+	ep.mux.mu.Lock()
+	defer ep.mux.mu.Unlock()
+	ep.message = handler
+}
+
+func (ep *muxEndpoint) SetNewClientHandler(handler ws.ConnectedHandler) {
+	ep.mux.mu.Lock()
+	defer ep.mux.mu.Unlock()
+	ep.connected = handler
+}
+
+func (ep *muxEndpoint) SetDisconnectedClientHandler(handler func(ws.Channel)) {
+	ep.mux.mu.Lock()
+	defer ep.mux.mu.Unlock()
+	ep.disconnected = handler
+}
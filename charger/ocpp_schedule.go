@@ -0,0 +1,183 @@
+package charger
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+)
+
+const (
+	scheduleHold    = 5 * time.Minute // validity of the current control value before falling back to the offline schedule
+	scheduleRefresh = scheduleHold / 2
+)
+
+// scheduleWatcher periodically renews the uploaded schedule and reconciles it after reconnects.
+// Must be wrapped in a goroutine.
+func (c *OCPP) scheduleWatcher(ctx context.Context) {
+	tick := time.NewTicker(scheduleRefresh)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.conn.Reconnected():
+			c.log.DEBUG.Println("reconciling charging schedule after reconnect")
+		case <-tick.C:
+		}
+
+		if err := c.refreshSchedule(); err != nil {
+			c.log.WARN.Printf("charging schedule: %v", err)
+		}
+	}
+}
+
+// refreshSchedule uploads the charging schedule for the current control value
+func (c *OCPP) refreshSchedule() error {
+	// not yet controlled by loadpoint
+	if c.lp == nil {
+		return nil
+	}
+
+	sched := c.offlineSchedule()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var current float64
+	if c.enabled {
+		current = c.current
+	}
+
+	return c.setCurrent(current, sched)
+}
+
+// offlineSchedule contains the loadpoint's inputs to the offline schedule
+type offlineSchedule struct {
+	maxCurrent, fallback float64
+	plan                 api.Rates
+}
+
+// offlineSchedule gathers the offline schedule inputs from the loadpoint.
+// Must be called without holding the lock as the loadpoint may be busy controlling the charger.
+// Returns nil if the schedule is disabled.
+func (c *OCPP) offlineSchedule() *offlineSchedule {
+	if !c.schedule || c.lp == nil {
+		return nil
+	}
+
+	return &offlineSchedule{
+		maxCurrent: c.circuitCurrent(c.lp.GetMaxCurrent()),
+		fallback:   c.circuitCurrent(c.fallbackCurrent()),
+		plan:       c.plan(),
+	}
+}
+
+// schedulePeriod returns a charging schedule period for given current
+func (c *OCPP) schedulePeriod(start time.Duration, current float64) types.ChargingSchedulePeriod {
+	phases := c.phases
+	period := types.NewChargingSchedulePeriod(int(start.Seconds()), current)
+
+	if c.cp.ChargingRateUnit == types.ChargingRateUnitWatts {
+		period = types.NewChargingSchedulePeriod(int(start.Seconds()), math.Trunc(230.0*current*float64(phases)))
+	} else {
+		// OCPP assumes phases == 3 if not set
+		if phases != 0 {
+			// set explicit phase configuration
+			period.NumberPhases = &phases
+		}
+	}
+
+	return period
+}
+
+// offlinePeriods returns the schedule periods applied by the charger once the current control value has expired.
+// Planned charging slots use the max current, the remaining time the mode's fallback current.
+func (c *OCPP) offlinePeriods(start, from time.Time, sched *offlineSchedule) []types.ChargingSchedulePeriod {
+	period := func(ts time.Time) types.ChargingSchedulePeriod {
+		current := sched.fallback
+		if r, err := sched.plan.At(ts); err == nil && !r.IsZero() {
+			current = sched.maxCurrent
+		}
+		return c.schedulePeriod(ts.Sub(start), current)
+	}
+
+	res := []types.ChargingSchedulePeriod{period(from)}
+
+	for _, slot := range sched.plan {
+		for _, ts := range []time.Time{slot.Start, slot.End} {
+			if !ts.After(from) {
+				continue
+			}
+
+			// merge periods with same limit
+			if p := period(ts); p.Limit != res[len(res)-1].Limit {
+				res = append(res, p)
+			}
+		}
+	}
+
+	return res
+}
+
+// plan returns the loadpoint's active charging plan
+func (c *OCPP) plan() api.Rates {
+	planTime := c.lp.EffectivePlanTime()
+	if planTime.IsZero() {
+		return nil
+	}
+
+	goal, _ := c.lp.GetPlanGoal()
+	requiredDuration := c.lp.GetPlanRequiredDuration(goal, c.lp.EffectiveMaxPower())
+	if requiredDuration <= 0 {
+		return nil
+	}
+
+	return c.lp.GetPlan(planTime, requiredDuration, c.lp.GetPlanPreCondDuration())
+}
+
+// fallbackCurrent returns the current the charger should offer without evcc control
+func (c *OCPP) fallbackCurrent() float64 {
+	switch c.lp.GetMode() {
+	case api.ModeNow:
+		return c.lp.GetMaxCurrent()
+	case api.ModeMinPV:
+		return c.lp.GetMinCurrent()
+	default:
+		return 0
+	}
+}
+
+// circuitCurrent limits the current to the remaining capacity of the loadpoint's circuit hierarchy.
+// The remaining capacity excludes the circuit's other consumers, but not the loadpoint itself.
+func (c *OCPP) circuitCurrent(current float64) float64 {
+	circuit := c.lp.GetCircuit()
+	if circuit == nil {
+		return current
+	}
+
+	phases := c.lp.ActivePhases()
+	if phases == 0 {
+		phases = 3
+	}
+
+	ownCurrent := c.lp.GetMaxPhaseCurrent()
+	ownPower := c.lp.GetChargePower()
+
+	for ; circuit != nil; circuit = circuit.GetParent() {
+		if limit := circuit.GetMaxCurrent(); limit > 0 {
+			other := max(0, circuit.GetMaxPhaseCurrent()-ownCurrent)
+			current = min(current, max(0, limit-other))
+		}
+
+		if limit := circuit.GetMaxPower(); limit > 0 {
+			other := max(0, circuit.GetChargePower()-ownPower)
+			current = min(current, max(0, limit-other)/230.0/float64(phases))
+		}
+	}
+
+	return current
+}
@@ -0,0 +1,88 @@
+package charger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/ocpp"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOcppOfflinePeriods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	now := time.Now().Truncate(time.Hour)
+	start := now.Add(-time.Minute)
+	from := now.Add(scheduleHold)
+
+	plan := api.Rates{
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)},
+		{Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour)},
+	}
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().EffectivePlanTime().Return(now.Add(4 * time.Hour)).AnyTimes()
+	lp.EXPECT().EffectiveMaxPower().Return(11e3).AnyTimes()
+	lp.EXPECT().GetPlanGoal().Return(80.0, true).AnyTimes()
+	lp.EXPECT().GetPlanRequiredDuration(80.0, 11e3).Return(2 * time.Hour).AnyTimes()
+	lp.EXPECT().GetPlanPreCondDuration().Return(time.Duration(0)).AnyTimes()
+	lp.EXPECT().GetPlan(now.Add(4*time.Hour), 2*time.Hour, time.Duration(0)).Return(plan).AnyTimes()
+	lp.EXPECT().GetMaxCurrent().Return(16.0).AnyTimes()
+	lp.EXPECT().GetMinCurrent().Return(6.0).AnyTimes()
+	lp.EXPECT().GetCircuit().Return(nil).AnyTimes()
+	lp.EXPECT().ActivePhases().Return(3).AnyTimes()
+
+	c := &OCPP{
+		cp:       ocpp.NewChargePoint(util.NewLogger("foo"), "abc"),
+		lp:       lp,
+		schedule: true,
+	}
+
+	for _, tc := range []struct {
+		mode     api.ChargeMode
+		expected []types.ChargingSchedulePeriod
+	}{
+		{api.ModePV, []types.ChargingSchedulePeriod{
+			{StartPeriod: 360, Limit: 0},
+			{StartPeriod: 3660, Limit: 16},
+			{StartPeriod: 10860, Limit: 0},
+		}},
+		{api.ModeMinPV, []types.ChargingSchedulePeriod{
+			{StartPeriod: 360, Limit: 6},
+			{StartPeriod: 3660, Limit: 16},
+			{StartPeriod: 10860, Limit: 6},
+		}},
+		{api.ModeNow, []types.ChargingSchedulePeriod{
+			{StartPeriod: 360, Limit: 16},
+		}},
+	} {
+		lp.EXPECT().GetMode().Return(tc.mode)
+		assert.Equal(t, tc.expected, c.offlinePeriods(start, from, c.offlineSchedule()), tc.mode)
+	}
+}
+
+func TestOcppCircuitCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	circuit := api.NewMockCircuit(ctrl)
+	circuit.EXPECT().GetParent().Return(nil).AnyTimes()
+	circuit.EXPECT().GetMaxCurrent().Return(20.0).AnyTimes()
+	circuit.EXPECT().GetMaxPower().Return(0.0).AnyTimes()
+	circuit.EXPECT().GetMaxPhaseCurrent().Return(14.0).AnyTimes() // 8A used by other consumers
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetCircuit().Return(circuit).AnyTimes()
+	lp.EXPECT().ActivePhases().Return(3).AnyTimes()
+	lp.EXPECT().GetMaxPhaseCurrent().Return(6.0).AnyTimes()
+	lp.EXPECT().GetChargePower().Return(4140.0).AnyTimes()
+
+	c := &OCPP{lp: lp}
+
+	assert.Equal(t, 12.0, c.circuitCurrent(16))
+	assert.Equal(t, 10.0, c.circuitCurrent(10))
+}
@@ -0,0 +1,100 @@
+package charger
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/sim"
+)
+
+// Sim is a simulated charger for local testing
+type Sim struct {
+	*embed
+	sim *sim.Simulation
+}
+
+func init() {
+	registry.Add("sim", NewSimFromConfig)
+}
+
+// NewSimFromConfig creates a simulated charger from generic config
+func NewSimFromConfig(other map[string]interface{}) (api.Charger, error) {
+	var cc struct {
+		embed `mapstructure:",squash"`
+		Sim   string
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	s, err := sim.Instance(cc.Sim)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sim{
+		embed: &cc.embed,
+		sim:   s,
+	}, nil
+}
+
+// Status implements the api.Charger interface
+func (wb *Sim) Status() (api.ChargeStatus, error) {
+	return wb.sim.Status(), nil
+}
+
+// Enabled implements the api.Charger interface
+func (wb *Sim) Enabled() (bool, error) {
+	return wb.sim.Enabled(), nil
+}
+
+// Enable implements the api.Charger interface
+func (wb *Sim) Enable(enable bool) error {
+	wb.sim.Enable(enable)
+	return nil
+}
+
+// MaxCurrent implements the api.Charger interface
+func (wb *Sim) MaxCurrent(current int64) error {
+	return wb.MaxCurrentMillis(float64(current))
+}
+
+var _ api.ChargerEx = (*Sim)(nil)
+
+// MaxCurrentMillis implements the api.ChargerEx interface
+func (wb *Sim) MaxCurrentMillis(current float64) error {
+	wb.sim.SetCurrent(current)
+	return nil
+}
+
+var _ api.Meter = (*Sim)(nil)
+
+// CurrentPower implements the api.Meter interface
+func (wb *Sim) CurrentPower() (float64, error) {
+	power, _ := wb.sim.Charge()
+	return power, nil
+}
+
+var _ api.MeterEnergy = (*Sim)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (wb *Sim) TotalEnergy() (float64, error) {
+	_, energy := wb.sim.Charge()
+	return energy, nil
+}
+
+var _ api.PhaseCurrents = (*Sim)(nil)
+
+// Currents implements the api.PhaseCurrents interface
+func (wb *Sim) Currents() (float64, float64, float64, error) {
+	l1, l2, l3 := wb.sim.Currents()
+	return l1, l2, l3, nil
+}
+
+var _ api.PhaseSwitcher = (*Sim)(nil)
+
+// Phases1p3p implements the api.PhaseSwitcher interface
+func (wb *Sim) Phases1p3p(phases int) error {
+	wb.sim.SetPhases(phases)
+	return nil
+}
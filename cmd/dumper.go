@@ -134,6 +134,11 @@ func (d *dumper) Dump(name string, v interface{}) {
 		fmt.Fprintf(w, "Capacity:\t%.1fkWh\n", v.Capacity())
 	}
 
+	if v, ok := v.(api.BatterySocLimiter); ok {
+		minSoc, maxSoc := v.GetSocLimits()
+		fmt.Fprintf(w, "Soc limits:\t%.0f%% - %.0f%%\n", minSoc, maxSoc)
+	}
+
 	if v, ok := v.(api.MaxACPowerGetter); ok {
 		fmt.Fprintf(w, "Max AC power:\t%.0fW\n", v.MaxACPower())
 	}
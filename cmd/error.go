@@ -28,6 +28,8 @@ const (
 	ClassMessenger
 	ClassSponsorship
 	ClassLoadpoint
+	ClassSimulator
+	ClassModbusServer
 )
 
 // FatalError is an error that can be marshaled
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/core"
+	"github.com/evcc-io/evcc/core/health"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server"
@@ -24,7 +25,6 @@ import (
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/evcc-io/evcc/util/telemetry"
 	_ "github.com/joho/godotenv/autoload"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 	vpr "github.com/spf13/viper"
@@ -87,6 +87,9 @@ func init() {
 	rootCmd.Flags().Bool("metrics", false, "Expose metrics")
 	bind(rootCmd, "metrics")
 
+	rootCmd.Flags().Bool("metrics-auth", false, "Require authentication for metrics")
+	bind(rootCmd, "metricsAuth", "metrics-auth")
+
 	rootCmd.Flags().Bool("profile", false, "Expose pprof profiles")
 	bind(rootCmd, "profile")
 
@@ -171,11 +174,6 @@ func runRoot(cmd *cobra.Command, args []string) {
 	socketHub := server.NewSocketHub()
 	httpd := server.NewHTTPd(fmt.Sprintf(":%d", conf.Network.Port), socketHub, customCssFile)
 
-	// metrics
-	if viper.GetBool("metrics") {
-		httpd.Router().Handle("/metrics", promhttp.Handler())
-	}
-
 	// pprof
 	if viper.GetBool("profile") {
 		httpd.Router().PathPrefix("/debug/").Handler(http.DefaultServeMux)
@@ -206,6 +204,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 		site, err = configureSiteAndLoadpoints(&conf)
 	}
 
+	// setup modbus server
+	if err == nil {
+		err = wrapErrorWithClass(ClassModbusServer, configureModbusServer(&conf.ModbusServer, site, tee.Attach()))
+	}
+
 	// setup influx
 	if err == nil {
 		influx, ierr := configureInflux(&conf.Influx)
@@ -243,6 +246,9 @@ func runRoot(cmd *cobra.Command, args []string) {
 		var mqtt *server.MQTT
 		mqtt, err = server.NewMQTT(strings.Trim(conf.Mqtt.Topic, "/"), site)
 		if err == nil {
+			if conf.Mqtt.Discovery != "" {
+				mqtt.Discover(strings.Trim(conf.Mqtt.Discovery, "/"), site)
+			}
 			go mqtt.Run(site, pipe.NewDropper(append(ignoreMqtt, ignoreEmpty)...).Pipe(tee.Attach()))
 		}
 	}
@@ -276,6 +282,33 @@ func runRoot(cmd *cobra.Command, args []string) {
 		err = wrapErrorWithClass(ClassMessenger, err)
 	}
 
+	// detect devices that stopped providing data
+	go health.Run(time.Minute)
+
+	// notify device health changes
+	if pushChan != nil {
+		health.SetNotifier(func(s health.Status) {
+			ev := health.EventUnhealthy
+			switch {
+			case s.Healthy:
+				ev = health.EventRecovered
+			case s.Stale && s.ConsecutiveFailures == 0:
+				ev = health.EventStale
+			}
+
+			pushChan <- push.Event{
+				Event: ev,
+				Attributes: map[string]any{
+					"class":    s.Class,
+					"name":     s.Name,
+					"error":    s.LastError,
+					"failures": s.ConsecutiveFailures,
+					"stale":    s.Stale,
+				},
+			}
+		})
+	}
+
 	// publish initial settings
 	valueChan <- util.Param{Key: keys.EEBus, Val: conf.EEBus.Configured()}
 	valueChan <- util.Param{Key: keys.Hems, Val: conf.HEMS}
@@ -329,6 +362,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 		valueChan <- util.Param{Key: keys.DemoMode, Val: true}
 	}
 
+	// metrics
+	if viper.GetBool("metrics") {
+		httpd.RegisterMetricsHandler(cache, authObject, viper.GetBool("metricsAuth"))
+	}
+
 	httpd.RegisterSystemHandler(site, valueChan, cache, authObject, func() {
 		log.INFO.Println("evcc was stopped by user. OS should restart the service. Or restart manually.")
 		err = errors.New("restart required") // https://gokrazy.org/development/process-interface/
@@ -347,7 +385,7 @@ func runRoot(cmd *cobra.Command, args []string) {
 		site.DumpConfig()
 		site.Prepare(valueChan, pushChan)
 
-		httpd.RegisterSiteHandlers(site, valueChan)
+		httpd.RegisterSiteHandlers(site, valueChan, authObject)
 
 		go func() {
 			site.Run(stopC, conf.Interval)
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagFrom = "from"
+	flagTo   = "to"
+)
+
+// sessionRepriceCmd represents the session reprice command
+var sessionRepriceCmd = &cobra.Command{
+	Use:   "reprice",
+	Short: "Recompute session prices from the rate history",
+	Long: `Recompute the price of charging sessions from the persisted grid and feed-in rate history.
+Only sessions with an energy breakdown and complete grid rates are updated.
+The rate history contains the rates as provided by the tariffs. Grid fee reductions are not applied.
+Sessions recorded in the session ledger are immutable and skipped.`,
+	Run: runSessionReprice,
+}
+
+func init() {
+	sessionCmd.AddCommand(sessionRepriceCmd)
+	sessionRepriceCmd.Flags().String(flagFrom, "", "Start date (YYYY-MM-DD), default 30 days ago")
+	sessionRepriceCmd.Flags().String(flagTo, "", "End date (YYYY-MM-DD, exclusive), default now")
+}
+
+func parseDateFlag(cmd *cobra.Command, flag string, def time.Time) time.Time {
+	val, _ := cmd.Flags().GetString(flag)
+	if val == "" {
+		return def
+	}
+
+	ts, err := time.ParseInLocation(time.DateOnly, val, time.Local)
+	if err != nil {
+		log.FATAL.Fatalf("invalid %s: %v", flag, err)
+	}
+
+	return ts
+}
+
+func runSessionReprice(cmd *cobra.Command, args []string) {
+	to := parseDateFlag(cmd, flagTo, time.Now())
+	from := parseDateFlag(cmd, flagFrom, to.AddDate(0, 0, -30))
+
+	if !from.Before(to) {
+		log.FATAL.Fatal("invalid time range")
+	}
+
+	// load config
+	if err := loadConfigFile(&conf, !cmd.Flag(flagIgnoreDatabase).Changed); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	// setup persistence
+	if err := configureDatabase(conf.Database); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	var sessions session.Sessions
+	if err := db.Instance.Where("created >= ? AND created < ?", from, to).Order("created").Find(&sessions).Error; err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	var updated, skipped int
+	for _, s := range sessions {
+		// ledgered sessions must not be modified
+		ledgered, err := session.Ledgered(s.ID)
+		if err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		if ledgered {
+			skipped++
+			continue
+		}
+
+		ok, err := s.RepriceFromHistory()
+		if err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := db.Instance.Save(&s).Error; err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		updated++
+	}
+
+	fmt.Printf("repriced %d of %d sessions (%d ledgered sessions skipped)\n", updated, len(sessions), skipped)
+
+	// wait for shutdown
+	<-shutdownDoneC()
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagSignature = "signature"
+	flagKey       = "key"
+)
+
+// sessionVerifyCmd represents the session verify command
+var sessionVerifyCmd = &cobra.Command{
+	Use:   "verify [export]",
+	Short: "Verify the session ledger or a ledger export",
+	Long: `Verify the hash chain and signatures of the session ledger.
+Without arguments, the ledger in the database is verified and compared to the current sessions.
+Given a ledger export (json or csv), its signature file and the trusted public key, the export is verified without database access.
+Without public key, the trusted key is read from the database.`,
+	Run:  runSessionVerify,
+	Args: cobra.MaximumNArgs(1),
+}
+
+func init() {
+	sessionCmd.AddCommand(sessionVerifyCmd)
+	sessionVerifyCmd.Flags().StringP(flagSignature, "s", "", "Ledger signature file")
+	sessionVerifyCmd.Flags().StringP(flagKey, "k", "", "Trusted ledger public key (base64)")
+}
+
+func runSessionVerify(cmd *cobra.Command, args []string) {
+	var sig *session.LedgerSignature
+	if file, _ := cmd.Flags().GetString(flagSignature); file != "" {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		sig = new(session.LedgerSignature)
+		if err := json.Unmarshal(b, sig); err != nil {
+			log.FATAL.Fatalf("invalid signature file: %v", err)
+		}
+	}
+
+	// the public key contained in the signature file is not trusted
+	publicKey, _ := cmd.Flags().GetString(flagKey)
+
+	// exports can be verified offline if the trusted key is given
+	if len(args) == 0 || publicKey == "" {
+		// load config
+		if err := loadConfigFile(&conf, !cmd.Flag(flagIgnoreDatabase).Changed); err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		// setup persistence
+		if err := configureDatabase(conf.Database); err != nil {
+			log.FATAL.Fatal(err)
+		}
+	}
+
+	var (
+		entries session.Ledger
+		err     error
+	)
+
+	if len(args) > 0 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			log.FATAL.Fatal(err)
+		}
+		defer f.Close()
+
+		if entries, err = session.ReadLedger(f); err != nil {
+			log.FATAL.Fatal(err)
+		}
+	} else if entries, err = session.LedgerEntries(); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	if publicKey == "" {
+		if publicKey, err = session.LedgerPublicKey(); err != nil {
+			log.FATAL.Fatalf("%v: specify the trusted public key using --%s", err, flagKey)
+		}
+	}
+
+	if sig != nil && sig.PublicKey != publicKey {
+		log.FATAL.Fatalf("%v: signature key %s does not match trusted key %s", session.ErrLedgerInvalid, sig.PublicKey, publicKey)
+	}
+
+	if err := entries.Verify(publicKey, sig); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	fmt.Printf("ledger valid: %d sessions, public key %s\n", len(entries), publicKey)
+	if sig != nil {
+		fmt.Printf("signature valid: %d sessions signed %s\n", sig.Count, sig.Created.Local().Format("2006-01-02 15:04:05"))
+	}
+
+	// compare with current sessions
+	if len(args) == 0 {
+		var current session.Sessions
+		if err := db.Instance.Find(&current).Error; err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		modified, deleted, err := entries.Compare(current)
+		if err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		for _, id := range modified {
+			fmt.Printf("session %d: modified in sessions table\n", id)
+		}
+		for _, id := range deleted {
+			fmt.Printf("session %d: deleted from sessions table\n", id)
+		}
+	}
+}
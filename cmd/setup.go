@@ -17,14 +17,19 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/charger"
+	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/core/circuit"
+	"github.com/evcc-io/evcc/core/dimming"
+	"github.com/evcc-io/evcc/core/driver"
+	"github.com/evcc-io/evcc/core/health"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/metrics"
 	"github.com/evcc-io/evcc/core/session"
 	coresettings "github.com/evcc-io/evcc/core/settings"
+	"github.com/evcc-io/evcc/core/sitepower"
 	"github.com/evcc-io/evcc/hems"
 	"github.com/evcc-io/evcc/meter"
 	"github.com/evcc-io/evcc/plugin/golang"
@@ -34,16 +39,20 @@ import (
 	"github.com/evcc-io/evcc/server"
 	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/server/db/cache"
+	"github.com/evcc-io/evcc/server/db/history"
+	"github.com/evcc-io/evcc/server/db/rates"
 	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/server/eebus"
 	"github.com/evcc-io/evcc/server/modbus"
 	"github.com/evcc-io/evcc/server/providerauth"
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/auth"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/locale"
 	"github.com/evcc-io/evcc/util/machine"
 	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/sim"
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/evcc-io/evcc/util/templates"
 	"github.com/evcc-io/evcc/vehicle"
@@ -507,6 +516,10 @@ func configureEnvironment(cmd *cobra.Command, conf *globalconfig.All) error {
 	// setup persistence
 	err := wrapErrorWithClass(ClassDatabase, configureDatabase(conf.Database))
 
+	if err == nil {
+		err = wrapErrorWithClass(ClassDatabase, history.SetRetention(conf.History.Retention))
+	}
+
 	// setup additional templates
 	if err == nil {
 		if cmd.PersistentFlags().Changed(flagTemplate) {
@@ -558,6 +571,11 @@ func configureEnvironment(cmd *cobra.Command, conf *globalconfig.All) error {
 		err = wrapErrorWithClass(ClassGo, configureGo(conf.Go))
 	}
 
+	// setup simulators
+	if err == nil {
+		err = wrapErrorWithClass(ClassSimulator, configureSimulators(conf.Simulators))
+	}
+
 	// setup config database
 	if err == nil {
 		// TODO decide wrapping
@@ -581,10 +599,45 @@ func configureDatabase(conf globalconfig.DB) error {
 		return err
 	}
 
+	if err := dimming.Init(); err != nil {
+		return err
+	}
+
+	if err := history.Init(); err != nil {
+		return err
+	}
+
+	// requires history
 	if err := metrics.Init(); err != nil {
 		return err
 	}
 
+	// requires history
+	if err := sitepower.Init(); err != nil {
+		return err
+	}
+
+	if err := rates.Init(); err != nil {
+		return err
+	}
+
+	if err := auth.Init(); err != nil {
+		return err
+	}
+
+	if err := driver.Init(); err != nil {
+		return err
+	}
+
+	// reject ocpp id tags not allowed at the charger's loadpoints if enabled
+	ocpp.SetAuthorizer(func(idTag string, loadpoints []loadpoint.API) bool {
+		names := lo.FilterMap(loadpoints, func(lp loadpoint.API, _ int) (string, bool) {
+			name := config.InstanceName(config.Loadpoints(), lp)
+			return name, name != ""
+		})
+		return driver.Authorized(idTag, names...)
+	})
+
 	if err := settings.Init(); err != nil {
 		return err
 	}
@@ -695,6 +748,16 @@ func configureGo(conf []globalconfig.Go) error {
 	return nil
 }
 
+// setup simulators
+func configureSimulators(conf []globalconfig.Simulator) error {
+	for _, cc := range conf {
+		if _, err := sim.Register(cc.Name, cc.Config); err != nil {
+			return fmt.Errorf("failed configuring simulator: %w", err)
+		}
+	}
+	return nil
+}
+
 // setup HEMS
 func configureHEMS(conf *globalconfig.Hems, site *core.Site, httpd *server.HTTPd) error {
 	// migrate settings
@@ -807,7 +870,7 @@ func configureMessengers(conf *globalconfig.Messaging, vehicles push.Vehicles, v
 	return messageChan, nil
 }
 
-func tariffInstance(name string, conf config.Typed) (api.Tariff, error) {
+func tariffInstance(name, usage string, conf config.Typed) (api.Tariff, error) {
 	ctx := util.WithLogger(context.TODO(), util.NewLogger(name))
 
 	props, err := customDevice(conf.Other)
@@ -815,7 +878,7 @@ func tariffInstance(name string, conf config.Typed) (api.Tariff, error) {
 		return nil, fmt.Errorf("cannot decode custom tariff '%s': %w", name, err)
 	}
 
-	instance, err := tariff.NewCachedFromConfig(ctx, conf.Type, props)
+	instance, err := tariff.NewCachedFromConfig(ctx, name, usage, conf.Type, props)
 	if err != nil {
 		if ce := new(util.ConfigError); errors.As(err, &ce) {
 			return nil, err
@@ -824,6 +887,12 @@ func tariffInstance(name string, conf config.Typed) (api.Tariff, error) {
 		// wrap non-config tariff errors to prevent fatals
 		log.ERROR.Printf("creating tariff %s failed: %v", name, err)
 		instance = tariff.NewWrapper(conf.Type, conf.Other, err)
+		health.Track(health.Tariff, name).Result(err)
+	}
+
+	// track health by configured name
+	if p, ok := instance.(*tariff.CachingProxy); ok {
+		p.SetObserver(health.Track(health.Tariff, name).Observe)
 	}
 
 	return instance, nil
@@ -835,7 +904,7 @@ func configureTariff(u api.TariffUsage, conf config.Typed, t *api.Tariff) error
 	}
 
 	name := u.String()
-	res, err := tariffInstance(name, conf)
+	res, err := tariffInstance(name, name, conf)
 	if err != nil {
 		return &DeviceError{name, err}
 	}
@@ -855,7 +924,7 @@ func configureSolarTariff(conf []config.Typed, t *api.Tariff) error {
 			}
 
 			name := fmt.Sprintf("%s-%s-%d", api.TariffUsageSolar, tariff.Name(conf), i)
-			res, err := tariffInstance(name, conf)
+			res, err := tariffInstance(name, api.TariffUsageSolar.String(), conf)
 			if err != nil {
 				return &DeviceError{name, err}
 			}
@@ -890,6 +959,10 @@ func configureTariffs(conf *globalconfig.Tariffs) (*tariff.Tariffs, error) {
 		tariffs.Currency = currency.MustParseISO(conf.Currency)
 	}
 
+	if conf.Retention != 0 {
+		rates.Retention = conf.Retention
+	}
+
 	var eg errgroup.Group
 	eg.Go(func() error { return configureTariff(api.TariffUsageGrid, conf.Grid, &tariffs.Grid) })
 	eg.Go(func() error { return configureTariff(api.TariffUsageFeedIn, conf.FeedIn, &tariffs.FeedIn) })
@@ -905,9 +978,41 @@ func configureTariffs(conf *globalconfig.Tariffs) (*tariff.Tariffs, error) {
 		return nil, &ClassError{ClassTariff, err}
 	}
 
+	if err := configureGridFee(conf.GridFee, &tariffs.Grid); err != nil {
+		return nil, &ClassError{ClassTariff, err}
+	}
+
+	if conf.SolarCorrection && tariffs.Solar != nil {
+		tariffs.Solar = metrics.NewCorrected(metrics.Solar, tariffs.Solar)
+	}
+
 	return &tariffs, nil
 }
 
+// configureGridFee applies the reduced network charge of the grid fee module to the grid tariff
+func configureGridFee(conf globalconfig.GridFee, t *api.Tariff) error {
+	if conf.Module == 0 {
+		return nil
+	}
+
+	var variable api.Tariff
+	if conf.Variable.Type != "" {
+		res, err := tariffInstance("gridfee", "gridfee", conf.Variable)
+		if err != nil {
+			return &DeviceError{"gridfee", err}
+		}
+		variable = res
+	}
+
+	res, err := dimming.NewGridFee(*t, dimming.Module(conf.Module), conf.NetworkCharge, conf.Reduction, variable)
+	if err != nil {
+		return err
+	}
+
+	*t = res
+	return nil
+}
+
 func configureDevices(conf globalconfig.All) error {
 	// collect references for filtering used devices
 	if err := collectRefs(conf); err != nil {
@@ -960,6 +1065,19 @@ func configureModbusProxy(conf *[]globalconfig.ModbusProxy) error {
 	return nil
 }
 
+func configureModbusServer(conf *globalconfig.ModbusServer, site *core.Site, in <-chan util.Param) error {
+	if conf.Port == 0 {
+		return nil
+	}
+
+	mode, err := modbus.ReadOnlyModeString(conf.ReadOnly)
+	if err != nil {
+		return err
+	}
+
+	return modbus.StartServer(conf.Port, site, in, mode)
+}
+
 func configureSiteAndLoadpoints(conf *globalconfig.All) (*core.Site, error) {
 	// migrate settings
 	if settings.Exists(keys.Interval) {
@@ -1047,6 +1165,11 @@ func configureSite(conf map[string]interface{}, loadpoints []*core.Loadpoint, ta
 		return site, err
 	}
 
+	// run control logic on simulated time
+	if clock := sim.Clock(); clock != nil {
+		site.SetClock(clock)
+	}
+
 	if err := site.Boot(log, loadpoints, tariffs); err != nil {
 		return site, fmt.Errorf("failed booting site: %w", err)
 	}
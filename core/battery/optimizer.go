@@ -0,0 +1,254 @@
+package battery
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// SlotDuration is the duration of a single schedule slot
+const SlotDuration = 15 * time.Minute
+
+// socSteps is the resolution of the soc state space
+const socSteps = 100
+
+// ErrNoRates is returned if there are no grid rates to optimize against
+var ErrNoRates = errors.New("no grid rates")
+
+// Battery describes the home battery to be scheduled
+type Battery struct {
+	Capacity          float64 // capacity in kWh
+	Soc               float64 // current soc in %
+	MinSoc            float64 // lowest allowed soc in %
+	MaxSoc            float64 // highest allowed soc in %
+	MaxChargePower    float64 // max charge power in W
+	MaxDischargePower float64 // max discharge power in W
+	Efficiency        float64 // round-trip efficiency
+}
+
+// Forecast is the input data for the optimization
+type Forecast struct {
+	Grid        api.Rates // grid price
+	FeedIn      api.Rates // feed-in price, optional
+	Solar       api.Rates // solar production in W, optional
+	Consumption api.Rates // household consumption in W, optional
+}
+
+// Slot is a single step of the battery schedule
+type Slot struct {
+	Start time.Time       `json:"start"`
+	End   time.Time       `json:"end"`
+	Mode  api.BatteryMode `json:"mode"`
+	Soc   float64         `json:"soc"`  // projected soc at end of slot
+	Grid  float64         `json:"grid"` // projected grid energy in Wh, import positive
+	Cost  float64         `json:"cost"` // projected grid cost
+}
+
+// Schedule is the battery schedule sorted by time
+type Schedule []Slot
+
+// At returns the slot for given timestamp
+func (s Schedule) At(ts time.Time) (Slot, bool) {
+	for _, slot := range s {
+		if !ts.Before(slot.Start) && ts.Before(slot.End) {
+			return slot, true
+		}
+	}
+	return Slot{}, false
+}
+
+// Cost returns the projected total cost of the schedule
+func (s Schedule) Cost() float64 {
+	var res float64
+	for _, slot := range s {
+		res += slot.Cost
+	}
+	return res
+}
+
+// MarshalMQTT implements server.MQTTMarshaler
+func (s Schedule) MarshalMQTT() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// step is the input of a single optimization step
+type step struct {
+	start, end    time.Time
+	grid, feedIn  float64 // price per kWh
+	net           float64 // household consumption minus solar production in Wh
+	charge, disch float64 // max charge/discharge energy in Wh
+}
+
+// modes are the optimizer's choices, ordered by preference for equal cost
+var modes = []api.BatteryMode{api.BatteryNormal, api.BatteryHold, api.BatteryCharge}
+
+// Optimize computes the lowest-cost battery schedule for the given forecast using dynamic programming
+// over discretized soc states. The schedule ends with the available grid rates or the horizon, whichever comes first.
+func Optimize(bat Battery, fc Forecast, start time.Time, horizon time.Duration) (Schedule, error) {
+	if bat.Capacity <= 0 {
+		return nil, errors.New("unknown battery capacity")
+	}
+
+	steps := prepare(bat, fc, start, horizon)
+	if len(steps) == 0 {
+		return nil, ErrNoRates
+	}
+
+	// stored energy per soc step in Wh
+	unit := bat.Capacity * 1e3 / socSteps
+	eff := math.Sqrt(max(0, min(1, bat.Efficiency)))
+	if eff == 0 {
+		eff = 1
+	}
+
+	minState := int(math.Ceil(bat.MinSoc))
+	maxState := int(math.Floor(bat.MaxSoc))
+	if maxState <= 0 || maxState > socSteps {
+		maxState = socSteps
+	}
+	minState = max(0, min(minState, maxState))
+
+	// remaining energy is valued at the average grid price, discounted by discharge losses
+	var avgPrice float64
+	for _, s := range steps {
+		avgPrice += s.grid
+	}
+	avgPrice /= float64(len(steps))
+
+	// value[i][state] is the minimum cost from step i with given soc state to the end of horizon
+	value := make([][]float64, len(steps)+1)
+	choice := make([][]api.BatteryMode, len(steps))
+
+	value[len(steps)] = make([]float64, socSteps+1)
+	for state := range value[len(steps)] {
+		value[len(steps)][state] = -float64(state) * unit * eff / 1e3 * avgPrice
+	}
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		value[i] = make([]float64, socSteps+1)
+		choice[i] = make([]api.BatteryMode, socSteps+1)
+
+		for state := range socSteps + 1 {
+			best := math.Inf(1)
+
+			for _, mode := range modes {
+				next, _, cost := transition(steps[i], mode, state, minState, maxState, unit, eff)
+
+				// prefer earlier modes unless substantially cheaper
+				if total := cost + value[i+1][next]; total < best-1e-9 {
+					best = total
+					choice[i][state] = mode
+				}
+			}
+
+			value[i][state] = best
+		}
+	}
+
+	// roll out the optimal decisions from current soc
+	state := int(math.Round(max(0, min(socSteps, bat.Soc))))
+	res := make(Schedule, 0, len(steps))
+
+	for i, s := range steps {
+		mode := choice[i][state]
+		next, grid, cost := transition(s, mode, state, minState, maxState, unit, eff)
+
+		res = append(res, Slot{
+			Start: s.start,
+			End:   s.end,
+			Mode:  mode,
+			Soc:   float64(next),
+			Grid:  grid,
+			Cost:  cost,
+		})
+
+		state = next
+	}
+
+	return res, nil
+}
+
+// prepare converts the forecast into equidistant optimization steps
+func prepare(bat Battery, fc Forecast, start time.Time, horizon time.Duration) []step {
+	hours := SlotDuration.Hours()
+	end := start.Add(horizon)
+
+	var res []step
+	for ts := start.Truncate(SlotDuration); ts.Before(end); ts = ts.Add(SlotDuration) {
+		grid, err := fc.Grid.At(ts)
+		if err != nil {
+			break
+		}
+
+		s := step{
+			start:  ts,
+			end:    ts.Add(SlotDuration),
+			grid:   grid.Value,
+			charge: bat.MaxChargePower * hours,
+			disch:  bat.MaxDischargePower * hours,
+		}
+
+		if r, err := fc.FeedIn.At(ts); err == nil {
+			s.feedIn = r.Value
+		}
+
+		if r, err := fc.Consumption.At(ts); err == nil {
+			s.net = r.Value * hours
+		}
+
+		if r, err := fc.Solar.At(ts); err == nil {
+			s.net -= r.Value * hours
+		}
+
+		res = append(res, s)
+	}
+
+	// first slot may already be partially over
+	if len(res) > 0 && start.After(res[0].start) {
+		res[0].start = start
+	}
+
+	return res
+}
+
+// transition applies the mode to the given soc state and returns the next state, grid energy and cost
+func transition(s step, mode api.BatteryMode, state, minState, maxState int, unit, eff float64) (int, float64, float64) {
+	stored := float64(state) * unit
+
+	// energy that can be charged or discharged within soc limits, measured at the battery terminals
+	room := max(0, (float64(maxState)*unit-stored)/eff)
+	avail := max(0, (stored-float64(minState)*unit)*eff)
+
+	var charge, discharge float64
+	grid := s.net
+
+	switch mode {
+	case api.BatteryCharge:
+		charge = min(s.charge, room)
+		grid += charge
+
+	default:
+		if s.net < 0 {
+			// surplus charges battery in normal and hold mode
+			charge = min(-s.net, s.charge, room)
+			grid += charge
+		} else if mode == api.BatteryNormal {
+			discharge = min(s.net, s.disch, avail)
+			grid -= discharge
+		}
+	}
+
+	stored += charge*eff - discharge/eff
+	next := int(math.Round(stored / unit))
+	next = max(0, min(socSteps, next))
+
+	cost := grid / 1e3 * s.grid
+	if grid < 0 {
+		cost = grid / 1e3 * s.feedIn
+	}
+
+	return next, grid, cost
+}
@@ -0,0 +1,136 @@
+package battery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rates(prices []float64, start time.Time) api.Rates {
+	res := make(api.Rates, 0, len(prices))
+	for i, v := range prices {
+		slotStart := start.Add(time.Duration(i) * time.Hour)
+		res = append(res, api.Rate{
+			Start: slotStart,
+			End:   slotStart.Add(time.Hour),
+			Value: v,
+		})
+	}
+	return res
+}
+
+func consumption(power float64, start time.Time) api.Rates {
+	return api.Rates{{
+		Start: start,
+		End:   start.Add(48 * time.Hour),
+		Value: power,
+	}}
+}
+
+func TestOptimizeArbitrage(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	bat := Battery{
+		Capacity:          10,
+		Soc:               10,
+		MinSoc:            10,
+		MaxSoc:            100,
+		MaxChargePower:    5e3,
+		MaxDischargePower: 5e3,
+		Efficiency:        0.9,
+	}
+
+	fc := Forecast{
+		Grid:        rates([]float64{0.1, 0.1, 0.4, 0.4, 0.4, 0.4}, start),
+		Consumption: consumption(2e3, start),
+	}
+
+	res, err := Optimize(bat, fc, start, 48*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, res, 24)
+
+	// charge while cheap
+	slot, ok := res.At(start)
+	require.True(t, ok)
+	assert.Equal(t, api.BatteryCharge, slot.Mode)
+
+	// discharge while expensive
+	slot, ok = res.At(start.Add(3 * time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, api.BatteryNormal, slot.Mode)
+	assert.Less(t, slot.Grid, 500.0)
+
+	// soc limits are respected
+	for _, slot := range res {
+		assert.GreaterOrEqual(t, slot.Soc, bat.MinSoc)
+		assert.LessOrEqual(t, slot.Soc, bat.MaxSoc)
+	}
+}
+
+func TestOptimizeHold(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	bat := Battery{
+		Capacity:          2,
+		Soc:               100,
+		MaxSoc:            100,
+		MaxChargePower:    0,
+		MaxDischargePower: 5e3,
+		Efficiency:        1,
+	}
+
+	fc := Forecast{
+		Grid:        rates([]float64{0.2, 0.5}, start),
+		Consumption: consumption(2e3, start),
+	}
+
+	res, err := Optimize(bat, fc, start, 48*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, res, 8)
+
+	// save battery energy for the expensive hour
+	for _, slot := range res[:4] {
+		assert.Equal(t, api.BatteryHold, slot.Mode)
+	}
+	for _, slot := range res[4:] {
+		assert.Equal(t, api.BatteryNormal, slot.Mode)
+	}
+}
+
+func TestOptimizeSolarSurplus(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	bat := Battery{
+		Capacity:          10,
+		Soc:               50,
+		MaxSoc:            100,
+		MaxChargePower:    5e3,
+		MaxDischargePower: 5e3,
+		Efficiency:        0.9,
+	}
+
+	fc := Forecast{
+		Grid:        rates([]float64{0.3, 0.3}, start),
+		FeedIn:      rates([]float64{0.08, 0.08}, start),
+		Solar:       rates([]float64{4e3, 4e3}, start),
+		Consumption: consumption(1e3, start),
+	}
+
+	res, err := Optimize(bat, fc, start, 48*time.Hour)
+	require.NoError(t, err)
+
+	// no grid charging with surplus
+	for _, slot := range res {
+		assert.NotEqual(t, api.BatteryCharge, slot.Mode)
+		assert.LessOrEqual(t, slot.Grid, 0.0)
+	}
+	assert.Greater(t, res[len(res)-1].Soc, bat.Soc)
+}
+
+func TestOptimizeNoRates(t *testing.T) {
+	_, err := Optimize(Battery{Capacity: 10}, Forecast{}, time.Now(), 24*time.Hour)
+	assert.ErrorIs(t, err, ErrNoRates)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"sync"
 	"time"
 
@@ -31,11 +32,12 @@ type Circuit struct {
 
 	maxCurrent    float64                 // max allowed current
 	maxPower      float64                 // max allowed power
+	maxImbalance  float64                 // max allowed current difference between phases
 	getMaxCurrent func() (float64, error) // dynamic max allowed current
 	getMaxPower   func() (float64, error) // dynamic max allowed power
 
-	current float64
-	power   float64
+	currents [3]float64 // currents per phase
+	power    float64
 
 	currentUpdated time.Time
 	powerUpdated   time.Time
@@ -49,6 +51,7 @@ func NewFromConfig(ctx context.Context, log *util.Logger, other map[string]inter
 		MeterRef      string         `mapstructure:"meter"`  // meter reference
 		MaxCurrent    float64        // the max allowed current
 		MaxPower      float64        // the max allowed power
+		MaxImbalance  float64        // the max allowed current difference between phases
 		GetMaxCurrent *plugin.Config // dynamic max allowed current
 		GetMaxPower   *plugin.Config // dynamic max allowed power
 		Timeout       time.Duration  // timeout between meter updates
@@ -77,6 +80,11 @@ func NewFromConfig(ctx context.Context, log *util.Logger, other map[string]inter
 		return nil, err
 	}
 
+	if cc.MaxImbalance > 0 {
+		circuit.maxImbalance = cc.MaxImbalance
+		circuit.log.DEBUG.Printf("validation of phase imbalance: %.3gA", cc.MaxImbalance)
+	}
+
 	circuit.getMaxPower, err = cc.GetMaxPower.FloatGetter(ctx)
 	if err != nil {
 		return nil, err
@@ -225,7 +233,7 @@ func (c *Circuit) RegisterChild(child api.Circuit) {
 
 func (c *Circuit) updateLoadpoints(loadpoints []api.CircuitLoad) {
 	c.power = 0
-	c.current = 0
+	c.currents = [3]float64{}
 
 	for _, lp := range loadpoints {
 		if lp.GetCircuit() != c {
@@ -233,13 +241,21 @@ func (c *Circuit) updateLoadpoints(loadpoints []api.CircuitLoad) {
 		}
 
 		c.power += lp.GetChargePower()
-		c.current += lp.GetMaxPhaseCurrent()
+		c.addCurrents(lp.GetPhaseCurrents())
 	}
 }
 
-func (c *Circuit) overloadOnError(t time.Time, val *float64) {
+func (c *Circuit) addCurrents(currents [3]float64) {
+	for i := range c.currents {
+		c.currents[i] += currents[i]
+	}
+}
+
+func (c *Circuit) overloadOnError(t time.Time, val ...*float64) {
 	if c.timeout > 0 && time.Since(t) > c.timeout {
-		*val = math.MaxFloat64
+		for _, v := range val {
+			*v = math.MaxFloat64
+		}
 	}
 }
 
@@ -259,7 +275,7 @@ func (c *Circuit) updateMeters() error {
 			i1, i2, i3, err = phaseMeter.Currents()
 			return err
 		}, modbus.Backoff()); err != nil {
-			c.overloadOnError(c.currentUpdated, &c.current)
+			c.overloadOnError(c.currentUpdated, &c.currents[0], &c.currents[1], &c.currents[2])
 			return fmt.Errorf("circuit currents: %w", err)
 		}
 
@@ -271,7 +287,7 @@ func (c *Circuit) updateMeters() error {
 			}
 		}
 
-		c.current = max(util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3))
+		c.currents = [3]float64{util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3)}
 		c.currentUpdated = time.Now()
 	}
 
@@ -289,10 +305,14 @@ func (c *Circuit) Update(loadpoints []api.CircuitLoad) (err error) {
 			c.log.DEBUG.Printf("power: %.5gW", c.power)
 		}
 
-		if maxCurrent != 0 && c.current > maxCurrent {
-			c.log.WARN.Printf("over current detected: %.3gA > %.3gA", c.current, maxCurrent)
+		if current := c.GetMaxPhaseCurrent(); maxCurrent != 0 && current > maxCurrent {
+			c.log.WARN.Printf("over current detected: %.3gA > %.3gA", c.currents, maxCurrent)
 		} else {
-			c.log.DEBUG.Printf("current: %.3gA", c.current)
+			c.log.DEBUG.Printf("currents: %.3gA", c.currents)
+		}
+
+		if imbalance := c.imbalance(); c.maxImbalance != 0 && imbalance > c.maxImbalance {
+			c.log.WARN.Printf("phase imbalance detected: %.3gA > %.3gA", imbalance, c.maxImbalance)
 		}
 	}()
 
@@ -312,7 +332,7 @@ func (c *Circuit) Update(loadpoints []api.CircuitLoad) (err error) {
 	c.updateLoadpoints(loadpoints)
 	for _, ch := range c.children {
 		c.power += ch.GetChargePower()
-		c.current += ch.GetMaxPhaseCurrent()
+		c.addCurrents(ch.GetPhaseCurrents())
 	}
 
 	return nil
@@ -325,7 +345,17 @@ func (c *Circuit) GetChargePower() float64 {
 
 // GetMaxPhaseCurrent returns the actual current
 func (c *Circuit) GetMaxPhaseCurrent() float64 {
-	return c.current
+	return max(c.currents[0], c.currents[1], c.currents[2])
+}
+
+// GetPhaseCurrents returns the actual currents per phase
+func (c *Circuit) GetPhaseCurrents() [3]float64 {
+	return c.currents
+}
+
+// imbalance returns the current difference between phases
+func (c *Circuit) imbalance() float64 {
+	return c.GetMaxPhaseCurrent() - min(c.currents[0], c.currents[1], c.currents[2])
 }
 
 // ValidatePower validates power request
@@ -350,18 +380,52 @@ func (c *Circuit) ValidatePower(old, new float64) float64 {
 	return c.parent.ValidatePower(old, new)
 }
 
-// ValidateCurrent validates current request
+// ValidateCurrent validates current request of a load using all phases
 func (c *Circuit) ValidateCurrent(old, new float64) float64 {
+	return c.ValidatePhaseCurrent([3]float64{old, old, old}, new, [3]bool{true, true, true})
+}
+
+// ValidatePhaseCurrent validates current request of a load using the given phases.
+// Old are the load's actual currents per phase, new is the requested current on each used phase.
+func (c *Circuit) ValidatePhaseCurrent(old [3]float64, new float64, phases [3]bool) float64 {
 	if maxCurrent := c.GetMaxCurrent(); maxCurrent != 0 {
-		delta := max(0, new-old)
-		potential := maxCurrent - c.current
+		for i, used := range phases {
+			if !used {
+				continue
+			}
 
-		if delta > potential {
-			capped := min(new, max(0, old+potential))
-			c.log.DEBUG.Printf("validate current: %.3gA + (%.3gA -> %.3gA) > %.3gA capped at %.3gA", c.current, old, new, maxCurrent, capped)
-			new = capped
-		} else {
-			c.log.TRACE.Printf("validate current: %.3gA + (%.3gA -> %.3gA) <= %.3gA ok", c.current, old, new, maxCurrent)
+			delta := max(0, new-old[i])
+			potential := maxCurrent - c.currents[i]
+
+			if delta > potential {
+				capped := min(new, max(0, old[i]+potential))
+				c.log.DEBUG.Printf("validate current L%d: %.3gA + (%.3gA -> %.3gA) > %.3gA capped at %.3gA", i+1, c.currents[i], old[i], new, maxCurrent, capped)
+				new = capped
+			} else {
+				c.log.TRACE.Printf("validate current L%d: %.3gA + (%.3gA -> %.3gA) <= %.3gA ok", i+1, c.currents[i], old[i], new, maxCurrent)
+			}
+		}
+	}
+
+	// loads on all phases don't change the imbalance
+	if c.maxImbalance != 0 && slices.Contains(phases[:], false) {
+		for j, used := range phases {
+			if used {
+				continue
+			}
+
+			for i, used := range phases {
+				if !used {
+					continue
+				}
+
+				// load phase must not exceed unused phase by more than max imbalance
+				if limit := c.currents[j] + c.maxImbalance - (c.currents[i] - old[i]); new > limit {
+					capped := max(0, limit)
+					c.log.DEBUG.Printf("validate imbalance L%d/L%d: %.3gA -> %.3gA exceeds %.3gA capped at %.3gA", i+1, j+1, old[i], new, c.maxImbalance, capped)
+					new = capped
+				}
+			}
 		}
 	}
 
@@ -369,5 +433,5 @@ func (c *Circuit) ValidateCurrent(old, new float64) float64 {
 		return new
 	}
 
-	return c.parent.ValidateCurrent(old, new)
+	return c.parent.ValidatePhaseCurrent(old, new, phases)
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -139,3 +140,67 @@ func TestCircuitCurrents(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestCircuitPhaseCurrents(t *testing.T) {
+	log := util.NewLogger("foo")
+
+	l1 := [3]bool{true, false, false}
+	l2 := [3]bool{false, true, false}
+	l123 := [3]bool{true, true, true}
+
+	for _, tc := range []struct {
+		maxCurrent, maxImbalance float64
+		currents, old            [3]float64
+		new                      float64
+		phases                   [3]bool
+		res                      float64
+	}{
+		// 1p loads on different phases don't limit each other
+		{32, 0, [3]float64{32, 0, 0}, [3]float64{}, 32, l2, 32},
+		{32, 0, [3]float64{32, 0, 0}, [3]float64{}, 32, l1, 0},
+		{32, 0, [3]float64{16, 0, 0}, [3]float64{}, 32, l123, 16},
+
+		// imbalance
+		{32, 20, [3]float64{}, [3]float64{}, 32, l1, 20},
+		{32, 20, [3]float64{10, 0, 0}, [3]float64{}, 32, l2, 20},
+		{32, 20, [3]float64{10, 10, 0}, [3]float64{}, 32, l1, 10},
+		{32, 20, [3]float64{16, 0, 0}, [3]float64{16, 0, 0}, 32, l1, 20},
+		{32, 20, [3]float64{25, 0, 0}, [3]float64{}, 32, l123, 7},
+
+		// existing imbalance caused by others reduces load
+		{32, 20, [3]float64{30, 0, 0}, [3]float64{6, 0, 0}, 16, l1, 0},
+	} {
+		c, err := New(log, "foo", tc.maxCurrent, 0, nil, 0)
+		require.NoError(t, err)
+
+		c.maxImbalance = tc.maxImbalance
+		c.currents = tc.currents
+
+		assert.Equal(t, tc.res, c.ValidatePhaseCurrent(tc.old, tc.new, tc.phases), tc)
+	}
+}
+
+func TestCircuitLoadpointPhases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	c, err := New(util.NewLogger("foo"), "foo", 32, 0, nil, 0)
+	require.NoError(t, err)
+
+	lp1 := loadpoint.NewMockAPI(ctrl)
+	lp1.EXPECT().GetCircuit().Return(c).AnyTimes()
+	lp1.EXPECT().GetChargePower().Return(7360.0)
+	lp1.EXPECT().GetPhaseCurrents().Return([3]float64{32, 0, 0})
+
+	lp2 := loadpoint.NewMockAPI(ctrl)
+	lp2.EXPECT().GetCircuit().Return(c).AnyTimes()
+	lp2.EXPECT().GetChargePower().Return(7360.0)
+	lp2.EXPECT().GetPhaseCurrents().Return([3]float64{0, 32, 0})
+
+	require.NoError(t, c.Update([]api.CircuitLoad{lp1, lp2}))
+
+	assert.Equal(t, [3]float64{32, 32, 0}, c.GetPhaseCurrents())
+	assert.Equal(t, 32.0, c.GetMaxPhaseCurrent())
+
+	// third 1p charger on L3 may use full current
+	assert.Equal(t, 32.0, c.ValidatePhaseCurrent([3]float64{}, 32, [3]bool{false, false, true}))
+}
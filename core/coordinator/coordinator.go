@@ -2,9 +2,11 @@ package coordinator
 
 import (
 	"sync"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	corevehicle "github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/util"
 )
 
@@ -136,7 +138,10 @@ func (c *Coordinator) identifyVehicleByStatus(available []api.Vehicle) api.Vehic
 
 	for _, vehicle := range available {
 		if vs, ok := vehicle.(api.ChargeState); ok {
+			start := time.Now()
 			status, err := vs.Status()
+			corevehicle.Observe(vehicle, start, err)
+
 			if err != nil {
 				if !loadpoint.AcceptableError(err) {
 					c.log.ERROR.Println("vehicle status:", err)
@@ -0,0 +1,190 @@
+package dimming
+
+import (
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+)
+
+const (
+	settleTime     = time.Minute     // time granted for reducing consumption after a limit is received
+	persistTimeout = 5 * time.Minute // max interval for persisting an active period
+)
+
+// Init creates the dimming period table
+func Init() error {
+	return db.Instance.AutoMigrate(new(Period))
+}
+
+// Report returns the dimming periods starting within the given time range
+func Report(from, to time.Time) (Periods, error) {
+	var res Periods
+
+	tx := db.Instance.Order("start DESC")
+	if !from.IsZero() {
+		tx = tx.Where("start >= ?", from)
+	}
+	if !to.IsZero() {
+		tx = tx.Where("start < ?", to)
+	}
+
+	return res, tx.Find(&res).Error
+}
+
+// Recorder records received consumption limits and the controllable consumption while they are active
+type Recorder struct {
+	mu        sync.Mutex
+	log       *util.Logger
+	clock     clock.Clock
+	power     func() float64
+	module    Module
+	current   *Period
+	updated   time.Time
+	persisted time.Time
+}
+
+// NewRecorder creates a recorder. Power returns the aggregate controllable consumption in W,
+// module is the grid fee module recorded with each period.
+// Periods left active by a previous run are closed.
+func NewRecorder(power func() float64, module Module) *Recorder {
+	r := &Recorder{
+		log:    util.NewLogger("dimming"),
+		clock:  clock.New(),
+		power:  power,
+		module: module,
+	}
+
+	if err := closeInterrupted(); err != nil {
+		r.log.ERROR.Println("close interrupted periods:", err)
+	}
+
+	return r
+}
+
+// closeInterrupted ends active periods at the time they were last persisted
+func closeInterrupted() error {
+	if db.Instance == nil {
+		return nil
+	}
+
+	var res Periods
+	if err := db.Instance.Where(`"end" IS NULL OR "end" = ?`, time.Time{}).Find(&res).Error; err != nil {
+		return err
+	}
+
+	for _, p := range res {
+		p.End = p.Updated
+		if p.End.Before(p.Start) {
+			p.End = p.Start
+		}
+
+		if err := db.Instance.Save(&p).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetLimit records a consumption limit in W received from source. Zero ends an active limit.
+func (r *Recorder) SetLimit(source string, limit float64, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current != nil && r.current.Source == source && r.current.Limit == limit && r.current.Duration == duration {
+		return
+	}
+
+	now := r.clock.Now()
+
+	if r.current != nil {
+		r.sample(now)
+		r.current.End = now
+		r.persist(now)
+
+		r.log.INFO.Printf("%s limit %.0fW ended after %v", r.current.Source, r.current.Limit, now.Sub(r.current.Start).Round(time.Second))
+		r.current = nil
+	}
+
+	if limit <= 0 {
+		return
+	}
+
+	r.log.INFO.Printf("%s limit %.0fW received", source, limit)
+
+	r.current = &Period{
+		Source:   source,
+		Start:    now,
+		Duration: duration,
+		Limit:    limit,
+		Module:   r.module,
+	}
+
+	r.updated = now
+	r.persist(now)
+}
+
+// Update samples the controllable consumption of an active limit
+func (r *Recorder) Update() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current == nil {
+		return
+	}
+
+	now := r.clock.Now()
+	r.sample(now)
+
+	if now.Sub(r.persisted) >= persistTimeout {
+		r.persist(now)
+	}
+}
+
+// Current returns the active period if any
+func (r *Recorder) Current() (Period, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current == nil {
+		return Period{}, false
+	}
+
+	return *r.current, true
+}
+
+func (r *Recorder) sample(now time.Time) {
+	d := now.Sub(r.updated)
+	r.updated = now
+
+	power := r.power()
+	r.current.Energy += power * d.Hours() / 1e3
+
+	if now.Sub(r.current.Start) < settleTime {
+		return
+	}
+
+	r.current.MaxPower = max(r.current.MaxPower, power)
+
+	if power > r.current.Limit {
+		r.current.Exceeded += d
+
+		r.log.WARN.Printf("%s limit %.0fW exceeded: %.0fW", r.current.Source, r.current.Limit, power)
+	}
+}
+
+func (r *Recorder) persist(now time.Time) {
+	r.persisted = now
+	r.current.Updated = now
+
+	if db.Instance == nil {
+		return
+	}
+
+	if err := db.Instance.Save(r.current).Error; err != nil {
+		r.log.ERROR.Println("persist:", err)
+	}
+}
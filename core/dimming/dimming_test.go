@@ -0,0 +1,164 @@
+package dimming
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	clock := clock.NewMock()
+
+	power := 11000.0
+	r := NewRecorder(func() float64 { return power }, Module2)
+	r.clock = clock
+
+	r.Update()
+	_, ok := r.Current()
+	assert.False(t, ok)
+
+	r.SetLimit("relay", 4200, 0)
+	start := clock.Now()
+
+	p, ok := r.Current()
+	require.True(t, ok)
+	assert.Equal(t, Module2, p.Module)
+
+	// reduce within settle time
+	clock.Add(30 * time.Second)
+	r.Update()
+	power = 4000
+
+	clock.Add(30 * time.Minute)
+	r.Update()
+
+	p, ok = r.Current()
+	require.True(t, ok)
+	assert.True(t, p.Compliant())
+	assert.Equal(t, 4000.0, p.MaxPower)
+	assert.InDelta(t, 11*0.5/60+4*0.5, p.Energy, 1e-6)
+
+	// exceed after settling
+	power = 5000
+	clock.Add(time.Minute)
+	r.Update()
+
+	p, _ = r.Current()
+	assert.False(t, p.Compliant())
+	assert.Equal(t, time.Minute, p.Exceeded)
+
+	// same limit is not recorded twice
+	r.SetLimit("relay", 4200, 0)
+	p2, _ := r.Current()
+	assert.Equal(t, start, p2.Start)
+
+	r.SetLimit("relay", 0, 0)
+	_, ok = r.Current()
+	assert.False(t, ok)
+}
+
+func TestRecorderClosesInterrupted(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, Init())
+
+	start := time.Date(2025, 1, 1, 17, 0, 0, 0, time.UTC)
+	active := Period{Source: "eebus", Start: start, Limit: 4200, Updated: start.Add(20 * time.Minute)}
+	closed := Period{Source: "eebus", Start: start.Add(-time.Hour), End: start.Add(-time.Minute), Limit: 4200}
+	require.NoError(t, db.Instance.Create(&active).Error)
+	require.NoError(t, db.Instance.Create(&closed).Error)
+
+	_ = NewRecorder(func() float64 { return 0 }, ModuleNone)
+
+	res, err := Report(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	assert.False(t, res[0].Active())
+	assert.Equal(t, start.Add(20*time.Minute), res[0].End.UTC())
+	assert.Equal(t, start.Add(-time.Minute), res[1].End.UTC())
+}
+
+func TestPeriodsCsv(t *testing.T) {
+	start := time.Date(2025, 1, 1, 17, 0, 0, 0, time.UTC)
+
+	pp := Periods{{
+		Source:   "eebus",
+		Module:   Module3,
+		Start:    start,
+		End:      start.Add(2 * time.Hour),
+		Duration: 2 * time.Hour,
+		Limit:    4200,
+		Energy:   8,
+		MaxPower: 4100,
+	}}
+
+	var b bytes.Buffer
+	require.NoError(t, pp.WriteCsv(context.Background(), &b))
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "eebus,2025-01-01T17:00:00Z,2025-01-01T19:00:00Z,7200,4200,8.000,4000,4100,0,true", lines[1])
+
+	s := pp.Summary()
+	assert.Equal(t, 2*time.Hour, s.Duration)
+	assert.Equal(t, 0, s.Violations)
+	assert.Equal(t, Module3, s.Module)
+}
+
+type testTariff api.Rates
+
+func (t testTariff) Rates() (api.Rates, error) { return api.Rates(t), nil }
+func (t testTariff) Type() api.TariffType      { return api.TariffTypePriceStatic }
+
+func TestGridFee(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rate := func(h int, v float64) api.Rate {
+		return api.Rate{Start: start.Add(time.Duration(h) * time.Hour), End: start.Add(time.Duration(h+1) * time.Hour), Value: v}
+	}
+
+	grid := testTariff{rate(0, 0.30), rate(18, 0.30)}
+	variable := testTariff{rate(0, 0.02), rate(18, 0.12)}
+
+	_, err := NewGridFee(grid, Module2, 0, 0, nil)
+	assert.Error(t, err)
+
+	m1, err := NewGridFee(grid, Module1, 0, 0, nil)
+	require.NoError(t, err)
+	rr, _ := m1.Rates()
+	assert.Equal(t, api.Rates(grid), rr)
+
+	m2, err := NewGridFee(grid, Module2, 0.10, 0, nil)
+	require.NoError(t, err)
+	rr, _ = m2.Rates()
+	assert.InDelta(t, 0.24, rr[0].Value, 1e-9)
+	assert.InDelta(t, 0.24, rr[1].Value, 1e-9)
+	assert.Equal(t, Module2, TariffModule(m2))
+	assert.Equal(t, ModuleNone, TariffModule(grid))
+
+	m2, err = NewGridFee(grid, Module2, 0.10, 0.5, nil)
+	require.NoError(t, err)
+	rr, _ = m2.Rates()
+	assert.InDelta(t, 0.25, rr[0].Value, 1e-9)
+
+	_, err = NewGridFee(grid, Module2, 0.10, 1.5, nil)
+	assert.Error(t, err)
+
+	m3, err := NewGridFee(grid, Module3, 0.10, 0, variable)
+	require.NoError(t, err)
+	rr, _ = m3.Rates()
+	assert.InDelta(t, 0.22, rr[0].Value, 1e-9)
+	assert.InDelta(t, 0.32, rr[1].Value, 1e-9)
+	assert.Equal(t, api.TariffTypePriceForecast, m3.Type())
+
+	// base rates are not modified
+	assert.Equal(t, 0.30, grid[0].Value)
+}
@@ -0,0 +1,124 @@
+package dimming
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Module is the §14a EnWG grid fee module
+type Module int
+
+const (
+	ModuleNone Module = iota
+	Module1           // flat annual network charge reduction
+	Module2           // reduced network charge per kWh
+	Module3           // time-variable network charge in addition to module 1
+)
+
+// DefaultReduction is the share of the network charge waived in module 2
+const DefaultReduction = 0.6
+
+// TariffModule returns the grid fee module applied to the grid tariff
+func TariffModule(t api.Tariff) Module {
+	if gf, ok := t.(*GridFee); ok {
+		return gf.module
+	}
+	return ModuleNone
+}
+
+// GridFee adjusts the grid tariff for the reduced network charge of the grid fee module
+type GridFee struct {
+	tariff    api.Tariff
+	module    Module
+	charge    float64
+	reduction float64
+	variable  api.Tariff
+}
+
+var _ api.Tariff = (*GridFee)(nil)
+
+// NewGridFee wraps the grid tariff. Charge is the regular network charge per kWh contained in the grid tariff,
+// reduction the share of it waived in module 2 (DefaultReduction if zero), variable is the time-variable network charge of module 3.
+func NewGridFee(tariff api.Tariff, m Module, charge, reduction float64, variable api.Tariff) (api.Tariff, error) {
+	if tariff == nil {
+		return nil, errors.New("grid fee module requires grid tariff")
+	}
+
+	switch m {
+	case Module1:
+	case Module2:
+		if charge <= 0 {
+			return nil, errors.New("module 2 requires network charge")
+		}
+		if reduction == 0 {
+			reduction = DefaultReduction
+		}
+		if reduction < 0 || reduction > 1 {
+			return nil, fmt.Errorf("invalid module 2 reduction: %v", reduction)
+		}
+	case Module3:
+		if charge <= 0 || variable == nil {
+			return nil, errors.New("module 3 requires network charge and variable network charge tariff")
+		}
+	default:
+		return nil, fmt.Errorf("invalid grid fee module: %d", m)
+	}
+
+	t := &GridFee{
+		tariff:    tariff,
+		module:    m,
+		charge:    charge,
+		reduction: reduction,
+		variable:  variable,
+	}
+
+	return t, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *GridFee) Rates() (api.Rates, error) {
+	rr, err := t.tariff.Rates()
+	if err != nil {
+		return nil, err
+	}
+
+	var variable api.Rates
+	if t.module == Module3 {
+		if variable, err = t.variable.Rates(); err != nil {
+			return nil, err
+		}
+	}
+
+	res := slices.Clone(rr)
+	for i, r := range res {
+		res[i].Value = r.Value + t.adjustment(r.Start, variable)
+	}
+
+	return res, nil
+}
+
+// adjustment returns the network charge difference at given time
+func (t *GridFee) adjustment(ts time.Time, variable api.Rates) float64 {
+	switch t.module {
+	case Module2:
+		return -t.reduction * t.charge
+	case Module3:
+		if r, err := variable.At(ts); err == nil {
+			return r.Value - t.charge
+		}
+	}
+
+	return 0
+}
+
+// Type implements the api.Tariff interface
+func (t *GridFee) Type() api.TariffType {
+	if t.module == Module3 {
+		return api.TariffTypePriceForecast
+	}
+	return t.tariff.Type()
+}
@@ -0,0 +1,146 @@
+package dimming
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Period is a consumption limit received from the grid operator and the controllable consumption while it was active
+type Period struct {
+	ID       uint          `json:"id" gorm:"primarykey"`
+	Source   string        `json:"source"`
+	Start    time.Time     `json:"start" gorm:"index"`
+	End      time.Time     `json:"end"`      // zero while active
+	Duration time.Duration `json:"duration"` // requested duration, 0 if until revoked
+	Limit    float64       `json:"limit"`    // W
+	Energy   float64       `json:"energy"`   // controllable consumption in kWh
+	MaxPower float64       `json:"maxPower"` // peak controllable power in W after settling
+	Exceeded time.Duration `json:"exceeded"` // time above limit after settling
+	Module   Module        `json:"module"`   // grid fee module while active
+	Updated  time.Time     `json:"-"`        // last persisted, used for closing periods interrupted by restart
+}
+
+// Active returns true if the limit has not ended yet
+func (p Period) Active() bool {
+	return p.End.IsZero()
+}
+
+// Compliant returns true if the controllable consumption did not exceed the limit after settling
+func (p Period) Compliant() bool {
+	return p.Exceeded == 0
+}
+
+// AvgPower returns the average controllable power in W
+func (p Period) AvgPower() float64 {
+	end := p.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	if d := end.Sub(p.Start); d > 0 {
+		return p.Energy * 1e3 / d.Hours()
+	}
+
+	return 0
+}
+
+// MarshalJSON adds the compliance result
+func (p Period) MarshalJSON() ([]byte, error) {
+	type period Period
+	return json.Marshal(struct {
+		period
+		AvgPower  float64 `json:"avgPower"`
+		Compliant bool    `json:"compliant"`
+	}{
+		period:    period(p),
+		AvgPower:  p.AvgPower(),
+		Compliant: p.Compliant(),
+	})
+}
+
+// Periods is a list of dimming periods
+type Periods []Period
+
+var _ api.CsvWriter = (*Periods)(nil)
+
+var csvHeader = []string{"source", "start", "end", "duration", "limit", "energy", "avgPower", "maxPower", "exceeded", "compliant"}
+
+// WriteCsv implements the api.CsvWriter interface
+func (pp *Periods) WriteCsv(_ context.Context, w io.Writer) error {
+	ww := csv.NewWriter(w)
+
+	if err := ww.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, p := range *pp {
+		var end string
+		if !p.Active() {
+			end = p.End.UTC().Format(time.RFC3339)
+		}
+
+		if err := ww.Write([]string{
+			p.Source,
+			p.Start.UTC().Format(time.RFC3339),
+			end,
+			strconv.Itoa(int(p.Duration.Seconds())),
+			strconv.FormatFloat(p.Limit, 'f', 0, 64),
+			strconv.FormatFloat(p.Energy, 'f', 3, 64),
+			strconv.FormatFloat(p.AvgPower(), 'f', 0, 64),
+			strconv.FormatFloat(p.MaxPower, 'f', 0, 64),
+			strconv.Itoa(int(p.Exceeded.Seconds())),
+			strconv.FormatBool(p.Compliant()),
+		}); err != nil {
+			return err
+		}
+	}
+
+	ww.Flush()
+
+	return ww.Error()
+}
+
+// Summary is the aggregated compliance result of a list of periods
+type Summary struct {
+	Periods    int           `json:"periods"`
+	Duration   time.Duration `json:"duration"`
+	Energy     float64       `json:"energy"`
+	Violations int           `json:"violations"`
+	Module     Module        `json:"module"`
+}
+
+// Summary aggregates the periods. The module is the grid fee module of the latest period.
+func (pp Periods) Summary() Summary {
+	res := Summary{
+		Periods: len(pp),
+	}
+
+	var latest time.Time
+
+	for _, p := range pp {
+		if p.Start.After(latest) {
+			latest = p.Start
+			res.Module = p.Module
+		}
+
+		end := p.End
+		if p.Active() {
+			end = time.Now()
+		}
+
+		res.Duration += end.Sub(p.Start)
+		res.Energy += p.Energy
+
+		if !p.Compliant() {
+			res.Violations++
+		}
+	}
+
+	return res
+}
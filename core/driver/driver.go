@@ -0,0 +1,154 @@
+package driver
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownTag = errors.New("unknown tag")
+	ErrNotAllowed = errors.New("loadpoint not allowed")
+)
+
+// Driver is a person identified by a charger identifier, e.g. an RFID tag
+type Driver struct {
+	Tag        string         `json:"tag" gorm:"primarykey"`
+	User       string         `json:"user"`
+	Vehicle    string         `json:"vehicle,omitempty"`                           // default vehicle name
+	Loadpoints []string       `json:"loadpoints,omitempty" gorm:"serializer:json"` // allowed loadpoint config names, empty for all
+	Mode       api.ChargeMode `json:"mode,omitempty"`                              // charge mode preset
+	Created    time.Time      `json:"created"`
+}
+
+// TableName implements gorm's tabler interface
+func (Driver) TableName() string {
+	return "drivers"
+}
+
+// AllowsLoadpoint checks if the driver may charge at the loadpoint identified by config name
+func (d Driver) AllowsLoadpoint(name string) bool {
+	return len(d.Loadpoints) == 0 || slices.Contains(d.Loadpoints, name)
+}
+
+func Init() error {
+	return db.Instance.AutoMigrate(new(Driver))
+}
+
+// normalize makes tags case-insensitive
+func normalize(tag string) string {
+	return strings.ToUpper(strings.TrimSpace(tag))
+}
+
+// All returns all registered drivers
+func All() ([]Driver, error) {
+	var res []Driver
+	err := db.Instance.Order("tag").Find(&res).Error
+	return res, err
+}
+
+// ByTag returns the driver identified by the tag
+func ByTag(tag string) (*Driver, error) {
+	var res Driver
+	err := db.Instance.Where("tag = ?", normalize(tag)).First(&res).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUnknownTag
+	}
+	return &res, err
+}
+
+// Save creates or updates the driver
+func Save(d Driver) (Driver, error) {
+	if d.Tag = normalize(d.Tag); d.Tag == "" {
+		return d, errors.New("missing tag")
+	}
+	if d.User == "" {
+		return d, errors.New("missing user")
+	}
+
+	if d.Mode != "" {
+		mode, err := api.ChargeModeString(string(d.Mode))
+		if err != nil {
+			return d, err
+		}
+		d.Mode = mode
+	}
+
+	if existing, err := ByTag(d.Tag); err == nil {
+		d.Created = existing.Created
+	} else {
+		d.Created = time.Now()
+	}
+
+	return d, db.Instance.Save(&d).Error
+}
+
+// Delete removes the driver identified by the tag
+func Delete(tag string) error {
+	res := db.Instance.Where("tag = ?", normalize(tag)).Delete(new(Driver))
+	if res.Error == nil && res.RowsAffected == 0 {
+		return ErrUnknownTag
+	}
+	return res.Error
+}
+
+// RejectUnknown returns true if unknown tags are not authorized to charge
+func RejectUnknown() bool {
+	res, _ := settings.Bool(keys.RejectUnknownTags)
+	return res
+}
+
+// SetRejectUnknown enables rejecting unknown tags
+func SetRejectUnknown(enable bool) error {
+	settings.SetBool(keys.RejectUnknownTags, enable)
+	return nil
+}
+
+// Authorize returns the driver identified by the tag if allowed to charge at the loadpoint identified by config name.
+// Unknown tags return nil unless rejected.
+func Authorize(tag, loadpoint string) (*Driver, error) {
+	if db.Instance == nil {
+		return nil, nil
+	}
+
+	d, err := ByTag(tag)
+	switch {
+	case errors.Is(err, ErrUnknownTag):
+		if RejectUnknown() {
+			return nil, err
+		}
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+
+	case !d.AllowsLoadpoint(loadpoint):
+		return nil, ErrNotAllowed
+	}
+
+	return d, nil
+}
+
+// Authorized checks if the tag may charge at any of the loadpoints identified by config name.
+// If the loadpoints are unknown, the tag must be allowed at any loadpoint.
+func Authorized(tag string, loadpoints ...string) bool {
+	if len(loadpoints) == 0 {
+		_, err := Authorize(tag, "")
+		return err == nil || errors.Is(err, ErrNotAllowed)
+	}
+
+	for _, lp := range loadpoints {
+		if _, err := Authorize(tag, lp); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
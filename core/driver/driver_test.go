@@ -0,0 +1,58 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorize(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, Init())
+
+	t.Cleanup(func() { _ = SetRejectUnknown(false) })
+
+	_, err := Save(Driver{Tag: "04a2b3", User: "alice"})
+	require.NoError(t, err)
+	_, err = Save(Driver{Tag: "04C4D5", User: "bob", Loadpoints: []string{"lp-2"}, Mode: "pv"})
+	require.NoError(t, err)
+	_, err = Save(Driver{Tag: "1", User: "eve", Mode: "turbo"})
+	assert.Error(t, err)
+
+	d, err := Authorize("04A2B3", "lp-1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", d.User)
+
+	d, err = Authorize("04c4d5", "lp-2")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", d.User)
+	assert.Equal(t, api.ModePV, d.Mode)
+
+	_, err = Authorize("04c4d5", "lp-1")
+	assert.ErrorIs(t, err, ErrNotAllowed)
+	assert.True(t, Authorized("04c4d5"))
+	assert.True(t, Authorized("04c4d5", "lp-1", "lp-2"))
+	assert.False(t, Authorized("04c4d5", "lp-1"), "rejected at charger's loadpoint")
+
+	// unknown tags
+	d, err = Authorize("ffff", "lp-1")
+	assert.NoError(t, err)
+	assert.Nil(t, d)
+	assert.True(t, Authorized("ffff"))
+
+	require.NoError(t, SetRejectUnknown(true))
+	_, err = Authorize("ffff", "lp-1")
+	assert.ErrorIs(t, err, ErrUnknownTag)
+	assert.False(t, Authorized("ffff"))
+	assert.False(t, Authorized("ffff", "lp-1"))
+
+	require.NoError(t, Delete("04A2B3"))
+	assert.ErrorIs(t, Delete("04A2B3"), ErrUnknownTag)
+
+	all, err := All()
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
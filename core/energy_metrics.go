@@ -1,5 +1,12 @@
 package core
 
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/core/session"
+)
+
 // EnergyMetrics calculates stats about the charged energy and gives you details about price or co2s
 type EnergyMetrics struct {
 	totalKWh          float64  // Total amount of energy used (kWh)
@@ -9,6 +16,12 @@ type EnergyMetrics struct {
 	currentGreenShare float64  // Current share of solar energy of site (0-1)
 	currentPrice      *float64 // Current price per kWh
 	currentCo2        *float64 // Current co2 emissions
+
+	breakdown           session.Breakdown // Energy by tariff slot and source
+	currentSlot         time.Time         // Current tariff slot
+	currentBatteryShare float64           // Current share of battery energy of site (0-1), part of green share
+	currentGridPrice    *float64          // Current grid price per kWh
+	currentFeedInPrice  *float64          // Current feed-in price per kWh, applied to self-produced energy
 }
 
 // SetEnvironment updates site information like solar share, price, co2 for use in later calculations
@@ -18,6 +31,14 @@ func (em *EnergyMetrics) SetEnvironment(greenShare float64, effPrice, effCo2 *fl
 	em.currentCo2 = effCo2
 }
 
+// SetSources updates the tariff slot, battery share and source prices for use in the session breakdown
+func (em *EnergyMetrics) SetSources(slot time.Time, batteryShare float64, gridPrice, feedInPrice *float64) {
+	em.currentSlot = slot
+	em.currentBatteryShare = batteryShare
+	em.currentGridPrice = gridPrice
+	em.currentFeedInPrice = feedInPrice
+}
+
 // Update sets the a new value for the total amount of charged energy and updated metrics based on environment values.
 // It returns the added total and green energy.
 func (em *EnergyMetrics) Update(chargedKWh float64) (float64, float64) {
@@ -29,6 +50,11 @@ func (em *EnergyMetrics) Update(chargedKWh float64) (float64, float64) {
 	em.totalKWh = chargedKWh
 	addedGreen := added * em.currentGreenShare
 	em.solarKWh += addedGreen
+	// breakdown
+	addedBattery := added * min(em.currentBatteryShare, em.currentGreenShare)
+	em.breakdown.Add(em.currentSlot, session.SourceGrid, added-addedGreen, em.currentGridPrice)
+	em.breakdown.Add(em.currentSlot, session.SourceSolar, addedGreen-addedBattery, em.currentFeedInPrice)
+	em.breakdown.Add(em.currentSlot, session.SourceBattery, addedBattery, em.currentFeedInPrice)
 	// optional values
 	if em.currentPrice != nil {
 		addedPrice := *em.currentPrice * added
@@ -55,6 +81,7 @@ func (em *EnergyMetrics) Reset() {
 	em.solarKWh = 0
 	em.price = nil
 	em.co2 = nil
+	em.breakdown = nil
 }
 
 // TotalWh returns the total energy in Wh
@@ -96,6 +123,20 @@ func (em *EnergyMetrics) Co2PerKWh() *float64 {
 	return &co2
 }
 
+// Breakdown returns the charged energy by tariff slot and source
+func (em *EnergyMetrics) Breakdown() session.Breakdown {
+	return slices.Clone(em.breakdown)
+}
+
+// SourceKWh returns the charged energy in kWh from given source
+func (em *EnergyMetrics) SourceKWh(source session.Source) *float64 {
+	if em.totalKWh == 0 {
+		return nil
+	}
+	res := em.breakdown.Energy(source)
+	return &res
+}
+
 // Publish publishes metrics with a given prefix
 func (em *EnergyMetrics) Publish(prefix string, p publisher) {
 	p.publish(prefix+"Energy", em.TotalWh())
@@ -2,6 +2,11 @@ package core
 
 import (
 	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func isEqualFloat64(a, b *float64) bool {
@@ -153,3 +158,46 @@ func TestEnergyMetrics(t *testing.T) {
 		t.Errorf("Metrics not properly reset %+v", s)
 	}
 }
+
+func TestEnergyMetricsBreakdown(t *testing.T) {
+	f := func(f float64) *float64 { return &f }
+
+	slot1 := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	slot2 := slot1.Add(time.Hour)
+
+	var em EnergyMetrics
+
+	// all grid
+	em.SetSources(slot1, 0, f(0.3), f(0.1))
+	em.SetEnvironment(0, f(0.3), nil)
+	em.Update(1)
+
+	// half solar, quarter battery
+	em.SetSources(slot2, 0.25, f(0.2), f(0.1))
+	em.SetEnvironment(0.75, f(0.125), nil)
+	em.Update(2)
+	em.Update(3)
+
+	b := em.Breakdown()
+	require.Len(t, b, 4)
+
+	assert.Equal(t, session.Item{Start: slot1, Source: session.SourceGrid, Energy: 1, Price: f(0.3)}, b[0])
+	assert.Equal(t, session.Item{Start: slot2, Source: session.SourceGrid, Energy: 0.5, Price: f(0.2)}, b[1])
+	assert.Equal(t, session.Item{Start: slot2, Source: session.SourceSolar, Energy: 1, Price: f(0.1)}, b[2])
+	assert.Equal(t, session.Item{Start: slot2, Source: session.SourceBattery, Energy: 0.5, Price: f(0.1)}, b[3])
+
+	assert.Equal(t, 1.5, *em.SourceKWh(session.SourceGrid))
+	assert.Equal(t, 1.0, *em.SourceKWh(session.SourceSolar))
+	assert.Equal(t, 0.5, *em.SourceKWh(session.SourceBattery))
+
+	// breakdown cost matches total price
+	var cost float64
+	for _, it := range b {
+		cost += *it.Cost()
+	}
+	assert.InDelta(t, *em.Price(), cost, 1e-9)
+
+	em.Reset()
+	assert.Empty(t, em.Breakdown())
+	assert.Nil(t, em.SourceKWh(session.SourceGrid))
+}
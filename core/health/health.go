@@ -0,0 +1,349 @@
+package health
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// device classes matching templates.Class
+const (
+	Charger = "charger"
+	Meter   = "meter"
+	Vehicle = "vehicle"
+	Tariff  = "tariff"
+)
+
+// push events
+const (
+	EventUnhealthy = "unhealthy" // device failing
+	EventStale     = "stale"     // device not failing but data stale
+	EventRecovered = "recovered"
+)
+
+const (
+	MaxFailures = 3   // consecutive failures before a device becomes unhealthy
+	alpha       = 0.1 // smoothing factor for error rate and latency
+)
+
+var ErrUnknownDevice = errors.New("unknown device")
+
+// maxAge is the duration without successful read after which a device's data is considered stale
+var maxAge = map[string]time.Duration{
+	Charger: 5 * time.Minute,
+	Meter:   5 * time.Minute,
+	Vehicle: 3 * time.Hour,
+	Tariff:  time.Hour,
+}
+
+// Status is the health status of a device
+type Status struct {
+	Class               string    `json:"class"`
+	Name                string    `json:"name"`
+	Healthy             bool      `json:"healthy"`
+	Stale               bool      `json:"stale"`
+	LastSuccess         time.Time `json:"lastSuccess,omitzero"`
+	LastFailure         time.Time `json:"lastFailure,omitzero"`
+	LastError           string    `json:"lastError,omitempty"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	Requests            int64     `json:"requests"`
+	Errors              int64     `json:"errors"`
+	ErrorRate           float64   `json:"errorRate"` // smoothed ratio of failed requests
+	Latency             int64     `json:"latency"`   // smoothed request duration in ms
+}
+
+// Tracker tracks the health of a single device
+type Tracker struct {
+	mu          sync.Mutex
+	class, name string
+	maxAge      time.Duration
+	since       time.Time // start of the current tracking period
+	idle        bool      // device is not expected to be read
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   error
+	failures    int
+	requests    int64
+	errors      int64
+	errorRate   float64
+	latency     time.Duration
+	healthy     bool
+}
+
+var (
+	mu       sync.Mutex
+	trackers = make(map[string]*Tracker)
+	notifier func(Status)
+	log      = util.NewLogger("health")
+)
+
+func key(class, name string) string {
+	return class + "/" + name
+}
+
+// Track returns the tracker for the device, creating it if required.
+// Returns nil if the device name is empty.
+func Track(class, name string) *Tracker {
+	if name == "" {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if t, ok := trackers[key(class, name)]; ok {
+		return t
+	}
+
+	t := &Tracker{
+		class:   class,
+		name:    name,
+		maxAge:  maxAge[class],
+		since:   time.Now(),
+		healthy: true,
+	}
+	trackers[key(class, name)] = t
+
+	return t
+}
+
+// Get returns the health status of the device
+func Get(class, name string) (Status, error) {
+	mu.Lock()
+	t, ok := trackers[key(class, name)]
+	mu.Unlock()
+
+	if !ok {
+		return Status{}, ErrUnknownDevice
+	}
+
+	return t.Status(), nil
+}
+
+// All returns the health status of all tracked devices
+func All() []Status {
+	mu.Lock()
+	res := make([]Status, 0, len(trackers))
+	for _, t := range trackers {
+		res = append(res, t.Status())
+	}
+	mu.Unlock()
+
+	slices.SortFunc(res, func(a, b Status) int {
+		return strings.Compare(key(a.Class, a.Name), key(b.Class, b.Name))
+	})
+
+	return res
+}
+
+// Remove stops tracking the device
+func Remove(class, name string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	delete(trackers, key(class, name))
+}
+
+// SetNotifier registers the callback invoked when a device becomes unhealthy or recovers
+func SetNotifier(fn func(Status)) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	notifier = fn
+}
+
+// Observe records a single device request started at the given time and its result
+func (t *Tracker) Observe(start time.Time, err error) {
+	t.Request(start, err)
+	t.Result(err)
+}
+
+// Request records the latency and error rate of a device request attempt started at the given time.
+// Use for each attempt of a retried read whose final outcome is recorded by Result.
+func (t *Tracker) Request(start time.Time, err error) {
+	if t == nil {
+		return
+	}
+
+	d := time.Since(start)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.requests++; t.requests == 1 {
+		t.latency = d
+	} else {
+		t.latency += time.Duration(alpha * float64(d-t.latency))
+	}
+
+	var failed float64
+	if err != nil {
+		failed = 1
+		t.errors++
+	}
+	t.errorRate += alpha * (failed - t.errorRate)
+}
+
+// Measure wraps the device read to record each attempt as request
+func Measure[T any](t *Tracker, fn func() (T, error)) func() (T, error) {
+	return func() (T, error) {
+		start := time.Now()
+		res, err := fn()
+		t.Request(start, err)
+		return res, err
+	}
+}
+
+// Result records the outcome of a device read and updates the device's health
+func (t *Tracker) Result(err error) {
+	if t == nil {
+		return
+	}
+
+	now := time.Now()
+
+	t.mu.Lock()
+
+	if err == nil {
+		t.lastSuccess = now
+		t.failures = 0
+	} else {
+		t.failures++
+		t.lastFailure = now
+		t.lastError = err
+	}
+
+	status, changed := t.update(now)
+
+	t.mu.Unlock()
+
+	if changed {
+		t.notify(status)
+	}
+}
+
+// SetIdle suspends or resumes the stale check for devices that are only read while in use
+func (t *Tracker) SetIdle(idle bool) {
+	if t == nil {
+		return
+	}
+
+	now := time.Now()
+
+	t.mu.Lock()
+
+	if t.idle && !idle {
+		t.since = now
+	}
+	t.idle = idle
+
+	status, changed := t.update(now)
+
+	t.mu.Unlock()
+
+	if changed {
+		t.notify(status)
+	}
+}
+
+// Evaluate updates the health of all tracked devices, detecting devices that stopped providing data
+func Evaluate(now time.Time) {
+	mu.Lock()
+	tt := slices.Collect(maps.Values(trackers))
+	mu.Unlock()
+
+	for _, t := range tt {
+		t.mu.Lock()
+		status, changed := t.update(now)
+		t.mu.Unlock()
+
+		if changed {
+			t.notify(status)
+		}
+	}
+}
+
+// Run evaluates the health of all tracked devices periodically
+func Run(interval time.Duration) {
+	for tick := time.Tick(interval); ; {
+		Evaluate(<-tick)
+	}
+}
+
+// update evaluates the device's health and returns the status and whether the health has changed
+func (t *Tracker) update(now time.Time) (Status, bool) {
+	healthy := t.evaluate(now)
+	changed := healthy != t.healthy
+	t.healthy = healthy
+
+	return t.status(now), changed
+}
+
+// notify logs the health change and invokes the notifier
+func (t *Tracker) notify(status Status) {
+	switch {
+	case status.Healthy:
+		log.INFO.Printf("%s %s: recovered", t.class, t.name)
+	case status.Stale:
+		log.WARN.Printf("%s %s: unhealthy, no data within %v", t.class, t.name, t.maxAge)
+	default:
+		log.WARN.Printf("%s %s: unhealthy after %d failures: %s", t.class, t.name, status.ConsecutiveFailures, status.LastError)
+	}
+
+	mu.Lock()
+	fn := notifier
+	mu.Unlock()
+
+	if fn != nil {
+		fn(status)
+	}
+}
+
+// stale checks if the device has not been read successfully within max age of the last success or tracking period start
+func (t *Tracker) stale(now time.Time) bool {
+	last := t.lastSuccess
+	if t.since.After(last) {
+		last = t.since
+	}
+
+	return !t.idle && t.maxAge > 0 && now.Sub(last) > t.maxAge
+}
+
+func (t *Tracker) evaluate(now time.Time) bool {
+	return t.failures < MaxFailures && !t.stale(now)
+}
+
+func (t *Tracker) status(now time.Time) Status {
+	res := Status{
+		Class:               t.class,
+		Name:                t.name,
+		Healthy:             t.healthy,
+		Stale:               t.stale(now),
+		LastSuccess:         t.lastSuccess,
+		LastFailure:         t.lastFailure,
+		ConsecutiveFailures: t.failures,
+		Requests:            t.requests,
+		Errors:              t.errors,
+		ErrorRate:           t.errorRate,
+		Latency:             t.latency.Milliseconds(),
+	}
+
+	if t.lastError != nil {
+		res.LastError = t.lastError.Error()
+	}
+
+	return res
+}
+
+// Status returns the device's health status
+func (t *Tracker) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status(time.Now())
+}
@@ -0,0 +1,151 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	var events []Status
+	SetNotifier(func(s Status) {
+		events = append(events, s)
+	})
+	defer SetNotifier(nil)
+
+	assert.Nil(t, Track(Meter, ""))
+
+	tr := Track(Meter, "grid")
+	assert.Same(t, tr, Track(Meter, "grid"))
+	defer Remove(Meter, "grid")
+
+	tr.Observe(time.Now(), nil)
+
+	for range MaxFailures - 1 {
+		tr.Observe(time.Now(), errors.New("timeout"))
+	}
+	assert.True(t, tr.Status().Healthy)
+	assert.Empty(t, events)
+
+	tr.Observe(time.Now(), errors.New("timeout"))
+
+	s, err := Get(Meter, "grid")
+	require.NoError(t, err)
+	assert.False(t, s.Healthy)
+	assert.Equal(t, MaxFailures, s.ConsecutiveFailures)
+	assert.Equal(t, int64(MaxFailures+1), s.Requests)
+	assert.Equal(t, int64(MaxFailures), s.Errors)
+	assert.Equal(t, "timeout", s.LastError)
+	require.Len(t, events, 1)
+	assert.False(t, events[0].Healthy)
+
+	tr.Observe(time.Now(), nil)
+	assert.True(t, tr.Status().Healthy)
+	require.Len(t, events, 2)
+	assert.True(t, events[1].Healthy)
+
+	_, err = Get(Meter, "pv")
+	assert.ErrorIs(t, err, ErrUnknownDevice)
+}
+
+func TestTrackerStale(t *testing.T) {
+	tr := Track(Vehicle, "car")
+	defer Remove(Vehicle, "car")
+
+	tr.Observe(time.Now(), nil)
+
+	// single failure long after last success
+	tr.mu.Lock()
+	tr.lastSuccess = time.Now().Add(-maxAge[Vehicle] - time.Minute)
+	tr.since = tr.lastSuccess
+	tr.mu.Unlock()
+
+	tr.Observe(time.Now(), errors.New("unavailable"))
+
+	s := tr.Status()
+	assert.True(t, s.Stale)
+	assert.False(t, s.Healthy)
+	assert.Equal(t, 1, s.ConsecutiveFailures)
+}
+
+func TestTrackerStaleWithoutFailures(t *testing.T) {
+	var events []Status
+	SetNotifier(func(s Status) {
+		events = append(events, s)
+	})
+	defer SetNotifier(nil)
+
+	tr := Track(Meter, "pv")
+	defer Remove(Meter, "pv")
+
+	now := time.Now()
+	tr.Observe(now, nil)
+
+	// device silently stopped providing data
+	Evaluate(now.Add(maxAge[Meter] - time.Second))
+	assert.Empty(t, events)
+
+	Evaluate(now.Add(maxAge[Meter] + time.Second))
+	require.Len(t, events, 1)
+	assert.False(t, events[0].Healthy)
+	assert.True(t, events[0].Stale)
+	assert.Zero(t, events[0].ConsecutiveFailures)
+
+	tr.Observe(time.Now(), nil)
+	require.Len(t, events, 2)
+	assert.True(t, events[1].Healthy)
+}
+
+func TestTrackerIdle(t *testing.T) {
+	tr := Track(Vehicle, "idle")
+	defer Remove(Vehicle, "idle")
+
+	tr.SetIdle(true)
+
+	// idle devices are not stale
+	tr.mu.Lock()
+	tr.since = time.Now().Add(-maxAge[Vehicle] - time.Minute)
+	tr.mu.Unlock()
+
+	Evaluate(time.Now())
+	assert.True(t, tr.Status().Healthy)
+
+	// resuming starts a new tracking period
+	tr.SetIdle(false)
+	Evaluate(time.Now().Add(maxAge[Vehicle] - time.Minute))
+	assert.True(t, tr.Status().Healthy)
+
+	Evaluate(time.Now().Add(maxAge[Vehicle] + time.Minute))
+	assert.False(t, tr.Status().Healthy)
+}
+
+func TestTrackerRetries(t *testing.T) {
+	tr := Track(Meter, "retry")
+	defer Remove(Meter, "retry")
+
+	var attempts int
+	fn := Measure(tr, func() (float64, error) {
+		if attempts++; attempts < MaxFailures+1 {
+			return 0, errors.New("timeout")
+		}
+		return 1, nil
+	})
+
+	// failed attempts of a successful read count as errors, not failures
+	for {
+		if _, err := fn(); err == nil {
+			tr.Result(nil)
+			break
+		}
+	}
+
+	s := tr.Status()
+	assert.True(t, s.Healthy)
+	assert.Equal(t, int64(MaxFailures+1), s.Requests)
+	assert.Equal(t, int64(MaxFailures), s.Errors)
+	assert.Zero(t, s.ConsecutiveFailures)
+	assert.Greater(t, s.ErrorRate, 0.0)
+}
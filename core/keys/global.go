@@ -21,4 +21,7 @@ const (
 	DemoMode           = "demoMode"
 	AuthDisabled       = "authDisabled"
 	AuthProviders      = "authProviders"
+	SessionLedger      = "sessionLedger"
+	SessionLedgerKey   = "sessionLedgerKey"
+	RejectUnknownTags  = "rejectUnknownTags"
 )
@@ -10,6 +10,7 @@ const (
 	Circuit          = "circuit"     // circuit ref
 	DefaultVehicle   = "vehicle"     // default vehicle ref
 	Priority         = "priority"    // priority
+	Rotation         = "rotation"    // phase rotation
 	MinCurrent       = "minCurrent"  // min current
 	MaxCurrent       = "maxCurrent"  // max current
 	MinSoc           = "minSoc"      // min soc
@@ -84,10 +85,17 @@ const (
 	// repeating plans
 	RepeatingPlans = "repeatingPlans" // key to access all repeating plans in db
 
+	// charge model
+	ChargeModel = "chargeModel" // key to access the learned vehicle charge model in db
+
 	// remote control
 	RemoteDisabled       = "remoteDisabled"       // remote disabled
 	RemoteDisabledSource = "remoteDisabledSource" // remote disabled source
 
+	// driver
+	DriverUser     = "driverUser"     // identified driver
+	DriverRejected = "driverRejected" // identifier rejected
+
 	// vehicle
 	VehicleName            = "vehicleName"            // vehicle name
 	VehicleTitle           = "vehicleTitle"           // vehicle title
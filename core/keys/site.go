@@ -11,6 +11,7 @@ const (
 	GridConfigured        = "gridConfigured"
 	Grid                  = "grid"
 	HomePower             = "homePower"
+	Prioritization        = "prioritization"
 	PrioritySoc           = "prioritySoc"
 	Pv                    = "pv"
 	PvEnergy              = "pvEnergy"
@@ -44,15 +45,17 @@ const (
 	BatteryDischargeControl = "batteryDischargeControl"
 	BatteryGridChargeLimit  = "batteryGridChargeLimit"
 	BatteryGridChargeActive = "batteryGridChargeActive"
+	BatteryOptimizer        = "batteryOptimizer"
 	BufferSoc               = "bufferSoc"
 	BufferStartSoc          = "bufferStartSoc"
 
 	// battery status
-	Battery       = "battery"
-	BatteryEnergy = "batteryEnergy"
-	BatteryMode   = "batteryMode"
-	BatteryPower  = "batteryPower"
-	BatterySoc    = "batterySoc"
+	Battery         = "battery"
+	BatteryEnergy   = "batteryEnergy"
+	BatteryMode     = "batteryMode"
+	BatteryPower    = "batteryPower"
+	BatterySchedule = "batterySchedule"
+	BatterySoc      = "batterySoc"
 
 	// external battery control
 	BatteryModeExternal = "batteryModeExternal"
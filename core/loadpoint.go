@@ -15,6 +15,8 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/core/driver"
+	"github.com/evcc-io/evcc/core/health"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/planner"
@@ -94,6 +96,7 @@ type Loadpoint struct {
 	ChargerRef string `mapstructure:"charger"` // Charger reference
 	VehicleRef string `mapstructure:"vehicle"` // Vehicle reference
 	MeterRef   string `mapstructure:"meter"`   // Charge meter reference
+	Rotation   string // Grid phases the charger phases are wired to, e.g. L2L3L1
 
 	Soc             loadpoint.SocConfig
 	Enable, Disable loadpoint.ThresholdConfig
@@ -114,6 +117,7 @@ type Loadpoint struct {
 	minCurrent               float64  // PV mode: start current	Min+PV mode: min current
 	maxCurrent               float64  // Max allowed current. Physically ensured by the charger
 	phasesConfigured         int      // Charger configured phase mode 0/1/3
+	rotation                 []int    // Grid phase index of each charger phase, nil for L1L2L3
 	limitSoc                 int      // Session limit for soc
 	limitEnergy              float64  // Session limit for energy
 	smartCostLimit           *float64 // always charge if consumption cost is below this value
@@ -131,6 +135,8 @@ type Loadpoint struct {
 	phasesSwitched      time.Time // Phase switch timestamp
 	vehicleDetectTicker *clock.Ticker
 	vehicleIdentifier   string
+	driver              *driver.Driver // identified driver
+	driverRejected      bool           // identifier rejected by driver registry
 
 	charger          api.Charger
 	chargeTimer      api.ChargeTimer
@@ -146,11 +152,12 @@ type Loadpoint struct {
 
 	// charge planning
 	planner          *planner.Planner
-	planTime         time.Time     // time goal
-	planPrecondition time.Duration // precondition duration
-	planEnergy       float64       // Plan charge energy in kWh (dumb vehicles)
-	planSlotEnd      time.Time     // current plan slot end time
-	planActive       bool          // charge plan exists and has a currently active slot
+	jointPlan        *planner.Result // site-level joint plan, guarded by mutex
+	planTime         time.Time       // time goal
+	planPrecondition time.Duration   // precondition duration
+	planEnergy       float64         // Plan charge energy in kWh (dumb vehicles)
+	planSlotEnd      time.Time       // current plan slot end time
+	planActive       bool            // charge plan exists and has a currently active slot
 
 	// cached state
 	status         api.ChargeStatus       // Charger status
@@ -226,6 +233,14 @@ func NewLoadpointFromConfig(log *util.Logger, settings settings.Settings, other
 		}
 	}
 
+	if lp.Rotation != "" {
+		rotation, err := parseRotation(lp.Rotation)
+		if err != nil {
+			return lp, err
+		}
+		lp.setRotation(rotation)
+	}
+
 	if lp.MeterRef != "" {
 		dev, err := config.Meters().ByName(lp.MeterRef)
 		if err != nil {
@@ -308,6 +323,14 @@ func NewLoadpoint(log *util.Logger, settings settings.Settings) *Loadpoint {
 	return lp
 }
 
+// setClock replaces the loadpoint's clock including the clock of its wakeup timer
+func (lp *Loadpoint) setClock(clock clock.Clock) {
+	lp.clock = clock
+	if lp.wakeUpTimer != nil {
+		lp.wakeUpTimer.clck = clock
+	}
+}
+
 // restoreSettings restores loadpoint settings
 func (lp *Loadpoint) restoreSettings() {
 	if testing.Testing() {
@@ -335,6 +358,11 @@ func (lp *Loadpoint) restoreSettings() {
 	if v, err := lp.settings.Int(keys.Priority); err == nil {
 		lp.setPriority(int(v))
 	}
+	if v, err := lp.settings.String(keys.Rotation); err == nil {
+		if rotation, err := parseRotation(v); err == nil {
+			lp.setRotation(rotation)
+		}
+	}
 	if v, err := lp.settings.Int(keys.PhasesConfigured); err == nil && (v > 0 || lp.hasPhaseSwitching()) {
 		lp.setPhasesConfigured(int(v))
 	}
@@ -433,6 +461,7 @@ func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 
 	// add wakeup timer
 	lp.wakeUpTimer = NewTimer()
+	lp.wakeUpTimer.clck = lp.clock
 }
 
 // pushEvent sends push messages to clients
@@ -538,10 +567,14 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	// forget startup energy offset
 	lp.chargedAtStartup = 0
 
-	// remove charger vehicle id and stop potential detection
+	// remove charger vehicle id and driver and stop potential detection
 	lp.setVehicleIdentifier("")
+	lp.setDriver(nil, false)
 	lp.stopVehicleDetection()
 
+	// learn vehicle charge model from completed session
+	lp.learnChargeModel()
+
 	// set default mode on disconnect
 	lp.defaultMode()
 
@@ -863,16 +896,17 @@ func (lp *Loadpoint) setLimit(current float64) error {
 
 	// apply circuit limits
 	if lp.circuit != nil {
-		var actualCurrent float64
+		activePhases := lp.ActivePhases()
+
+		var actualCurrents [3]float64
 		if lp.chargeCurrents != nil {
-			actualCurrent = max(lp.chargeCurrents[0], lp.chargeCurrents[1], lp.chargeCurrents[2])
+			actualCurrents = lp.gridCurrents(lp.chargeCurrents)
 		} else if lp.charging() {
-			actualCurrent = lp.offeredCurrent
+			actualCurrents = lp.gridCurrents(lp.offeredCurrents(activePhases))
 		}
 
-		currentLimit := lp.circuit.ValidateCurrent(actualCurrent, current)
+		currentLimit := lp.circuit.ValidatePhaseCurrent(actualCurrents, current, lp.gridPhases(activePhases))
 
-		activePhases := lp.ActivePhases()
 		powerLimit := lp.circuit.ValidatePower(lp.chargePower, currentToPower(current, activePhases))
 		currentLimitViaPower := powerToCurrent(powerLimit, activePhases)
 
@@ -1085,7 +1119,10 @@ func statusEvents(prevStatus, status api.ChargeStatus) []string {
 func (lp *Loadpoint) updateChargerStatus() (bool, error) {
 	var welcomeCharge bool
 
+	start := time.Now()
 	status, err := lp.charger.Status()
+	health.Track(health.Charger, lp.GetChargerRef()).Observe(start, err)
+
 	if err != nil {
 		return false, fmt.Errorf("charger status: %w", err)
 	}
@@ -1505,7 +1542,10 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower, batteryBoostPo
 
 // UpdateChargePowerAndCurrents updates charge meter power and currents for load management
 func (lp *Loadpoint) UpdateChargePowerAndCurrents() float64 {
-	power, err := backoff.RetryWithData(lp.chargeMeter.CurrentPower, modbus.Backoff())
+	tracker := health.Track(health.Meter, lp.GetMeterRef())
+	power, err := backoff.RetryWithData(health.Measure(tracker, lp.chargeMeter.CurrentPower), modbus.Backoff())
+	tracker.Result(err)
+
 	if err == nil {
 		lp.Lock()
 		lp.chargePower = power // update value if no error
@@ -1707,6 +1747,10 @@ func (lp *Loadpoint) publishSocAndRange() {
 			return
 		}
 
+		if lp.charging() {
+			socEstimator.Sample(lp.chargePower)
+		}
+
 		lp.vehicleSoc = f
 		lp.log.DEBUG.Printf("vehicle soc: %.0f%%", lp.vehicleSoc)
 		lp.publish(keys.VehicleSoc, lp.vehicleSoc)
@@ -1717,7 +1761,11 @@ func (lp *Loadpoint) publishSocAndRange() {
 
 		// vehicle limit
 		if vs, ok := lp.GetVehicle().(api.SocLimiter); ok {
-			if limit, err := vs.GetLimitSoc(); err == nil {
+			start := time.Now()
+			limit, err := vs.GetLimitSoc()
+			vehicle.Observe(lp.GetVehicle(), start, err)
+
+			if err == nil {
 				apiLimitSoc = int(limit)
 				lp.log.DEBUG.Printf("vehicle soc limit: %d%%", limit)
 				// https://github.com/evcc-io/evcc/issues/13349
@@ -1740,7 +1788,11 @@ func (lp *Loadpoint) publishSocAndRange() {
 
 		// range
 		if vs, ok := lp.GetVehicle().(api.VehicleRange); ok {
-			if rng, err := vs.Range(); err == nil {
+			start := time.Now()
+			rng, err := vs.Range()
+			vehicle.Observe(lp.GetVehicle(), start, err)
+
+			if err == nil {
 				lp.log.DEBUG.Printf("vehicle range: %dkm", rng)
 				lp.publish(keys.VehicleRange, rng)
 			} else if !loadpoint.AcceptableError(err) {
@@ -1794,12 +1846,12 @@ func (lp *Loadpoint) shouldBeConsistent() bool {
 
 // chargerUpdateCompleted returns true if enable command should be already processed by the charger (so we can try to sync charger and loadpoint)
 func (lp *Loadpoint) chargerUpdateCompleted() bool {
-	return time.Since(lp.chargerSwitched) > chargerSwitchDuration
+	return lp.clock.Since(lp.chargerSwitched) > chargerSwitchDuration
 }
 
 // phaseSwitchCompleted returns true if phase switch command should be already processed by the charger (so we can try to sync charger and loadpoint and are able to measure currents)
 func (lp *Loadpoint) phaseSwitchCompleted() bool {
-	return time.Since(lp.phasesSwitched) > phaseSwitchDuration
+	return lp.clock.Since(lp.phasesSwitched) > phaseSwitchDuration
 }
 
 // Update is the main control function. It reevaluates meters and charger state
@@ -1895,6 +1947,10 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, consumption, f
 	case lp.scalePhasesRequired():
 		err = lp.scalePhases(lp.phasesConfigured)
 
+	case lp.driverRejected:
+		// identifier not authorized
+		err = lp.setLimit(0)
+
 	case lp.remoteControlled(loadpoint.RemoteHardDisable):
 		remoteDisabled = loadpoint.RemoteHardDisable
 		fallthrough
@@ -1927,7 +1983,7 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, consumption, f
 	case mode == api.ModeMinPV || mode == api.ModePV:
 		// cheap tariff
 		if smartCostActive {
-			rate, _ := consumption.At(time.Now())
+			rate, _ := consumption.At(lp.clock.Now())
 			lp.log.DEBUG.Printf("smart consumption active: %.2f", rate.Value)
 			err = lp.fastCharging()
 			lp.resetPhaseTimer()
@@ -1937,7 +1993,7 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, consumption, f
 
 		// attractive feedin
 		if smartFeedInPriorityActive {
-			rate, _ := feedin.At(time.Now())
+			rate, _ := feedin.At(lp.clock.Now())
 			lp.log.DEBUG.Printf("smart feed-in active: %.2f", rate.Value)
 
 			var targetCurrent float64
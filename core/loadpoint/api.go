@@ -21,6 +21,8 @@ type API interface {
 
 	// GetStatus returns the charging status
 	GetStatus() api.ChargeStatus
+	// HasChargerFeature returns true if the charger provides the given feature
+	HasChargerFeature(api.Feature) bool
 
 	//
 	// references
@@ -57,6 +59,10 @@ type API interface {
 	GetPriority() int
 	// SetPriority sets the priority
 	SetPriority(int)
+	// GetRotation returns the grid phases the charger phases are wired to
+	GetRotation() string
+	// SetRotation sets the grid phases the charger phases are wired to
+	SetRotation(string) error
 	// GetMinCurrent returns the min charging current
 	GetMinCurrent() float64
 	// SetMinCurrent sets the min charging current
@@ -102,6 +108,8 @@ type API interface {
 	EffectivePlanId() int
 	// EffectivePlanTime returns the effective plan time
 	EffectivePlanTime() time.Time
+	// EffectiveLimitSoc returns the effective session limit soc
+	EffectiveLimitSoc() int
 	// EffectiveMinPower returns the min charging power for the minimum active phases
 	EffectiveMinPower() float64
 	// EffectiveMaxPower returns the max charging power taking active phases into account
@@ -127,6 +135,8 @@ type API interface {
 	SocBasedPlanning() bool
 	// GetPlan creates a charging plan
 	GetPlan(targetTime time.Time, requiredDuration, precondition time.Duration) api.Rates
+	// GetPlanShortfall returns the charging duration that cannot be planned before plan time
+	GetPlanShortfall(targetTime time.Time, requiredDuration, precondition time.Duration) time.Duration
 
 	// GetSocConfig returns the soc poll settings
 	GetSocConfig() SocConfig
@@ -188,6 +198,8 @@ type API interface {
 	GetChargePowerFlexibility(rates api.Rates) float64
 	// GetMaxPhaseCurrent returns max phase current
 	GetMaxPhaseCurrent() float64
+	// GetPhaseCurrents returns the currents per grid phase
+	GetPhaseCurrents() [3]float64
 
 	//
 	// charge progress
@@ -199,6 +211,8 @@ type API interface {
 	GetRemainingDuration() time.Duration
 	// GetRemainingEnergy is the remaining charge energy in Wh
 	GetRemainingEnergy() float64
+	// GetSoc returns the vehicle soc
+	GetSoc() float64
 
 	//
 	// vehicles
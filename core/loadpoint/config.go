@@ -21,6 +21,7 @@ type DynamicConfig struct {
 	DefaultMode              string    `json:"defaultMode"`
 	Priority                 int       `json:"priority"`
 	PhasesConfigured         int       `json:"phasesConfigured"`
+	Rotation                 string    `json:"rotation"`
 	MinCurrent               float64   `json:"minCurrent"`
 	MaxCurrent               float64   `json:"maxCurrent"`
 	SmartCostLimit           *float64  `json:"smartCostLimit"`
@@ -75,6 +76,10 @@ func (payload DynamicConfig) Apply(lp API) error {
 		err = lp.SetPhasesConfigured(payload.PhasesConfigured)
 	}
 
+	if err == nil {
+		err = lp.SetRotation(payload.Rotation)
+	}
+
 	if err == nil && payload.MinCurrent != 0 {
 		err = lp.SetMinCurrent(payload.MinCurrent)
 	}
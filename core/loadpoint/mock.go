@@ -55,6 +55,20 @@ func (mr *MockAPIMockRecorder) ActivePhases() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivePhases", reflect.TypeOf((*MockAPI)(nil).ActivePhases))
 }
 
+// EffectiveLimitSoc mocks base method.
+func (m *MockAPI) EffectiveLimitSoc() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EffectiveLimitSoc")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// EffectiveLimitSoc indicates an expected call of EffectiveLimitSoc.
+func (mr *MockAPIMockRecorder) EffectiveLimitSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectiveLimitSoc", reflect.TypeOf((*MockAPI)(nil).EffectiveLimitSoc))
+}
+
 // EffectiveMaxPower mocks base method.
 func (m *MockAPI) EffectiveMaxPower() float64 {
 	m.ctrl.T.Helper()
@@ -391,6 +405,20 @@ func (mr *MockAPIMockRecorder) GetMode() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMode", reflect.TypeOf((*MockAPI)(nil).GetMode))
 }
 
+// GetPhaseCurrents mocks base method.
+func (m *MockAPI) GetPhaseCurrents() [3]float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhaseCurrents")
+	ret0, _ := ret[0].([3]float64)
+	return ret0
+}
+
+// GetPhaseCurrents indicates an expected call of GetPhaseCurrents.
+func (mr *MockAPIMockRecorder) GetPhaseCurrents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhaseCurrents", reflect.TypeOf((*MockAPI)(nil).GetPhaseCurrents))
+}
+
 // GetPhases mocks base method.
 func (m *MockAPI) GetPhases() int {
 	m.ctrl.T.Helper()
@@ -492,6 +520,20 @@ func (mr *MockAPIMockRecorder) GetPlanRequiredDuration(goal, maxPower any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanRequiredDuration", reflect.TypeOf((*MockAPI)(nil).GetPlanRequiredDuration), goal, maxPower)
 }
 
+// GetPlanShortfall mocks base method.
+func (m *MockAPI) GetPlanShortfall(targetTime time.Time, requiredDuration, precondition time.Duration) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlanShortfall", targetTime, requiredDuration, precondition)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetPlanShortfall indicates an expected call of GetPlanShortfall.
+func (mr *MockAPIMockRecorder) GetPlanShortfall(targetTime, requiredDuration, precondition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanShortfall", reflect.TypeOf((*MockAPI)(nil).GetPlanShortfall), targetTime, requiredDuration, precondition)
+}
+
 // GetPriority mocks base method.
 func (m *MockAPI) GetPriority() int {
 	m.ctrl.T.Helper()
@@ -534,6 +576,20 @@ func (mr *MockAPIMockRecorder) GetRemainingEnergy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemainingEnergy", reflect.TypeOf((*MockAPI)(nil).GetRemainingEnergy))
 }
 
+// GetRotation mocks base method.
+func (m *MockAPI) GetRotation() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRotation")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRotation indicates an expected call of GetRotation.
+func (mr *MockAPIMockRecorder) GetRotation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRotation", reflect.TypeOf((*MockAPI)(nil).GetRotation))
+}
+
 // GetSmartCostLimit mocks base method.
 func (m *MockAPI) GetSmartCostLimit() *float64 {
 	m.ctrl.T.Helper()
@@ -562,6 +618,20 @@ func (mr *MockAPIMockRecorder) GetSmartFeedInPriorityLimit() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartFeedInPriorityLimit", reflect.TypeOf((*MockAPI)(nil).GetSmartFeedInPriorityLimit))
 }
 
+// GetSoc mocks base method.
+func (m *MockAPI) GetSoc() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSoc")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetSoc indicates an expected call of GetSoc.
+func (mr *MockAPIMockRecorder) GetSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSoc", reflect.TypeOf((*MockAPI)(nil).GetSoc))
+}
+
 // GetSocConfig mocks base method.
 func (m *MockAPI) GetSocConfig() SocConfig {
 	m.ctrl.T.Helper()
@@ -646,6 +716,20 @@ func (mr *MockAPIMockRecorder) HasChargeMeter() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChargeMeter", reflect.TypeOf((*MockAPI)(nil).HasChargeMeter))
 }
 
+// HasChargerFeature mocks base method.
+func (m *MockAPI) HasChargerFeature(arg0 api.Feature) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasChargerFeature", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasChargerFeature indicates an expected call of HasChargerFeature.
+func (mr *MockAPIMockRecorder) HasChargerFeature(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChargerFeature", reflect.TypeOf((*MockAPI)(nil).HasChargerFeature), arg0)
+}
+
 // IsFastChargingActive mocks base method.
 func (m *MockAPI) IsFastChargingActive() bool {
 	m.ctrl.T.Helper()
@@ -910,6 +994,20 @@ func (mr *MockAPIMockRecorder) SetPriority(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPriority", reflect.TypeOf((*MockAPI)(nil).SetPriority), arg0)
 }
 
+// SetRotation mocks base method.
+func (m *MockAPI) SetRotation(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRotation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRotation indicates an expected call of SetRotation.
+func (mr *MockAPIMockRecorder) SetRotation(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRotation", reflect.TypeOf((*MockAPI)(nil).SetRotation), arg0)
+}
+
 // SetSmartCostLimit mocks base method.
 func (m *MockAPI) SetSmartCostLimit(limit *float64) {
 	m.ctrl.T.Helper()
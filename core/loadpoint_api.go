@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -240,6 +241,38 @@ func (lp *Loadpoint) SetPriority(prio int) {
 	}
 }
 
+// GetRotation returns the grid phases the charger phases are wired to
+func (lp *Loadpoint) GetRotation() string {
+	lp.RLock()
+	defer lp.RUnlock()
+	return formatRotation(lp.rotation)
+}
+
+// setRotation sets the grid phase index of each charger phase (no mutex)
+func (lp *Loadpoint) setRotation(rotation []int) {
+	lp.rotation = rotation
+	lp.publish(keys.Rotation, formatRotation(lp.rotation))
+	lp.settings.SetString(keys.Rotation, formatRotation(lp.rotation))
+}
+
+// SetRotation sets the grid phases the charger phases are wired to, e.g. L2L3L1
+func (lp *Loadpoint) SetRotation(s string) error {
+	rotation, err := parseRotation(s)
+	if err != nil {
+		return err
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set rotation:", s)
+	if !slices.Equal(lp.rotation, rotation) {
+		lp.setRotation(rotation)
+	}
+
+	return nil
+}
+
 // GetPhases returns the enabled phases
 func (lp *Loadpoint) GetPhases() int {
 	lp.RLock()
@@ -635,6 +668,16 @@ func (lp *Loadpoint) GetMaxPhaseCurrent() float64 {
 	return max(lp.chargeCurrents[0], lp.chargeCurrents[1], lp.chargeCurrents[2])
 }
 
+// GetPhaseCurrents returns the currents per grid phase
+func (lp *Loadpoint) GetPhaseCurrents() [3]float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	if lp.chargeCurrents == nil {
+		return lp.gridCurrents(lp.offeredCurrents(lp.activePhases()))
+	}
+	return lp.gridCurrents(lp.chargeCurrents)
+}
+
 // GetMinCurrent returns the min loadpoint current
 func (lp *Loadpoint) GetMinCurrent() float64 {
 	lp.RLock()
@@ -744,6 +787,13 @@ func (lp *Loadpoint) GetRemainingEnergy() float64 {
 	return lp.chargeRemainingEnergy
 }
 
+// GetSoc returns the vehicle soc
+func (lp *Loadpoint) GetSoc() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.vehicleSoc
+}
+
 // SetRemainingEnergy sets the remaining charge energy in Wh
 func (lp *Loadpoint) SetRemainingEnergy(chargeRemainingEnergy float64) {
 	lp.Lock()
@@ -14,6 +14,11 @@ func (lp *Loadpoint) chargerHasFeature(f api.Feature) bool {
 	return ok && slices.Contains(c.Features(), f)
 }
 
+// HasChargerFeature returns true if the charger provides the given feature
+func (lp *Loadpoint) HasChargerFeature(f api.Feature) bool {
+	return lp.chargerHasFeature(f)
+}
+
 // publishChargerFeature publishes availability of charger features
 func (lp *Loadpoint) publishChargerFeature(f api.Feature) {
 	lp.publish(keys.ChargerFeature+f.String(), lp.chargerHasFeature(f))
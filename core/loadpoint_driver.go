@@ -0,0 +1,72 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/driver"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// setDriver updates the identified driver and attributes the session
+func (lp *Loadpoint) setDriver(d *driver.Driver, rejected bool) {
+	lp.driver = d
+	lp.driverRejected = rejected
+
+	var user string
+	if d != nil {
+		user = d.User
+	}
+
+	lp.publish(keys.DriverUser, user)
+	lp.publish(keys.DriverRejected, rejected)
+
+	lp.updateSession(func(s *session.Session) {
+		s.User = user
+	})
+}
+
+// identifyDriver authorizes the identifier against the driver registry.
+// It returns false if the identifier is rejected. Otherwise, the driver's
+// default vehicle (if any) is returned and the charge mode preset applied.
+func (lp *Loadpoint) identifyDriver(id string) (api.Vehicle, bool) {
+	d, err := driver.Authorize(id, loadpointName(lp))
+	if err != nil {
+		lp.log.WARN.Printf("charger vehicle id %s: %v", id, err)
+		lp.setDriver(nil, true)
+		return nil, false
+	}
+
+	lp.setDriver(d, false)
+
+	if d == nil {
+		return nil, true
+	}
+
+	lp.log.INFO.Printf("driver identified: %s", d.User)
+
+	// release charger waiting for authorization
+	if c, ok := lp.charger.(api.Authorizer); ok {
+		if err := c.Authorize(id); err != nil {
+			lp.log.ERROR.Printf("charger authorize: %v", err)
+		}
+	}
+
+	var vehicle api.Vehicle
+	if d.Vehicle != "" {
+		if dev, err := config.Vehicles().ByName(d.Vehicle); err == nil {
+			vehicle = dev.Instance()
+		} else {
+			lp.log.ERROR.Printf("driver vehicle: %v", err)
+		}
+	}
+
+	return vehicle, true
+}
+
+// applyDriverMode applies the identified driver's charge mode preset
+func (lp *Loadpoint) applyDriverMode() {
+	if lp.driver != nil && lp.driver.Mode != "" {
+		lp.SetMode(lp.driver.Mode)
+	}
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/driver"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/settings"
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdentifyDriver(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, driver.Init())
+
+	t.Cleanup(func() { _ = driver.SetRejectUnknown(false) })
+
+	_, err = driver.Save(driver.Driver{Tag: "abc", User: "alice", Loadpoints: []string{"lp-1"}, Mode: api.ModeNow})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+
+	type identifier struct {
+		api.Charger
+		api.Identifier
+	}
+
+	id := api.NewMockIdentifier(ctrl)
+
+	// drivers are restricted by loadpoint config name, not title
+	newLoadpoint := func(name, title string) *Loadpoint {
+		lp := &Loadpoint{
+			log:      util.NewLogger("foo"),
+			clock:    clock.NewMock(),
+			title:    title,
+			mode:     api.ModePV,
+			charger:  &identifier{api.NewMockCharger(ctrl), id},
+			settings: settings.NewDatabaseSettingsAdapter("foo"),
+		}
+
+		require.NoError(t, config.Loadpoints().Add(config.NewStaticDevice(config.Named{Name: name}, loadpoint.API(lp))))
+		t.Cleanup(func() { _ = config.Loadpoints().Delete(name) })
+
+		return lp
+	}
+
+	garage := newLoadpoint("lp-1", "Garage")
+	carport := newLoadpoint("lp-2", "lp-1")
+
+	// known tag applies driver and mode preset
+	lp := garage
+	id.EXPECT().Identify().Return("ABC", nil)
+	lp.identifyVehicle()
+	require.NotNil(t, lp.driver)
+	assert.Equal(t, "alice", lp.driver.User)
+	assert.False(t, lp.driverRejected)
+	assert.Equal(t, api.ModeNow, lp.GetMode())
+
+	// known tag at other loadpoint is rejected
+	lp = carport
+	id.EXPECT().Identify().Return("abc", nil)
+	lp.identifyVehicle()
+	assert.Nil(t, lp.driver)
+	assert.True(t, lp.driverRejected)
+	assert.Equal(t, api.ModePV, lp.GetMode())
+
+	// unknown tag is accepted unless rejected
+	lp = garage
+	id.EXPECT().Identify().Return("xyz", nil)
+	lp.identifyVehicle()
+	assert.Nil(t, lp.driver)
+	assert.False(t, lp.driverRejected)
+
+	id.EXPECT().Identify().Return("", nil)
+	lp.identifyVehicle()
+
+	require.NoError(t, driver.SetRejectUnknown(true))
+	id.EXPECT().Identify().Return("xyz", nil)
+	lp.identifyVehicle()
+	assert.True(t, lp.driverRejected)
+
+	// removed tag clears rejection
+	id.EXPECT().Identify().Return("", nil)
+	lp.identifyVehicle()
+	assert.False(t, lp.driverRejected)
+}
@@ -1,6 +1,10 @@
 package core
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
 )
@@ -162,3 +166,70 @@ func (lp *Loadpoint) hasPhaseSwitching() bool {
 	_, ok := lp.charger.(api.PhaseSwitcher)
 	return ok
 }
+
+// parseRotation parses the grid phases the charger phases are wired to, e.g. L2L3L1
+func parseRotation(s string) ([]int, error) {
+	if s == "" || strings.EqualFold(s, "L1L2L3") {
+		return nil, nil
+	}
+
+	s = strings.ToUpper(s)
+	if len(s) != 6 {
+		return nil, fmt.Errorf("invalid rotation: %s", s)
+	}
+
+	res := make([]int, 0, 3)
+	for i := 0; i < len(s); i += 2 {
+		phase := int(s[i+1]) - '1'
+		if s[i] != 'L' || phase < 0 || phase > 2 || slices.Contains(res, phase) {
+			return nil, fmt.Errorf("invalid rotation: %s", s)
+		}
+		res = append(res, phase)
+	}
+
+	return res, nil
+}
+
+// formatRotation formats the grid phase index of each charger phase, e.g. L2L3L1. Empty for L1L2L3.
+func formatRotation(rotation []int) string {
+	var res strings.Builder
+	for _, phase := range rotation {
+		fmt.Fprintf(&res, "L%d", phase+1)
+	}
+	return res.String()
+}
+
+// gridPhase returns the grid phase index of the given charger phase index
+func (lp *Loadpoint) gridPhase(i int) int {
+	if lp.rotation == nil {
+		return i
+	}
+	return lp.rotation[i]
+}
+
+// gridPhases returns the grid phases used by the given number of active charger phases
+func (lp *Loadpoint) gridPhases(phases int) [3]bool {
+	var res [3]bool
+	for i := range min(expect(phases), 3) {
+		res[lp.gridPhase(i)] = true
+	}
+	return res
+}
+
+// gridCurrents maps charger phase currents to grid phases
+func (lp *Loadpoint) gridCurrents(currents []float64) [3]float64 {
+	var res [3]float64
+	for i, c := range currents[:min(len(currents), 3)] {
+		res[lp.gridPhase(i)] = c
+	}
+	return res
+}
+
+// offeredCurrents returns the offered current for each active charger phase
+func (lp *Loadpoint) offeredCurrents(phases int) []float64 {
+	res := make([]float64, 3)
+	for i := range min(expect(phases), 3) {
+		res[i] = lp.offeredCurrent
+	}
+	return res
+}
@@ -477,3 +477,35 @@ func TestScalePhasesIfAvailable(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestParseRotation(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		res []int
+		err bool
+	}{
+		{"", nil, false},
+		{"L1L2L3", nil, false},
+		{"L2L3L1", []int{1, 2, 0}, false},
+		{"l3l1l2", []int{2, 0, 1}, false},
+		{"L1L1L2", nil, true},
+		{"L1L2L4", nil, true},
+		{"L1L2", nil, true},
+	} {
+		res, err := parseRotation(tc.in)
+		if tc.err {
+			require.Error(t, err, tc.in)
+			continue
+		}
+		require.NoError(t, err, tc.in)
+		require.Equal(t, tc.res, res, tc.in)
+
+		if res != nil {
+			require.Equal(t, strings.ToUpper(tc.in), formatRotation(res), tc.in)
+		}
+	}
+
+	lp := &Loadpoint{rotation: []int{1, 2, 0}}
+	require.Equal(t, [3]bool{false, true, false}, lp.gridPhases(1))
+	require.Equal(t, [3]float64{0, 16, 0}, lp.gridCurrents([]float64{16, 0, 0}))
+}
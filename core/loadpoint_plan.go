@@ -99,9 +99,77 @@ func (lp *Loadpoint) GetPlan(targetTime time.Time, requiredDuration, preconditio
 		return nil
 	}
 
+	// use site's joint plan if it was created for the same goal
+	if jp := lp.getJointPlan(targetTime, requiredDuration, precondition); jp != nil {
+		return jp.Plan
+	}
+
 	return lp.planner.Plan(requiredDuration, precondition, targetTime)
 }
 
+// GetPlanShortfall returns the charging duration the joint plan for given goal could not schedule before plan time
+func (lp *Loadpoint) GetPlanShortfall(targetTime time.Time, requiredDuration, precondition time.Duration) time.Duration {
+	if jp := lp.getJointPlan(targetTime, requiredDuration, precondition); jp != nil {
+		return jp.Shortfall
+	}
+	return 0
+}
+
+// getJointPlan returns the site's joint plan for this loadpoint if it was created for the given goal
+func (lp *Loadpoint) getJointPlan(targetTime time.Time, requiredDuration, precondition time.Duration) *planner.Result {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if jp := lp.jointPlan; jp != nil && jp.TargetTime.Equal(targetTime) && jp.RequiredDuration == requiredDuration && jp.Precondition == precondition {
+		return jp
+	}
+
+	return nil
+}
+
+// setJointPlan sets the site's joint plan for this loadpoint
+func (lp *Loadpoint) setJointPlan(res *planner.Result) {
+	lp.Lock()
+	defer lp.Unlock()
+	lp.jointPlan = res
+}
+
+// jointPlanRequest returns the loadpoint's charging demand for joint planning
+func (lp *Loadpoint) jointPlanRequest(id int) (planner.Request, bool) {
+	if !lp.connected() {
+		return planner.Request{}, false
+	}
+
+	planTime := lp.EffectivePlanTime()
+	if planTime.IsZero() || lp.clock.Until(planTime) < 0 {
+		return planner.Request{}, false
+	}
+
+	goal, _ := lp.GetPlanGoal()
+	maxPower := lp.EffectiveMaxPower()
+	requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
+	if requiredDuration <= 0 {
+		return planner.Request{}, false
+	}
+
+	lp.RLock()
+	maxCurrent := lp.effectiveMaxCurrent()
+	lp.RUnlock()
+
+	return planner.Request{
+		Id:               id,
+		Title:            lp.GetTitle(),
+		Priority:         lp.EffectivePriority(),
+		TargetTime:       planTime,
+		RequiredDuration: requiredDuration,
+		Precondition:     lp.GetPlanPreCondDuration(),
+		MinPower:         lp.EffectiveMinPower(),
+		MaxPower:         maxPower,
+		MaxCurrent:       maxCurrent,
+		Circuit:          lp.GetCircuit(),
+	}, true
+}
+
 // plannerActive checks if the charging plan has a currently active slot
 func (lp *Loadpoint) plannerActive() (active bool) {
 	defer func() {
@@ -147,13 +215,17 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 		return false
 	}
 
-	plan := lp.GetPlan(planTime, requiredDuration, lp.GetPlanPreCondDuration())
+	precondition := lp.GetPlanPreCondDuration()
+	plan := lp.GetPlan(planTime, requiredDuration, precondition)
 	if plan == nil {
 		return false
 	}
 
+	// joint planning may not be able to schedule the required duration due to shared circuit limits
+	excessDuration := max(requiredDuration-lp.clock.Until(planTime), lp.GetPlanShortfall(planTime, requiredDuration, precondition))
+
 	var overrun string
-	if excessDuration := requiredDuration - lp.clock.Until(planTime); excessDuration > 0 {
+	if excessDuration > 0 {
 		overrun = fmt.Sprintf("overruns by %v, ", excessDuration.Round(time.Second))
 		planOverrun = excessDuration
 	}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/session"
@@ -48,6 +50,10 @@ func (lp *Loadpoint) createSession() {
 		}
 	}
 
+	if lp.driver != nil {
+		lp.session.User = lp.driver.User
+	}
+
 	// energy
 	lp.energyMetrics.Reset()
 	lp.energyMetrics.Publish("session", lp)
@@ -77,12 +83,21 @@ func (lp *Loadpoint) stopSession() {
 	s.Price = lp.energyMetrics.Price()
 	s.PricePerKWh = lp.energyMetrics.PricePerKWh()
 	s.Co2PerKWh = lp.energyMetrics.Co2PerKWh()
+	s.GridEnergy = lp.energyMetrics.SourceKWh(session.SourceGrid)
+	s.SolarEnergy = lp.energyMetrics.SourceKWh(session.SourceSolar)
+	s.BatteryEnergy = lp.energyMetrics.SourceKWh(session.SourceBattery)
+	s.Breakdown = lp.energyMetrics.Breakdown()
 	s.ChargedEnergy = lp.energyMetrics.TotalWh() / 1e3
 	s.ChargeDuration = lo.ToPtr(lp.chargeDuration.Abs())
 
 	lp.db.Persist(s)
 }
 
+// setEnergySources updates the tariff slot, battery share and source prices of the session breakdown
+func (lp *Loadpoint) setEnergySources(slot time.Time, batteryShare float64, gridPrice, feedInPrice *float64) {
+	lp.energyMetrics.SetSources(slot, batteryShare, gridPrice, feedInPrice)
+}
+
 type sessionOption func(*session.Session)
 
 // updateSession updates any parameter of a charging session and persists the session.
@@ -101,13 +116,18 @@ func (lp *Loadpoint) updateSession(opts ...sessionOption) {
 	}
 }
 
-// clearSession clears the charging session without persisting it.
+// clearSession clears the charging session without persisting it. Finalized sessions are recorded in the ledger.
 func (lp *Loadpoint) clearSession() {
 	// test guard
 	if lp.db == nil {
 		return
 	}
 
+	// record finalized session
+	if lp.session != nil && !lp.session.Created.IsZero() {
+		lp.db.Finalize(*lp.session)
+	}
+
 	lp.session = nil
 }
 
@@ -29,7 +29,7 @@ func (lp *Loadpoint) checkSmartLimit(limit *float64, rates api.Rates, checkBelow
 }
 
 func (lp *Loadpoint) smartLimitActive(limit *float64, rates api.Rates, checkBelow bool) bool {
-	rate, err := rates.At(time.Now())
+	rate, err := rates.At(lp.clock.Now())
 	if err != nil || limit == nil {
 		return false
 	}
@@ -46,7 +46,7 @@ func (lp *Loadpoint) smartLimitNextStart(limit *float64, rates api.Rates, checkB
 		return time.Time{}
 	}
 
-	now := time.Now()
+	now := lp.clock.Now()
 	for _, slot := range rates {
 		if slot.Start.After(now) && (checkBelow && slot.Value <= *limit || !checkBelow && slot.Value >= *limit) {
 			return slot.Start
@@ -8,6 +8,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/settings"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/push"
@@ -763,3 +764,22 @@ func TestPVHysteresisAfterPhaseSwitch(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestJointPlanGoal(t *testing.T) {
+	target := time.Date(2025, 1, 6, 7, 0, 0, 0, time.Local)
+
+	lp := &Loadpoint{
+		jointPlan: &planner.Result{
+			Request:   planner.Request{TargetTime: target, RequiredDuration: time.Hour, Precondition: 0},
+			Shortfall: 10 * time.Minute,
+		},
+	}
+
+	assert.Equal(t, 10*time.Minute, lp.GetPlanShortfall(target, time.Hour, 0))
+
+	// previews for other goals do not use the joint plan
+	assert.Nil(t, lp.getJointPlan(target, 2*time.Hour, 0))
+	assert.Nil(t, lp.getJointPlan(target.Add(time.Hour), time.Hour, 0))
+	assert.Nil(t, lp.getJointPlan(target, time.Hour, time.Minute))
+	assert.Zero(t, lp.GetPlanShortfall(target, 2*time.Hour, 0))
+}
@@ -65,14 +65,31 @@ func (lp *Loadpoint) identifyVehicle() {
 	// vehicle found or removed
 	lp.setVehicleIdentifier(id)
 
-	if id != "" {
-		lp.log.DEBUG.Println("charger vehicle id:", id)
-
-		if vehicle := lp.selectVehicleByID(id); vehicle != nil {
-			lp.stopVehicleDetection()
-			lp.setActiveVehicle(vehicle)
+	if id == "" {
+		// forget rejection of removed identifier
+		if lp.driverRejected {
+			lp.setDriver(nil, false)
 		}
+		return
+	}
+
+	lp.log.DEBUG.Println("charger vehicle id:", id)
+
+	vehicle, ok := lp.identifyDriver(id)
+	if !ok {
+		return
+	}
+
+	if vehicle == nil {
+		vehicle = lp.selectVehicleByID(id)
+	}
+
+	if vehicle != nil {
+		lp.stopVehicleDetection()
+		lp.setActiveVehicle(vehicle)
 	}
+
+	lp.applyDriverMode()
 }
 
 // selectVehicleByID selects the vehicle with the given ID
@@ -112,6 +129,8 @@ func (lp *Loadpoint) selectVehicleByID(id string) api.Vehicle {
 func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 	lp.vmu.Lock()
 
+	prev := lp.vehicle
+
 	from := "unknown"
 	if lp.vehicle != nil {
 		lp.coordinator.Release(lp.vehicle)
@@ -126,6 +145,12 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 	lp.vehicle = v
 	lp.vmu.Unlock()
 
+	// stale vehicle data is only detected while connected
+	if prev != v {
+		vehicle.Health(prev).SetIdle(true)
+		vehicle.Health(v).SetIdle(false)
+	}
+
 	if from != to {
 		lp.log.INFO.Printf("vehicle updated: %s -> %s", from, to)
 	}
@@ -139,6 +164,8 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 			estimate = true
 		}
 		lp.socEstimator = soc.NewEstimator(lp.log, lp.charger, v, estimate)
+		lp.socEstimator.SetModel(vehicle.Settings(lp.log, v).GetChargeModel())
+		lp.socEstimator.SetHealth(vehicle.Health(v))
 
 		lp.publish(keys.VehicleName, vehicle.Settings(lp.log, v).Name())
 		lp.publish(keys.VehicleTitle, v.GetTitle())
@@ -305,7 +332,11 @@ func (lp *Loadpoint) identifyVehicleByStatus() {
 // vehicleOdometer updates odometer
 func (lp *Loadpoint) vehicleOdometer() {
 	if vs, ok := lp.GetVehicle().(api.VehicleOdometer); ok {
-		if odo, err := vs.Odometer(); err == nil {
+		start := time.Now()
+		odo, err := vs.Odometer()
+		vehicle.Observe(lp.GetVehicle(), start, err)
+
+		if err == nil {
 			lp.log.DEBUG.Printf("vehicle odometer: %.0fkm", odo)
 			lp.publish(keys.VehicleOdometer, odo)
 
@@ -319,6 +350,18 @@ func (lp *Loadpoint) vehicleOdometer() {
 	}
 }
 
+// learnChargeModel updates the vehicle's charge model from the session's observations
+func (lp *Loadpoint) learnChargeModel() {
+	v := lp.GetVehicle()
+	if v == nil || lp.socEstimator == nil {
+		return
+	}
+
+	if model, updated := lp.socEstimator.Learn(); updated {
+		vehicle.Settings(lp.log, v).SetChargeModel(model)
+	}
+}
+
 // vehicleClimatePollAllowed determines if polling depending on mode and connection status
 func (lp *Loadpoint) vehicleClimatePollAllowed() bool {
 	switch {
@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"math"
+	"time"
+)
+
+// forecast is a slot's forecasted and realized energy
+type forecast struct {
+	Meter     int       `gorm:"column:meter;uniqueIndex:forecast_meter_ts"`
+	Timestamp time.Time `gorm:"column:ts;uniqueIndex:forecast_meter_ts"`
+	Forecast  float64   `gorm:"column:forecast"`
+	Actual    float64   `gorm:"column:actual"`
+	Curtailed bool      `gorm:"column:curtailed"` // actual production was limited by the site
+}
+
+// Accuracy is the forecast error statistics for a time range
+type Accuracy struct {
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Count int       `json:"count"` // number of slots
+	MAE   float64   `json:"mae"`   // mean absolute error in Wh
+	RMSE  float64   `json:"rmse"`  // root mean square error in Wh
+	Bias  float64   `json:"bias"`  // mean error (forecast - actual) in Wh
+	WAPE  float64   `json:"wape"`  // absolute error relative to actual energy
+}
+
+// errorSum accumulates forecast errors
+type errorSum struct {
+	count                         int
+	absErr, sqErr, bias, forecast float64
+	actual                        float64
+}
+
+func (s *errorSum) add(r forecast) {
+	e := r.Forecast - r.Actual
+	s.absErr += math.Abs(e)
+	s.sqErr += e * e
+	s.bias += e
+	s.forecast += r.Forecast
+	s.actual += r.Actual
+	s.count++
+}
+
+func (s *errorSum) accuracy(from, to time.Time) Accuracy {
+	res := Accuracy{From: from, To: to, Count: s.count}
+	if s.count == 0 {
+		return res
+	}
+
+	n := float64(s.count)
+	res.MAE = s.absErr / n
+	res.RMSE = math.Sqrt(s.sqErr / n)
+	res.Bias = s.bias / n
+
+	if s.actual > 0 {
+		res.WAPE = s.absErr / s.actual
+	}
+
+	return res
+}
+
+// GetAccuracy returns the meter's forecast accuracy in given time range
+func GetAccuracy(id int, from, to time.Time) (Accuracy, error) {
+	rows, err := forecasts(id, from, to)
+	if err != nil {
+		return Accuracy{From: from, To: to}, err
+	}
+
+	var sum errorSum
+	for _, r := range rows {
+		sum.add(r)
+	}
+
+	return sum.accuracy(from, to), nil
+}
+
+// GetHourlyAccuracy returns the meter's forecast accuracy per local hour of day in given time range
+func GetHourlyAccuracy(id int, from, to time.Time) ([]Accuracy, error) {
+	rows, err := forecasts(id, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var sums [24]errorSum
+	for _, r := range rows {
+		sums[r.Timestamp.Local().Hour()].add(r)
+	}
+
+	res := make([]Accuracy, 0, len(sums))
+	for _, s := range sums {
+		res = append(res, s.accuracy(from, to))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db"
+)
+
+const (
+	correctionHistory   = 30 * 24 * time.Hour // history used for learning correction factors
+	correctionInterval  = time.Hour           // correction factor update interval
+	correctionMinEnergy = 1e3                 // min forecasted energy in Wh per hour of day before correcting
+	correctionMin       = 0.5
+	correctionMax       = 1.5
+)
+
+// Correction returns the ratio of actual to forecasted energy per local hour of day learned from history before now.
+// Curtailed slots are excluded, hours without sufficient forecasted energy are not corrected.
+func Correction(id int, now time.Time) ([]float64, error) {
+	rows, err := forecasts(id, now.Add(-correctionHistory), now)
+	if err != nil {
+		return nil, err
+	}
+
+	var sums [24]errorSum
+	for _, r := range rows {
+		if !r.Curtailed {
+			sums[r.Timestamp.Local().Hour()].add(r)
+		}
+	}
+
+	res := make([]float64, 0, len(sums))
+	for _, s := range sums {
+		f := 1.0
+		if s.forecast >= correctionMinEnergy {
+			f = min(correctionMax, max(correctionMin, s.actual/s.forecast))
+		}
+		res = append(res, f)
+	}
+
+	return res, nil
+}
+
+// Corrected applies the learned hourly correction factors to a forecast tariff
+type Corrected struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	id      int
+	tariff  api.Tariff
+	factors []float64
+	updated time.Time
+}
+
+var _ api.Tariff = (*Corrected)(nil)
+
+// NewCorrected creates a tariff correcting the given forecast by the meter's learned bias
+func NewCorrected(id int, t api.Tariff) *Corrected {
+	return &Corrected{
+		clock:  clock.New(),
+		id:     id,
+		tariff: t,
+	}
+}
+
+// Unwrap returns the uncorrected tariff
+func (t *Corrected) Unwrap() api.Tariff {
+	return t.tariff
+}
+
+// Factors returns the current correction factors per local hour of day
+func (t *Corrected) Factors() []float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+	if t.factors == nil || now.Sub(t.updated) >= correctionInterval {
+		t.updated = now
+
+		if db.Instance != nil {
+			if f, err := Correction(t.id, now); err == nil {
+				t.factors = f
+			}
+		}
+	}
+
+	return t.factors
+}
+
+// Rates implements the api.Tariff interface
+func (t *Corrected) Rates() (api.Rates, error) {
+	rr, err := t.tariff.Rates()
+	if err != nil {
+		return nil, err
+	}
+
+	factors := t.Factors()
+	if factors == nil {
+		return rr, nil
+	}
+
+	res := slices.Clone(rr)
+	for i, r := range res {
+		res[i].Value = r.Value * factors[r.Start.Local().Hour()]
+	}
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *Corrected) Type() api.TariffType {
+	return t.tariff.Type()
+}
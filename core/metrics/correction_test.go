@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type solarForecast struct {
+	rates api.Rates
+}
+
+func (t *solarForecast) Rates() (api.Rates, error) {
+	return t.rates, nil
+}
+
+func (t *solarForecast) Type() api.TariffType {
+	return api.TariffTypeSolar
+}
+
+func TestCorrection(t *testing.T) {
+	setup(t)
+
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	// one week of forecasts 25% too high at noon, too little energy at 8am
+	for day := range 7 {
+		noon := start.AddDate(0, 0, day).Add(12 * time.Hour)
+		for i := range 4 {
+			require.NoError(t, PersistForecast(Solar, noon.Add(time.Duration(i)*SlotDuration), 500, 400, false))
+		}
+		require.NoError(t, PersistForecast(Solar, noon.Add(-4*time.Hour), 10, 20, false))
+
+		// curtailed production is ignored
+		require.NoError(t, PersistForecast(Solar, noon.Add(2*time.Hour), 500, 100, true))
+	}
+
+	now := start.AddDate(0, 0, 7)
+
+	f, err := Correction(Solar, now)
+	require.NoError(t, err)
+	require.Len(t, f, 24)
+	assert.InDelta(t, 0.8, f[12], 1e-9)
+	assert.Equal(t, 1.0, f[8])
+	assert.Equal(t, 1.0, f[14])
+	assert.Equal(t, 1.0, f[0])
+
+	hourly, err := GetHourlyAccuracy(Solar, start, now)
+	require.NoError(t, err)
+	require.Len(t, hourly, 24)
+	assert.Equal(t, 28, hourly[12].Count)
+	assert.InDelta(t, 100, hourly[12].Bias, 1e-9)
+	assert.InDelta(t, -10, hourly[8].Bias, 1e-9)
+
+	fcst := &solarForecast{api.Rates{
+		{Start: now.Add(8 * time.Hour), End: now.Add(9 * time.Hour), Value: 1000},
+		{Start: now.Add(12 * time.Hour), End: now.Add(13 * time.Hour), Value: 1000},
+	}}
+
+	c := NewCorrected(Solar, fcst)
+	clock := clock.NewMock()
+	clock.Set(now)
+	c.clock = clock
+
+	rr, err := c.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, rr[0].Value)
+	assert.InDelta(t, 800, rr[1].Value, 1e-9)
+
+	// uncorrected forecast is unmodified
+	assert.Equal(t, 1000.0, fcst.rates[1].Value)
+	assert.Equal(t, fcst, c.Unwrap())
+}
+
+func TestCorrectionLimits(t *testing.T) {
+	setup(t)
+
+	start := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+	require.NoError(t, PersistForecast(Solar, start, 1000, 100, false))
+
+	f, err := Correction(Solar, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, correctionMin, f[12])
+}
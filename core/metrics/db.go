@@ -2,11 +2,33 @@ package metrics
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/history"
+	"gorm.io/gorm/clause"
 )
 
+// Meter ids
+const (
+	Household = 1 // household consumption
+	Solar     = 2 // pv production
+)
+
+// SlotDuration is the metrics resolution
+const SlotDuration = 15 * time.Minute
+
+// forecastRetention is the time forecasts are kept for accuracy tracking
+const forecastRetention = 365 * 24 * time.Hour
+
+// groups are the energy history groups of the meters
+var groups = map[int]string{
+	Household: "home",
+	Solar:     "pv",
+}
+
+// meter is the legacy household consumption table replaced by the energy history
 type meter struct {
 	Meter     int       `json:"meter" gorm:"column:meter;uniqueIndex:meter_ts"`
 	Timestamp time.Time `json:"ts" gorm:"column:ts;uniqueIndex:meter_ts"`
@@ -15,59 +37,106 @@ type meter struct {
 
 var ErrIncomplete = errors.New("meter profile incomplete")
 
+// Init creates the forecast table and migrates household consumption to the energy history.
+// Requires the energy history to be initialized.
 func Init() error {
-	return db.Instance.AutoMigrate(new(meter))
-}
+	if err := db.Instance.AutoMigrate(new(forecast)); err != nil {
+		return err
+	}
 
-// Persist stores 15min consumption in Wh
-func Persist(ts time.Time, value float64) error {
-	return db.Instance.Create(meter{
-		Meter:     1,
-		Timestamp: ts.Truncate(15 * time.Minute),
-		Value:     value,
-	}).Error
+	return migrateMeters()
 }
 
-// Profile returns a 15min average meter profile in Wh
-func Profile() (*[96]float64, error) {
-	db, err := db.Instance.DB()
-	if err != nil {
-		return nil, err
+// migrateMeters moves the legacy household consumption to the energy history. Existing history takes precedence.
+func migrateMeters() error {
+	if !db.Instance.Migrator().HasTable(new(meter)) {
+		return nil
 	}
 
-	rows, err := db.Query(`SELECT min(ts) AS ts, avg(val) AS val
-		FROM meters
-		WHERE meter = ?
-		GROUP BY strftime("%H:%M", ts)
-		ORDER BY ts`, 1)
-	if err != nil {
-		return nil, err
+	var first history.Entry
+	if err := db.Instance.Where("grp = ? AND resolution = ?", groups[Household], history.Slot).Order("ts").Limit(1).Find(&first).Error; err != nil {
+		return err
 	}
-	defer rows.Close()
 
-	res := make([]float64, 0, 96)
+	// one-time migration, timestamps are filtered after loading as they may have been stored in different time zones
+	var rows []meter
+	if err := db.Instance.Where("meter = ?", Household).Find(&rows).Error; err != nil {
+		return err
+	}
 
-	for rows.Next() {
-		var (
-			ts  time.Time
-			val float64
-		)
-		if err := rows.Scan(&ts, &val); err != nil {
-			return nil, err
+	for _, r := range rows {
+		if !first.Timestamp.IsZero() && !r.Timestamp.Before(first.Timestamp) {
+			continue
 		}
 
-		hour := ts.Hour()
-		minute := ts.Minute() / 15
-		if len(res) != hour*4+minute {
-			return nil, ErrIncomplete
+		if err := history.Persist(groups[Household], groups[Household], r.Timestamp, r.Value, 0); err != nil {
+			return err
 		}
+	}
+
+	return db.Instance.Migrator().DropTable(new(meter))
+}
+
+// Persist records the slot's actual energy in Wh against the forecast from the meter's profile for accuracy tracking.
+// The energy itself is recorded by the energy history.
+func Persist(id int, ts time.Time, value float64) error {
+	ts = ts.Truncate(SlotDuration).UTC()
 
-		res = append(res, val)
+	// forecast from data before the slot
+	p, err := NewProfile(id, ts)
+	if err != nil {
+		return err
+	}
+
+	return PersistForecast(id, ts, p.Energy(ts), value, false)
+}
+
+// PersistForecast stores a slot's forecasted and actual energy in Wh for accuracy tracking.
+// Curtailed slots are not used for correcting the forecast. Existing slots are replaced.
+// Forecasts older than the retention period are pruned.
+func PersistForecast(id int, ts time.Time, fcst, actual float64, curtailed bool) error {
+	ts = ts.Truncate(SlotDuration).UTC()
+
+	if err := db.Instance.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "meter"}, {Name: "ts"}},
+		UpdateAll: true,
+	}).Create(&forecast{
+		Meter:     id,
+		Timestamp: ts,
+		Forecast:  fcst,
+		Actual:    actual,
+		Curtailed: curtailed,
+	}).Error; err != nil {
+		return fmt.Errorf("forecast: %w", err)
+	}
+
+	return db.Instance.Where("meter = ? AND ts < ?", id, ts.Add(-forecastRetention)).Delete(new(forecast)).Error
+}
+
+// forecasts returns the meter's forecasts in given time range ordered by time.
+// Timestamps are stored in UTC for comparing them in the query.
+func forecasts(id int, from, to time.Time) ([]forecast, error) {
+	var res []forecast
+	err := db.Instance.Where("meter = ? AND ts >= ? AND ts < ?", id, from.UTC(), to.UTC()).Order("ts").Find(&res).Error
+	return res, err
+}
+
+// energy returns the meter's energy per slot in given time range from the energy history
+func energy(id int, from, to time.Time) ([]meter, error) {
+	group, ok := groups[id]
+	if !ok {
+		return nil, fmt.Errorf("invalid meter: %d", id)
+	}
+
+	rows, err := history.Sum(group, history.Slot, from, to)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(res) != 96 {
-		return nil, ErrIncomplete
+	res := make([]meter, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, meter{Meter: id, Timestamp: r.Timestamp, Value: r.Import})
 	}
 
-	return (*[96]float64)(res), nil
+	return res, nil
 }
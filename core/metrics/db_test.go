@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateMeters(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, history.Init())
+
+	ts := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+
+	// legacy table overlapping with recorded history
+	require.NoError(t, db.Instance.AutoMigrate(new(meter)))
+	for i := range 4 {
+		require.NoError(t, db.Instance.Create(meter{Meter: Household, Timestamp: ts.Add(time.Duration(i) * SlotDuration), Value: 100}).Error)
+	}
+	require.NoError(t, history.Persist("home", "home", ts.Add(3*SlotDuration), 200, 0))
+
+	require.NoError(t, Init())
+	assert.False(t, db.Instance.Migrator().HasTable(new(meter)))
+
+	rows, err := energy(Household, ts, ts.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, 100.0, rows[0].Value)
+	assert.Equal(t, 200.0, rows[3].Value)
+}
+
+func TestForecastPruned(t *testing.T) {
+	setup(t)
+
+	ts := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, PersistForecast(Solar, ts, 100, 100, false))
+	require.NoError(t, PersistForecast(Solar, ts.Add(forecastRetention), 100, 100, false))
+
+	rows, err := forecasts(Solar, time.Time{}, ts.Add(2*forecastRetention))
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	require.NoError(t, PersistForecast(Solar, ts.Add(forecastRetention+SlotDuration), 100, 100, false))
+
+	rows, err = forecasts(Solar, time.Time{}, ts.Add(2*forecastRetention))
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+}
+
+func TestForecastReplaced(t *testing.T) {
+	setup(t)
+
+	ts := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+
+	// same slot recorded again, e.g. after restart
+	require.NoError(t, PersistForecast(Solar, ts, 100, 100, false))
+	require.NoError(t, PersistForecast(Solar, ts, 200, 50, true))
+
+	rows, err := forecasts(Solar, ts, ts.Add(SlotDuration))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 200.0, rows[0].Forecast)
+	assert.Equal(t, 50.0, rows[0].Actual)
+	assert.True(t, rows[0].Curtailed)
+}
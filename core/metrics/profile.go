@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+const (
+	profileHistory  = 8 * 7 * 24 * time.Hour // history used for building profiles
+	profileHalfLife = 14 * 24 * time.Hour    // recent days are weighted higher
+	slotsPerDay     = 96
+)
+
+// dayType distinguishes weekdays and weekends
+type dayType int
+
+const (
+	weekday dayType = iota
+	weekend
+)
+
+func dayTypeOf(ts time.Time) dayType {
+	if wd := ts.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return weekend
+	}
+	return weekday
+}
+
+func slotOf(ts time.Time) int {
+	return ts.Hour()*4 + ts.Minute()/15
+}
+
+// Profile is a recency-weighted 15min consumption profile in Wh, separated by weekdays and weekends
+type Profile struct {
+	slots [2][slotsPerDay]float64
+}
+
+// NewProfile builds the meter's profile from history before the given time.
+// Missing slots are filled from the other day type or the day type's average.
+func NewProfile(id int, now time.Time) (*Profile, error) {
+	rows, err := energy(id, now.Add(-profileHistory), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrIncomplete
+	}
+
+	var sum, weight [2][slotsPerDay]float64
+
+	for _, r := range rows {
+		ts := r.Timestamp.Local()
+		age := now.Sub(r.Timestamp)
+		w := math.Pow(0.5, float64(age)/float64(profileHalfLife))
+
+		dt, slot := dayTypeOf(ts), slotOf(ts)
+		sum[dt][slot] += w * r.Value
+		weight[dt][slot] += w
+	}
+
+	res := new(Profile)
+
+	for dt := range res.slots {
+		var total, count float64
+
+		for slot := range slotsPerDay {
+			if weight[dt][slot] > 0 {
+				res.slots[dt][slot] = sum[dt][slot] / weight[dt][slot]
+				total += res.slots[dt][slot]
+				count++
+			}
+		}
+
+		// fill missing slots
+		other := 1 - dt
+		for slot := range slotsPerDay {
+			switch {
+			case weight[dt][slot] > 0:
+			case weight[other][slot] > 0:
+				res.slots[dt][slot] = sum[other][slot] / weight[other][slot]
+			case count > 0:
+				res.slots[dt][slot] = total / count
+			default:
+				res.slots[dt][slot] = math.NaN()
+			}
+		}
+	}
+
+	// day type without any data at all uses the other day type
+	for dt := range res.slots {
+		for slot := range slotsPerDay {
+			if math.IsNaN(res.slots[dt][slot]) {
+				res.slots[dt][slot] = res.slots[1-dt][slot]
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// Energy returns the expected consumption in Wh for the slot containing ts
+func (p *Profile) Energy(ts time.Time) float64 {
+	ts = ts.Local()
+	return p.slots[dayTypeOf(ts)][slotOf(ts)]
+}
+
+// Forecast returns the expected average power in W for each 15min slot of the horizon
+func (p *Profile) Forecast(from time.Time, horizon time.Duration) api.Rates {
+	start := from.Truncate(SlotDuration)
+	end := from.Add(horizon)
+
+	res := make(api.Rates, 0, int(horizon/SlotDuration)+1)
+	for ts := start; ts.Before(end); ts = ts.Add(SlotDuration) {
+		res = append(res, api.Rate{
+			Start: ts,
+			End:   ts.Add(SlotDuration),
+			Value: p.Energy(ts) / SlotDuration.Hours(),
+		})
+	}
+
+	return res
+}
+
+// Forecast returns the meter's expected average power in W for each 15min slot of the horizon
+func Forecast(id int, from time.Time, horizon time.Duration) (api.Rates, error) {
+	p, err := NewProfile(id, from)
+	if err != nil {
+		return nil, err
+	}
+	return p.Forecast(from, horizon), nil
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, history.Init())
+	require.NoError(t, Init())
+}
+
+// persist records the household consumption like the site does
+// persist records the slot's energy and its forecast if enough data is available
+func persist(t *testing.T, ts time.Time, val float64) {
+	require.NoError(t, history.Persist(groups[Household], groups[Household], ts, val, 0))
+	if err := Persist(Household, ts, val); !errors.Is(err, ErrIncomplete) {
+		require.NoError(t, err)
+	}
+}
+
+func TestProfileIncomplete(t *testing.T) {
+	setup(t)
+
+	_, err := NewProfile(Household, time.Now())
+	assert.ErrorIs(t, err, ErrIncomplete)
+}
+
+func TestProfileWeekdayWeekend(t *testing.T) {
+	setup(t)
+
+	// monday
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	// two weeks of history, weekdays 100Wh, weekends 300Wh per slot
+	for ts := start; ts.Before(start.AddDate(0, 0, 14)); ts = ts.Add(SlotDuration) {
+		val := 100.0
+		if dayTypeOf(ts) == weekend {
+			val = 300
+		}
+		persist(t, ts, val)
+	}
+
+	now := start.AddDate(0, 0, 14)
+	p, err := NewProfile(Household, now)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 100, p.Energy(now), 1e-6)
+	assert.InDelta(t, 300, p.Energy(now.AddDate(0, 0, 5)), 1e-6)
+
+	rates := p.Forecast(now, 48*time.Hour)
+	require.Len(t, rates, 192)
+	assert.InDelta(t, 400, rates[0].Value, 1e-6) // W
+}
+
+func TestProfileRecencyWeighted(t *testing.T) {
+	setup(t)
+
+	start := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+
+	// same weekday slot, four weeks ago 400Wh, last week 100Wh
+	persist(t, start, 400)
+	persist(t, start.AddDate(0, 0, 21), 100)
+
+	p, err := NewProfile(Household, start.AddDate(0, 0, 28))
+	require.NoError(t, err)
+
+	// recent value dominates
+	v := p.Energy(start.AddDate(0, 0, 28))
+	assert.Greater(t, v, 100.0)
+	assert.Less(t, v, 250.0)
+}
+
+func TestProfileFillsMissingSlots(t *testing.T) {
+	setup(t)
+
+	start := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+	persist(t, start, 200)
+
+	p, err := NewProfile(Household, start.Add(time.Hour))
+	require.NoError(t, err)
+
+	// missing slots and day types use available data
+	assert.Equal(t, 200.0, p.Energy(start.Add(3*time.Hour)))
+	assert.Equal(t, 200.0, p.Energy(start.AddDate(0, 0, 5)))
+}
+
+func TestAccuracy(t *testing.T) {
+	setup(t)
+
+	start := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+
+	// first value has no forecast
+	require.NoError(t, history.Persist(groups[Household], groups[Household], start, 100, 0))
+	assert.ErrorIs(t, Persist(Household, start, 100), ErrIncomplete)
+	persist(t, start.Add(SlotDuration), 150)
+	persist(t, start.Add(2*SlotDuration), 50)
+
+	res, err := GetAccuracy(Household, start, start.Add(time.Hour))
+	require.NoError(t, err)
+
+	// forecasts are 100Wh and 125Wh (average of available slots)
+	assert.Equal(t, 2, res.Count)
+	assert.InDelta(t, 62.5, res.MAE, 0.1)
+	assert.InDelta(t, 12.5, res.Bias, 0.1)
+	assert.InDelta(t, 0.625, res.WAPE, 0.001)
+}
@@ -0,0 +1,298 @@
+package planner
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Request is a single loadpoint's charging demand for joint planning
+type Request struct {
+	Id               int           // caller-defined identifier, e.g. loadpoint index
+	Title            string        // title for logging
+	Priority         int           // higher priorities are planned first
+	TargetTime       time.Time     // plan target time
+	RequiredDuration time.Duration // required charging duration at max power
+	Precondition     time.Duration // precondition duration before target time
+	MinPower         float64       // min charging power
+	MaxPower         float64       // max charging power
+	MaxCurrent       float64       // max phase current
+	Circuit          api.Circuit   // circuit the loadpoint is attached to
+}
+
+// Result is the joint plan of a single request
+type Result struct {
+	Request
+	Plan      api.Rates     // plan sorted by time
+	Shortfall time.Duration // charging duration that could not be planned before target time
+}
+
+// Feasible returns if the plan reaches its goal before target time
+func (r Result) Feasible() bool {
+	return r.Shortfall <= 0
+}
+
+// Joint plans multiple requests sharing the same tariff and circuit limits
+type Joint struct {
+	log    *util.Logger
+	clock  clock.Clock // mockable time
+	tariff api.Tariff
+}
+
+// WithJointClock sets the joint planner's clock
+func WithJointClock(clock clock.Clock) func(t *Joint) {
+	return func(t *Joint) {
+		t.clock = clock
+	}
+}
+
+// NewJoint creates a joint planner
+func NewJoint(log *util.Logger, tariff api.Tariff, opt ...func(t *Joint)) *Joint {
+	p := &Joint{
+		log:    log,
+		clock:  clock.New(),
+		tariff: tariff,
+	}
+
+	for _, o := range opt {
+		o(p)
+	}
+
+	return p
+}
+
+// circuitUsage is the planned power and current of a circuit per slot
+type circuitUsage struct {
+	maxPower, maxCurrent float64
+	power, current       []float64
+}
+
+// slots returns the planning grid from now until the latest target time.
+// Rates are split at target and precondition times such that each slot belongs
+// entirely inside or outside of a request's planning window.
+func (t *Joint) slots(requests []Request) api.Rates {
+	now := t.clock.Now()
+
+	var horizon time.Time
+	for _, r := range requests {
+		if r.TargetTime.After(horizon) {
+			horizon = r.TargetTime
+		}
+	}
+
+	var rates api.Rates
+	if t.tariff != nil {
+		if rr, err := t.tariff.Rates(); err == nil {
+			rates = slices.Clone(rr)
+		}
+	}
+	rates.Sort()
+
+	// like the single planner, time beyond available rates is treated as free
+	res := make(api.Rates, 0, len(rates)+1)
+	last := now
+	for _, r := range rates {
+		if !r.End.After(now) || !r.Start.Before(horizon) {
+			continue
+		}
+		if r.Start.After(last) {
+			res = append(res, api.Rate{Start: last, End: r.Start})
+		}
+		if r.Start.Before(now) {
+			r.Start = now
+		}
+		if r.End.After(horizon) {
+			r.End = horizon
+		}
+		res = append(res, r)
+		last = r.End
+	}
+	if last.Before(horizon) {
+		res = append(res, api.Rate{Start: last, End: horizon})
+	}
+
+	// split slots at request boundaries
+	for _, r := range requests {
+		for _, ts := range []time.Time{r.TargetTime, r.TargetTime.Add(-r.Precondition)} {
+			for i, slot := range res {
+				if slot.Start.Before(ts) && slot.End.After(ts) {
+					tail := slot
+					tail.Start = ts
+					res[i].End = ts
+					res = slices.Insert(res, i+1, tail)
+					break
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// capacity returns the share of the request's max power that can be planned for the given slot
+func (t *Joint) capacity(r Request, slot int, usage map[api.Circuit]*circuitUsage) float64 {
+	if r.MaxPower <= 0 {
+		return 0
+	}
+
+	res := 1.0
+	for c := r.Circuit; c != nil; c = c.GetParent() {
+		u := usage[c]
+
+		if u.maxPower > 0 {
+			res = min(res, (u.maxPower-u.power[slot])/r.MaxPower)
+		}
+
+		if u.maxCurrent > 0 && r.MaxCurrent > 0 {
+			res = min(res, (u.maxCurrent-u.current[slot])/r.MaxCurrent)
+		}
+	}
+
+	// charging below min power is not possible
+	if res*r.MaxPower < r.MinPower || res <= 0 {
+		return 0
+	}
+
+	return res
+}
+
+// Plan creates a feasible, jointly lowest-cost plan for all requests.
+// Requests are planned in order of descending priority and ascending target time,
+// each using the cheapest slots that are not yet exhausted by circuit limits.
+// The results are returned in order of the given requests.
+func (t *Joint) Plan(requests []Request) []Result {
+	res := make([]Result, len(requests))
+	for i, r := range requests {
+		res[i].Request = r
+	}
+
+	if len(requests) == 0 {
+		return res
+	}
+
+	now := t.clock.Now()
+	slots := t.slots(requests)
+
+	// cache circuit limits for the planning run
+	usage := make(map[api.Circuit]*circuitUsage)
+	for _, r := range requests {
+		for c := r.Circuit; c != nil; c = c.GetParent() {
+			if _, ok := usage[c]; !ok {
+				usage[c] = &circuitUsage{
+					maxPower:   c.GetMaxPower(),
+					maxCurrent: c.GetMaxCurrent(),
+					power:      make([]float64, len(slots)),
+					current:    make([]float64, len(slots)),
+				}
+			}
+		}
+	}
+
+	order := make([]int, len(requests))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		ri, rj := requests[i], requests[j]
+		if c := cmp.Compare(rj.Priority, ri.Priority); c != 0 {
+			return c
+		}
+		return ri.TargetTime.Compare(rj.TargetTime)
+	})
+
+	for _, idx := range order {
+		r := requests[idx]
+		res[idx].Plan, res[idx].Shortfall = t.plan(r, now, slots, usage)
+
+		if !res[idx].Feasible() {
+			t.log.WARN.Printf("joint plan: %s cannot reach goal by %v, short by %v", r.Title,
+				r.TargetTime.Round(time.Second).Local(), res[idx].Shortfall.Round(time.Second))
+		}
+	}
+
+	return res
+}
+
+// plan allocates the cheapest available slots to a single request and records the circuit usage
+func (t *Joint) plan(r Request, now time.Time, slots api.Rates, usage map[api.Circuit]*circuitUsage) (api.Rates, time.Duration) {
+	requiredDuration := r.RequiredDuration
+	if requiredDuration <= 0 || !r.TargetTime.After(now) {
+		return nil, max(0, requiredDuration)
+	}
+
+	// candidate slots sorted by cost, precondition slots are preferred like in the single planner
+	preCondStart := r.TargetTime.Add(-r.Precondition)
+
+	type candidate struct {
+		api.Rate
+		idx  int
+		cost api.Rate
+	}
+
+	var candidates []candidate
+	for i, slot := range slots {
+		if !slot.End.After(now) || !slot.Start.Before(r.TargetTime) {
+			continue
+		}
+
+		cost := slot
+		if r.Precondition > 0 && !slot.Start.Before(preCondStart) {
+			cost.Value = 0
+		}
+
+		candidates = append(candidates, candidate{Rate: slot, idx: i, cost: cost})
+	}
+
+	slices.SortStableFunc(candidates, func(i, j candidate) int {
+		return sortByCost(i.cost, j.cost)
+	})
+
+	var plan api.Rates
+
+	for _, c := range candidates {
+		share := t.capacity(r, c.idx, usage)
+		if share <= 0 {
+			continue
+		}
+
+		slot := c.Rate
+		effective := time.Duration(float64(slot.End.Sub(slot.Start)) * share)
+
+		// slot covers more than we need, so shorten it
+		if effective > requiredDuration {
+			active := time.Duration(float64(requiredDuration) / share)
+
+			// the first (if not single) slot should start as late as possible
+			if IsFirst(slot, plan) && len(plan) > 0 {
+				slot.Start = slot.End.Add(-active)
+			} else {
+				slot.End = slot.Start.Add(active)
+			}
+
+			effective = requiredDuration
+		}
+
+		requiredDuration -= effective
+		plan = append(plan, slot)
+
+		for circuit := r.Circuit; circuit != nil; circuit = circuit.GetParent() {
+			u := usage[circuit]
+			u.power[c.idx] += share * r.MaxPower
+			u.current[c.idx] += share * r.MaxCurrent
+		}
+
+		// we found all necessary slots
+		if requiredDuration <= 0 {
+			requiredDuration = 0
+			break
+		}
+	}
+
+	plan.Sort()
+
+	return plan, requiredDuration
+}
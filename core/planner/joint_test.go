@@ -0,0 +1,132 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestJointPlanWithoutCircuit(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{20, 60, 10, 80, 40, 90}, clock.Now(), time.Hour), nil)
+
+	p := NewJoint(util.NewLogger("foo"), trf, func(t *Joint) {
+		t.clock = clock
+	})
+
+	res := p.Plan([]Request{
+		{Id: 1, TargetTime: clock.Now().Add(6 * time.Hour), RequiredDuration: time.Hour, MaxPower: 11e3},
+		{Id: 2, TargetTime: clock.Now().Add(6 * time.Hour), RequiredDuration: time.Hour, MaxPower: 11e3},
+	})
+	require.Len(t, res, 2)
+
+	// without circuit both loadpoints share the cheapest slot
+	for _, r := range res {
+		assert.True(t, r.Feasible())
+		assert.Equal(t, time.Hour, Duration(r.Plan))
+		assert.Equal(t, clock.Now().Add(2*time.Hour), Start(r.Plan))
+	}
+}
+
+func TestJointPlanCircuitLimit(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{20, 60, 10, 80, 40, 90}, clock.Now(), time.Hour), nil)
+
+	circuit := api.NewMockCircuit(ctrl)
+	circuit.EXPECT().GetParent().AnyTimes().Return(nil)
+	circuit.EXPECT().GetMaxPower().AnyTimes().Return(11e3)
+	circuit.EXPECT().GetMaxCurrent().AnyTimes().Return(0.0)
+
+	p := NewJoint(util.NewLogger("foo"), trf, func(t *Joint) {
+		t.clock = clock
+	})
+
+	res := p.Plan([]Request{
+		{Id: 1, Priority: 0, TargetTime: clock.Now().Add(6 * time.Hour), RequiredDuration: time.Hour, MaxPower: 11e3, Circuit: circuit},
+		{Id: 2, Priority: 1, TargetTime: clock.Now().Add(6 * time.Hour), RequiredDuration: time.Hour, MaxPower: 11e3, Circuit: circuit},
+	})
+	require.Len(t, res, 2)
+
+	// higher priority gets the cheapest slot
+	assert.True(t, res[1].Feasible())
+	assert.Equal(t, clock.Now().Add(2*time.Hour), Start(res[1].Plan))
+	assert.Equal(t, 10.0, AverageCost(res[1].Plan))
+
+	// lower priority gets the next cheapest slot
+	assert.True(t, res[0].Feasible())
+	assert.Equal(t, clock.Now(), Start(res[0].Plan))
+	assert.Equal(t, 20.0, AverageCost(res[0].Plan))
+}
+
+func TestJointPlanPartialCapacity(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{20, 60, 10, 80}, clock.Now(), time.Hour), nil)
+
+	circuit := api.NewMockCircuit(ctrl)
+	circuit.EXPECT().GetParent().AnyTimes().Return(nil)
+	circuit.EXPECT().GetMaxPower().AnyTimes().Return(0.0)
+	circuit.EXPECT().GetMaxCurrent().AnyTimes().Return(24.0)
+
+	p := NewJoint(util.NewLogger("foo"), trf, func(t *Joint) {
+		t.clock = clock
+	})
+
+	res := p.Plan([]Request{
+		{Id: 1, TargetTime: clock.Now().Add(4 * time.Hour), RequiredDuration: time.Hour, MinPower: 4e3, MaxPower: 11e3, MaxCurrent: 16, Circuit: circuit},
+		{Id: 2, TargetTime: clock.Now().Add(4 * time.Hour), RequiredDuration: time.Hour, MinPower: 4e3, MaxPower: 11e3, MaxCurrent: 16, Circuit: circuit},
+	})
+	require.Len(t, res, 2)
+
+	assert.True(t, res[0].Feasible())
+	assert.Equal(t, time.Hour, Duration(res[0].Plan))
+
+	// second loadpoint gets the remaining 8A in the cheapest slot and needs additional 30 minutes
+	assert.True(t, res[1].Feasible())
+	assert.Equal(t, 90*time.Minute, Duration(res[1].Plan))
+	assert.Equal(t, clock.Now().Add(30*time.Minute), Start(res[1].Plan))
+}
+
+func TestJointPlanInfeasible(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{20, 60, 10, 80}, clock.Now(), time.Hour), nil)
+
+	circuit := api.NewMockCircuit(ctrl)
+	circuit.EXPECT().GetParent().AnyTimes().Return(nil)
+	circuit.EXPECT().GetMaxPower().AnyTimes().Return(11e3)
+	circuit.EXPECT().GetMaxCurrent().AnyTimes().Return(0.0)
+
+	p := NewJoint(util.NewLogger("foo"), trf, func(t *Joint) {
+		t.clock = clock
+	})
+
+	res := p.Plan([]Request{
+		{Id: 1, Priority: 1, TargetTime: clock.Now().Add(2 * time.Hour), RequiredDuration: 2 * time.Hour, MaxPower: 11e3, Circuit: circuit},
+		{Id: 2, TargetTime: clock.Now().Add(2 * time.Hour), RequiredDuration: time.Hour, MaxPower: 11e3, Circuit: circuit},
+	})
+	require.Len(t, res, 2)
+
+	assert.True(t, res[0].Feasible())
+	assert.Equal(t, 2*time.Hour, Duration(res[0].Plan))
+
+	assert.False(t, res[1].Feasible())
+	assert.Empty(t, res[1].Plan)
+	assert.Equal(t, time.Hour, res[1].Shortfall)
+}
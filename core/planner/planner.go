@@ -16,6 +16,13 @@ type Planner struct {
 	tariff api.Tariff
 }
 
+// WithClock sets the planner's clock
+func WithClock(clock clock.Clock) func(t *Planner) {
+	return func(t *Planner) {
+		t.clock = clock
+	}
+}
+
 // New creates a price planner
 func New(log *util.Logger, tariff api.Tariff, opt ...func(t *Planner)) *Planner {
 	p := &Planner{
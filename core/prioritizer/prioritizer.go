@@ -1,36 +1,99 @@
 package prioritizer
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/util"
 )
 
+// rotationInterval is the time a loadpoint keeps the surplus when it only suffices for a single loadpoint
+const rotationInterval = 30 * time.Minute
+
 type Prioritizer struct {
-	mu     sync.Mutex
-	log    *util.Logger
-	demand map[loadpoint.API]float64
+	mu       sync.Mutex
+	log      *util.Logger
+	clock    clock.Clock
+	strategy Strategy
+	demand   map[loadpoint.API]float64
+	waiting  map[loadpoint.API]bool // loadpoints asking for surplus
+	order    []loadpoint.API        // stable order for rotation
+	active   loadpoint.API          // loadpoint receiving the surplus in rotation
+	rotated  time.Time
 }
 
 func New(log *util.Logger) *Prioritizer {
 	return &Prioritizer{
-		log:    log,
-		demand: make(map[loadpoint.API]float64),
+		log:     log,
+		clock:   clock.New(),
+		demand:  make(map[loadpoint.API]float64),
+		waiting: make(map[loadpoint.API]bool),
 	}
 }
 
+// GetStrategy returns the prioritization strategy
+func (p *Prioritizer) GetStrategy() Strategy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.strategy
+}
+
+// SetStrategy sets the prioritization strategy
+func (p *Prioritizer) SetStrategy(strategy Strategy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.strategy = strategy
+	p.active = nil
+}
+
 func (p *Prioritizer) UpdateChargePowerFlexibility(lp loadpoint.API, rates api.Rates) {
-	if power := lp.GetChargePowerFlexibility(rates); power >= 0 {
-		p.mu.Lock()
+	power := lp.GetChargePowerFlexibility(rates)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if power >= 0 {
 		p.demand[lp] = power
-		p.mu.Unlock()
 	}
+
+	if p.strategy != StrategyPriority {
+		p.waiting[lp] = waitingForSurplus(lp)
+
+		if !slices.Contains(p.order, lp) {
+			p.order = append(p.order, lp)
+		}
+	}
+}
+
+// waitingForSurplus determines if the loadpoint is connected and asks for surplus
+func waitingForSurplus(lp loadpoint.API) bool {
+	if mode := lp.GetMode(); mode != api.ModePV && mode != api.ModeMinPV || lp.GetStatus() == api.StatusA {
+		return false
+	}
+
+	soc, limit := lp.GetSoc(), lp.EffectiveLimitSoc()
+	return soc == 0 || limit == 0 || soc < float64(limit)
 }
 
 func (p *Prioritizer) GetChargePowerFlexibility(lp loadpoint.API) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.strategy == StrategyPriority {
+		return p.byPriority(lp)
+	}
+
+	return p.byShare(lp)
+}
+
+// byPriority gives the flexible power of all lower priority loadpoints to the loadpoint
+func (p *Prioritizer) byPriority(lp loadpoint.API) float64 {
 	prio := lp.EffectivePriority()
 
 	var (
@@ -51,3 +114,87 @@ func (p *Prioritizer) GetChargePowerFlexibility(lp loadpoint.API) float64 {
 
 	return reduceBy
 }
+
+// byShare distributes the flexible power of all loadpoints according to the strategy's weights.
+// If the flexible power only suffices for a single loadpoint, it is rotated between the waiting loadpoints.
+func (p *Prioritizer) byShare(lp loadpoint.API) float64 {
+	var (
+		pool, others, minPower float64
+		candidates             []loadpoint.API
+	)
+
+	for _, l := range p.order {
+		power := p.demand[l]
+		pool += power
+		if l != lp {
+			others += power
+		}
+
+		if p.waiting[l] {
+			candidates = append(candidates, l)
+			minPower = max(minPower, l.EffectiveMinPower())
+		}
+	}
+
+	if !p.waiting[lp] || others == 0 {
+		return 0
+	}
+
+	// surplus only suffices for a single loadpoint
+	if len(candidates) > 1 && pool < 2*minPower {
+		p.rotate(candidates)
+
+		if lp != p.active {
+			return 0
+		}
+
+		if p.log != nil {
+			p.log.DEBUG.Printf("lp %s gets additional %.0fW in rotation\n", lp.GetTitle(), others)
+		}
+
+		return others
+	}
+
+	now := p.clock.Now()
+
+	var total float64
+	for _, l := range candidates {
+		total += p.strategy.weight(l, now)
+	}
+
+	share := pool * p.strategy.weight(lp, now) / total
+	reduceBy := min(others, max(0, share-p.demand[lp]))
+
+	if p.log != nil && reduceBy > 0 {
+		p.log.DEBUG.Printf("lp %s gets additional %.0fW (%s share %.0fW of %.0fW)\n", lp.GetTitle(), reduceBy, p.strategy, share, pool)
+	}
+
+	return reduceBy
+}
+
+// rotate selects the loadpoint receiving the surplus
+func (p *Prioritizer) rotate(candidates []loadpoint.API) {
+	now := p.clock.Now()
+
+	idx := slices.Index(candidates, p.active)
+	if idx >= 0 && now.Sub(p.rotated) < rotationInterval {
+		return
+	}
+
+	var next loadpoint.API
+	if idx >= 0 {
+		next = candidates[(idx+1)%len(candidates)]
+	} else {
+		// start with the loadpoint currently using most power
+		next = slices.MaxFunc(candidates, func(a, b loadpoint.API) int {
+			return cmp.Compare(p.demand[a], p.demand[b])
+		})
+	}
+
+	if p.log != nil && next != p.active {
+		p.log.DEBUG.Printf("lp %s receives surplus in rotation\n", next.GetTitle())
+	}
+
+	p.active = next
+	p.rotated = now
+}
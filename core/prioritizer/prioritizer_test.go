@@ -3,6 +3,8 @@ package prioritizer
 import (
 	"testing"
 
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -38,3 +40,76 @@ func TestPrioritzer(t *testing.T) {
 	p.UpdateChargePowerFlexibility(lo, nil)
 	assert.Equal(t, 0.0, p.GetChargePowerFlexibility(hi))
 }
+
+func TestPrioritizerStrategies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	newLoadpoint := func(soc float64) *loadpoint.MockAPI {
+		lp := loadpoint.NewMockAPI(ctrl)
+		lp.EXPECT().GetTitle().AnyTimes()
+		lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+		lp.EXPECT().GetStatus().Return(api.StatusB).AnyTimes()
+		lp.EXPECT().GetSoc().Return(soc).AnyTimes()
+		lp.EXPECT().EffectiveLimitSoc().Return(100).AnyTimes()
+		lp.EXPECT().EffectiveMinPower().Return(1380.0).AnyTimes()
+		return lp
+	}
+
+	for _, tc := range []struct {
+		strategy Strategy
+		charging float64
+		res      float64
+	}{
+		{StrategyFairShare, 4000, 2000},
+		{StrategySocDeficit, 4000, 3200},
+	} {
+		p := New(nil)
+		p.SetStrategy(tc.strategy)
+
+		full := newLoadpoint(80)
+		empty := newLoadpoint(20)
+
+		full.EXPECT().GetChargePowerFlexibility(nil).Return(tc.charging)
+		p.UpdateChargePowerFlexibility(full, nil)
+		empty.EXPECT().GetChargePowerFlexibility(nil).Return(0.0)
+		p.UpdateChargePowerFlexibility(empty, nil)
+
+		assert.Equal(t, 0.0, p.GetChargePowerFlexibility(full), tc.strategy)
+		assert.Equal(t, tc.res, p.GetChargePowerFlexibility(empty), tc.strategy)
+	}
+}
+
+func TestPrioritizerRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	clock := clock.NewMock()
+	p := New(nil)
+	p.clock = clock
+	p.SetStrategy(StrategyFairShare)
+
+	var lps []*loadpoint.MockAPI
+	for range 2 {
+		lp := loadpoint.NewMockAPI(ctrl)
+		lp.EXPECT().GetTitle().AnyTimes()
+		lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+		lp.EXPECT().GetStatus().Return(api.StatusB).AnyTimes()
+		lp.EXPECT().GetSoc().Return(0.0).AnyTimes()
+		lp.EXPECT().EffectiveLimitSoc().Return(0).AnyTimes()
+		lp.EXPECT().EffectiveMinPower().Return(1380.0).AnyTimes()
+		lps = append(lps, lp)
+	}
+
+	// surplus only suffices for one loadpoint
+	lps[0].EXPECT().GetChargePowerFlexibility(nil).Return(2000.0)
+	p.UpdateChargePowerFlexibility(lps[0], nil)
+	lps[1].EXPECT().GetChargePowerFlexibility(nil).Return(0.0)
+	p.UpdateChargePowerFlexibility(lps[1], nil)
+
+	// charging loadpoint keeps surplus
+	assert.Equal(t, 0.0, p.GetChargePowerFlexibility(lps[1]))
+
+	// rotate after interval
+	clock.Add(rotationInterval)
+	assert.Equal(t, 2000.0, p.GetChargePowerFlexibility(lps[1]))
+	assert.Equal(t, 0.0, p.GetChargePowerFlexibility(lps[0]))
+}
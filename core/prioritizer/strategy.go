@@ -0,0 +1,44 @@
+package prioritizer
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+//go:generate go tool enumer -type Strategy -trimprefix Strategy -transform=lower -text
+
+// Strategy defines how flexible charge power is distributed between loadpoints
+type Strategy int
+
+const (
+	StrategyPriority   Strategy = iota // higher priority loadpoints take all flexible power
+	StrategyFairShare                  // flexible power is shared equally
+	StrategySocDeficit                 // flexible power is shared by missing soc
+	StrategyDeadline                   // flexible power is shared by plan urgency
+)
+
+// deadlineHorizon is the plan distance at which a loadpoint starts to gain weight
+const deadlineHorizon = 24 * time.Hour
+
+// weight returns the relative share of flexible power for the loadpoint
+func (s Strategy) weight(lp loadpoint.API, now time.Time) float64 {
+	switch s {
+	case StrategySocDeficit:
+		if soc := lp.GetSoc(); soc > 0 {
+			return max(1, float64(lp.EffectiveLimitSoc())-soc)
+		}
+		// unknown soc is treated as half empty
+		return 50
+
+	case StrategyDeadline:
+		planTime := lp.EffectivePlanTime()
+		if planTime.IsZero() {
+			return 1
+		}
+		return 1 + float64(deadlineHorizon)/float64(max(15*time.Minute, planTime.Sub(now)))
+
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,89 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Source is the origin of charged energy
+type Source string
+
+const (
+	SourceGrid    Source = "grid"
+	SourceSolar   Source = "solar"
+	SourceBattery Source = "battery"
+)
+
+// Item is the energy charged from a single source within a tariff slot
+type Item struct {
+	Start  time.Time `json:"start"`
+	Source Source    `json:"source"`
+	Energy float64   `json:"energy"`          // kWh
+	Price  *float64  `json:"price,omitempty"` // applied rate per kWh
+}
+
+// Cost returns the item's cost or nil if the rate is unknown
+func (i Item) Cost() *float64 {
+	if i.Price == nil {
+		return nil
+	}
+	cost := i.Energy * *i.Price
+	return &cost
+}
+
+// Breakdown is the itemized session energy by tariff slot and source
+type Breakdown []Item
+
+// Add adds energy in kWh from given source to the tariff slot starting at ts
+func (b *Breakdown) Add(ts time.Time, source Source, energy float64, price *float64) {
+	if energy <= 0 {
+		return
+	}
+
+	for i := len(*b) - 1; i >= 0 && (*b)[i].Start.Equal(ts); i-- {
+		if it := &(*b)[i]; it.Source == source && samePrice(it.Price, price) {
+			it.Energy += energy
+			return
+		}
+	}
+
+	var p *float64
+	if price != nil {
+		p = new(float64)
+		*p = *price
+	}
+
+	*b = append(*b, Item{Start: ts, Source: source, Energy: energy, Price: p})
+}
+
+// Energy returns the total energy in kWh from given source
+func (b Breakdown) Energy(source Source) float64 {
+	var res float64
+	for _, it := range b {
+		if it.Source == source {
+			res += it.Energy
+		}
+	}
+	return res
+}
+
+// String implements fmt.Stringer for csv export
+func (b Breakdown) String() string {
+	items := make([]string, 0, len(b))
+	for _, it := range b {
+		s := fmt.Sprintf("%s %s %.3fkWh", it.Start.Local().Format("2006-01-02 15:04"), it.Source, it.Energy)
+		if it.Price != nil {
+			s += fmt.Sprintf(" @%.4f", *it.Price)
+		}
+		items = append(items, s)
+	}
+	return strings.Join(items, "; ")
+}
+
+func samePrice(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
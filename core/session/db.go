@@ -18,7 +18,7 @@ var (
 )
 
 func Init() error {
-	err := db.Instance.AutoMigrate(new(Session))
+	err := db.Instance.AutoMigrate(new(Session), new(LedgerEntry))
 	if err == nil {
 		err = db.Instance.Find(&sessions).Error
 	}
@@ -58,6 +58,17 @@ func (s *DB) Persist(session interface{}) {
 	}
 }
 
+// Finalize records the completed session in the ledger if enabled
+func (s *DB) Finalize(session Session) {
+	if !LedgerEnabled() {
+		return
+	}
+
+	if err := Append(session); err != nil {
+		s.log.ERROR.Printf("ledger: %v", err)
+	}
+}
+
 // Return sessions
 // TODO make this part of server/db
 func (s *DB) Sessions() (Sessions, error) {
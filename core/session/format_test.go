@@ -2,6 +2,7 @@ package session
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
@@ -15,3 +16,19 @@ func TestFormatValue(t *testing.T) {
 	assert.Equal(t, "1.234", formatValue(mp, f, 3))
 	assert.Equal(t, "1.234", formatValue(mp, &f, 3))
 }
+
+func TestFormatBreakdown(t *testing.T) {
+	mp := message.NewPrinter(language.Make("en"))
+
+	ts := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+	price := 0.3
+
+	var b Breakdown
+	b.Add(ts, SourceGrid, 1, &price)
+	b.Add(ts, SourceGrid, 0.5, &price)
+	b.Add(ts, SourceSolar, 2, nil)
+
+	assert.Equal(t, 1.5, b.Energy(SourceGrid))
+	assert.Equal(t, "2025-01-06 12:00 grid 1.500kWh @0.3000; 2025-01-06 12:00 solar 2.000kWh", formatValue(mp, b, 3))
+	assert.Empty(t, formatValue(mp, Breakdown(nil), 3))
+}
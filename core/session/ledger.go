@@ -0,0 +1,396 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"gorm.io/gorm"
+)
+
+// LedgerAlgorithm is the ledger signature algorithm
+const LedgerAlgorithm = "ed25519"
+
+var ErrLedgerInvalid = errors.New("ledger invalid")
+
+// LedgerEntry is a finalized session in the append-only ledger.
+// Each entry's hash covers the previous entry's hash, making the ledger a hash chain.
+type LedgerEntry struct {
+	Seq       uint      `json:"seq" gorm:"primarykey;autoIncrement:false"`
+	SessionID uint      `json:"sessionId" gorm:"index"`
+	Created   time.Time `json:"created"`
+	Data      string    `json:"data"`     // session json
+	PrevHash  string    `json:"prevHash"` // hex sha256
+	Hash      string    `json:"hash"`     // hex sha256
+	Signature string    `json:"signature"`
+}
+
+// Ledger is a list of ledger entries
+type Ledger []LedgerEntry
+
+var _ api.CsvWriter = (*Ledger)(nil)
+
+// LedgerSignature is the detached signature of a ledger export.
+// It signs the chain head, so any export containing the first Count entries can be verified.
+type LedgerSignature struct {
+	Algorithm string    `json:"algorithm"`
+	PublicKey string    `json:"publicKey"` // base64
+	Created   time.Time `json:"created"`
+	Count     int       `json:"count"`
+	Head      string    `json:"head"`
+	Signature string    `json:"signature"` // base64
+}
+
+// LedgerEnabled returns true if finalized sessions are recorded in the ledger
+func LedgerEnabled() bool {
+	res, _ := settings.Bool(keys.SessionLedger)
+	return res
+}
+
+// SetLedgerEnabled enables the session ledger. The signing key is created on first use.
+func SetLedgerEnabled(enable bool) error {
+	if enable {
+		if _, err := ledgerKey(); err != nil {
+			return err
+		}
+	}
+
+	settings.SetBool(keys.SessionLedger, enable)
+
+	return nil
+}
+
+// ErrNoLedgerKey is returned if no ledger signing key has been created yet
+var ErrNoLedgerKey = errors.New("no ledger key")
+
+// storedLedgerKey returns the ledger signing key from the settings without creating it
+func storedLedgerKey() (ed25519.PrivateKey, error) {
+	val, err := settings.String(keys.SessionLedgerKey)
+	if err != nil || val == "" {
+		return nil, ErrNoLedgerKey
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(val)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, errors.New("invalid ledger key")
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ledgerKey returns the ledger signing key from the settings or generates a new one
+func ledgerKey() (ed25519.PrivateKey, error) {
+	key, err := storedLedgerKey()
+	if !errors.Is(err, ErrNoLedgerKey) {
+		return key, err
+	}
+
+	_, key, err = ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.SetString(keys.SessionLedgerKey, base64.StdEncoding.EncodeToString(key.Seed()))
+
+	return key, settings.Persist()
+}
+
+// LedgerPublicKey returns the base64 encoded ledger public key. Returns ErrNoLedgerKey if no key has been created.
+func LedgerPublicKey() (string, error) {
+	key, err := storedLedgerKey()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)), nil
+}
+
+// ledgerHash returns the hash of the entry's content
+func ledgerHash(e LedgerEntry) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\n%d\n%s\n%s\n", e.Seq, e.SessionID, e.PrevHash, e.Data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// headMessage is the message signed by a ledger signature
+func headMessage(count int, head string) []byte {
+	return fmt.Appendf(nil, "%d\n%s", count, head)
+}
+
+// Append adds the finalized session to the ledger
+func Append(s Session) error {
+	key, err := ledgerKey()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return db.Instance.Transaction(func(tx *gorm.DB) error {
+		var prev LedgerEntry
+		if err := tx.Order("seq DESC").Limit(1).Find(&prev).Error; err != nil {
+			return err
+		}
+
+		e := LedgerEntry{
+			Seq:       prev.Seq + 1,
+			SessionID: s.ID,
+			Created:   time.Now().UTC().Truncate(time.Second),
+			Data:      string(data),
+			PrevHash:  prev.Hash,
+		}
+		e.Hash = ledgerHash(e)
+
+		hash, _ := hex.DecodeString(e.Hash)
+		e.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, hash))
+
+		return tx.Create(&e).Error
+	})
+}
+
+// Ledgered returns true if the session has been recorded in the ledger
+func Ledgered(id uint) (bool, error) {
+	var count int64
+	err := db.Instance.Model(new(LedgerEntry)).Where("session_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// LedgerEntries returns all ledger entries in order
+func LedgerEntries() (Ledger, error) {
+	var res Ledger
+	err := db.Instance.Order("seq").Find(&res).Error
+	return res, err
+}
+
+// Sign creates the detached signature of the ledger
+func (l Ledger) Sign() (LedgerSignature, error) {
+	key, err := ledgerKey()
+	if err != nil {
+		return LedgerSignature{}, err
+	}
+
+	res := LedgerSignature{
+		Algorithm: LedgerAlgorithm,
+		PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Created:   time.Now().UTC().Truncate(time.Second),
+		Count:     len(l),
+	}
+
+	if len(l) > 0 {
+		res.Head = l[len(l)-1].Hash
+	}
+
+	res.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, headMessage(res.Count, res.Head)))
+
+	return res, nil
+}
+
+// Verify validates hashes, signatures and chaining of all entries using the given base64 public key.
+// If sig is not nil, the ledger head is validated against the detached signature.
+func (l Ledger) Verify(publicKey string, sig *LedgerSignature) error {
+	pub, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return errors.New("invalid public key")
+	}
+
+	var prev string
+	for i, e := range l {
+		if e.Seq != uint(i+1) {
+			return fmt.Errorf("%w: entry %d: unexpected sequence %d", ErrLedgerInvalid, i+1, e.Seq)
+		}
+
+		if e.PrevHash != prev {
+			return fmt.Errorf("%w: entry %d: chain broken", ErrLedgerInvalid, e.Seq)
+		}
+
+		if ledgerHash(e) != e.Hash {
+			return fmt.Errorf("%w: entry %d: hash mismatch", ErrLedgerInvalid, e.Seq)
+		}
+
+		hash, _ := hex.DecodeString(e.Hash)
+		signature, err := base64.StdEncoding.DecodeString(e.Signature)
+		if err != nil || !ed25519.Verify(pub, hash, signature) {
+			return fmt.Errorf("%w: entry %d: invalid signature", ErrLedgerInvalid, e.Seq)
+		}
+
+		prev = e.Hash
+	}
+
+	if sig == nil {
+		return nil
+	}
+
+	if sig.Algorithm != LedgerAlgorithm || sig.PublicKey != publicKey {
+		return fmt.Errorf("%w: signature key mismatch", ErrLedgerInvalid)
+	}
+
+	if sig.Count > len(l) {
+		return fmt.Errorf("%w: signature covers %d entries, ledger has %d", ErrLedgerInvalid, sig.Count, len(l))
+	}
+
+	var head string
+	if sig.Count > 0 {
+		head = l[sig.Count-1].Hash
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil || head != sig.Head || !ed25519.Verify(pub, headMessage(sig.Count, sig.Head), signature) {
+		return fmt.Errorf("%w: invalid head signature", ErrLedgerInvalid)
+	}
+
+	return nil
+}
+
+// Sessions returns the sessions recorded in the ledger
+func (l Ledger) Sessions() (Sessions, error) {
+	res := make(Sessions, 0, len(l))
+	for _, e := range l {
+		var s Session
+		if err := json.Unmarshal([]byte(e.Data), &s); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", e.Seq, err)
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+// canonical returns the session's json with times normalized to UTC
+func canonical(s Session) string {
+	s.Created = s.Created.UTC()
+	s.Finished = s.Finished.UTC()
+
+	s.Breakdown = slices.Clone(s.Breakdown)
+	for i := range s.Breakdown {
+		s.Breakdown[i].Start = s.Breakdown[i].Start.UTC()
+	}
+
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// Compare returns the ids of ledger sessions that have been modified or deleted in the current sessions
+func (l Ledger) Compare(current Sessions) (modified, deleted []uint, err error) {
+	ledgered, err := l.Sessions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[uint]Session, len(current))
+	for _, s := range current {
+		byID[s.ID] = s
+	}
+
+	for _, s := range ledgered {
+		cur, ok := byID[s.ID]
+		switch {
+		case !ok:
+			deleted = append(deleted, s.ID)
+		case canonical(cur) != canonical(s):
+			modified = append(modified, s.ID)
+		}
+	}
+
+	return modified, deleted, nil
+}
+
+var ledgerCsvHeader = []string{"seq", "sessionId", "created", "data", "prevHash", "hash", "signature"}
+
+// WriteCsv implements the api.CsvWriter interface
+func (l *Ledger) WriteCsv(_ context.Context, w io.Writer) error {
+	ww := csv.NewWriter(w)
+
+	if err := ww.Write(ledgerCsvHeader); err != nil {
+		return err
+	}
+
+	for _, e := range *l {
+		if err := ww.Write([]string{
+			strconv.FormatUint(uint64(e.Seq), 10),
+			strconv.FormatUint(uint64(e.SessionID), 10),
+			e.Created.UTC().Format(time.RFC3339),
+			e.Data,
+			e.PrevHash,
+			e.Hash,
+			e.Signature,
+		}); err != nil {
+			return err
+		}
+	}
+
+	ww.Flush()
+
+	return ww.Error()
+}
+
+// ReadLedger reads a ledger export in json or csv format
+func ReadLedger(r io.Reader) (Ledger, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var res Ledger
+	if err := json.Unmarshal(b, &res); err == nil {
+		return res, nil
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		return nil, errors.New("invalid ledger format")
+	}
+
+	for i, rec := range records {
+		if i == 0 {
+			continue
+		}
+
+		if len(rec) != len(ledgerCsvHeader) {
+			return nil, fmt.Errorf("line %d: invalid record", i+1)
+		}
+
+		seq, err := strconv.ParseUint(rec[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		id, err := strconv.ParseUint(rec[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		created, err := time.Parse(time.RFC3339, rec[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		res = append(res, LedgerEntry{
+			Seq:       uint(seq),
+			SessionID: uint(id),
+			Created:   created,
+			Data:      rec[3],
+			PrevHash:  rec[4],
+			Hash:      rec[5],
+			Signature: rec[6],
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,148 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLedger(t *testing.T) Sessions {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, settings.Init())
+	require.NoError(t, Init())
+
+	created := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+
+	var res Sessions
+	for i := range 3 {
+		s := Session{
+			Created:       created.Add(time.Duration(i) * time.Hour),
+			Finished:      created.Add(time.Duration(i)*time.Hour + 30*time.Minute),
+			Loadpoint:     "garage",
+			Vehicle:       "car",
+			ChargedEnergy: float64(i + 1),
+		}
+		require.NoError(t, db.Instance.Create(&s).Error)
+		require.NoError(t, Append(s))
+		res = append(res, s)
+	}
+
+	return res
+}
+
+func TestLedgerPublicKeyReadOnly(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, settings.Init())
+
+	_, err := LedgerPublicKey()
+	assert.ErrorIs(t, err, ErrNoLedgerKey)
+
+	// no key created
+	_, err = storedLedgerKey()
+	assert.ErrorIs(t, err, ErrNoLedgerKey)
+}
+
+func TestLedgerVerify(t *testing.T) {
+	setupLedger(t)
+
+	entries, err := LedgerEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	key, err := LedgerPublicKey()
+	require.NoError(t, err)
+
+	sig, err := entries.Sign()
+	require.NoError(t, err)
+	assert.NoError(t, entries.Verify(key, &sig))
+
+	// tampered data
+	tampered := append(Ledger(nil), entries...)
+	tampered[1].Data = `{"chargedEnergy":1}`
+	assert.ErrorIs(t, tampered.Verify(key, nil), ErrLedgerInvalid)
+
+	// removed entry
+	assert.ErrorIs(t, append(Ledger{entries[0]}, entries[2]).Verify(key, nil), ErrLedgerInvalid)
+
+	// truncated export
+	assert.ErrorIs(t, entries[:2].Verify(key, &sig), ErrLedgerInvalid)
+
+	// signature with foreign key
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	forged := sig
+	forged.PublicKey = base64.StdEncoding.EncodeToString(pub)
+	assert.ErrorIs(t, entries.Verify(key, &forged), ErrLedgerInvalid)
+	assert.ErrorIs(t, entries.Verify(forged.PublicKey, &forged), ErrLedgerInvalid)
+
+	// later entries don't invalidate earlier signature
+	s := Session{ID: 99, ChargedEnergy: 1}
+	require.NoError(t, Append(s))
+	entries, err = LedgerEntries()
+	require.NoError(t, err)
+	assert.NoError(t, entries.Verify(key, &sig))
+}
+
+func TestLedgerExport(t *testing.T) {
+	setupLedger(t)
+
+	entries, err := LedgerEntries()
+	require.NoError(t, err)
+
+	key, err := LedgerPublicKey()
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, entries.WriteCsv(context.Background(), &b))
+
+	res, err := ReadLedger(&b)
+	require.NoError(t, err)
+	assert.NoError(t, res.Verify(key, nil))
+
+	js, err := json.Marshal(entries)
+	require.NoError(t, err)
+
+	res, err = ReadLedger(bytes.NewReader(js))
+	require.NoError(t, err)
+	assert.NoError(t, res.Verify(key, nil))
+}
+
+func TestLedgerCompare(t *testing.T) {
+	sessions := setupLedger(t)
+
+	entries, err := LedgerEntries()
+	require.NoError(t, err)
+
+	var current Sessions
+	require.NoError(t, db.Instance.Find(&current).Error)
+
+	modified, deleted, err := entries.Compare(current)
+	require.NoError(t, err)
+	assert.Empty(t, modified)
+	assert.Empty(t, deleted)
+
+	require.NoError(t, db.Instance.Model(&sessions[0]).Update("vehicle", "other").Error)
+	require.NoError(t, db.Instance.Delete(&sessions[2]).Error)
+
+	current = nil
+	require.NoError(t, db.Instance.Find(&current).Error)
+
+	modified, deleted, err = entries.Compare(current)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{sessions[0].ID}, modified)
+	assert.Equal(t, []uint{sessions[2].ID}, deleted)
+
+	ledgered, err := Ledgered(sessions[0].ID)
+	require.NoError(t, err)
+	assert.True(t, ledgered)
+}
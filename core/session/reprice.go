@@ -0,0 +1,80 @@
+package session
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/rates"
+)
+
+// Reprice updates the breakdown prices and the session price from the given rates.
+// Grid energy is priced at the grid rate, self-produced energy at the feed-in rate of the item's slot.
+// It returns false and leaves the session unmodified if the session has no breakdown or a grid rate is missing.
+// Rates MUST be sorted by start time.
+func (s *Session) Reprice(grid, feedin api.Rates) bool {
+	if len(s.Breakdown) == 0 {
+		return false
+	}
+
+	breakdown := make(Breakdown, 0, len(s.Breakdown))
+
+	var price float64
+	for _, it := range s.Breakdown {
+		var rate float64
+
+		if it.Source == SourceGrid {
+			r, err := grid.At(it.Start)
+			if err != nil {
+				return false
+			}
+			rate = r.Value
+		} else if r, err := feedin.At(it.Start); err == nil {
+			rate = r.Value
+		}
+
+		breakdown.Add(it.Start, it.Source, it.Energy, &rate)
+		price += it.Energy * rate
+	}
+
+	s.Breakdown = breakdown
+	s.Price = &price
+
+	if s.ChargedEnergy > 0 {
+		pricePerKWh := price / s.ChargedEnergy
+		s.PricePerKWh = &pricePerKWh
+	}
+
+	return true
+}
+
+// RepriceFromHistory reprices the session from the persisted rate history
+func (s *Session) RepriceFromHistory() (bool, error) {
+	if db.Instance == nil || len(s.Breakdown) == 0 {
+		return false, nil
+	}
+
+	from := s.Created
+	for _, it := range s.Breakdown {
+		if it.Start.Before(from) {
+			from = it.Start
+		}
+	}
+
+	to := s.Finished
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	grid, err := rates.Get(api.TariffUsageGrid.String(), "", from, to)
+	if err != nil {
+		return false, err
+	}
+
+	feedin, err := rates.Get(api.TariffUsageFeedIn.String(), "", from, to)
+	if err != nil {
+		return false, err
+	}
+
+	return s.Reprice(grid, feedin), nil
+}
@@ -0,0 +1,51 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReprice(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.Local)
+	slot := start.Add(15 * time.Minute)
+
+	price := func(f float64) *float64 { return &f }
+
+	s := Session{
+		Created:       start,
+		Finished:      slot.Add(15 * time.Minute),
+		ChargedEnergy: 4,
+		Breakdown: Breakdown{
+			{Start: start, Source: SourceGrid, Energy: 1, Price: price(0.3)},
+			{Start: start, Source: SourceSolar, Energy: 1, Price: price(0.08)},
+			{Start: slot, Source: SourceGrid, Energy: 1, Price: price(0.3)},
+			{Start: slot, Source: SourceBattery, Energy: 1, Price: price(0.08)},
+		},
+	}
+
+	grid := api.Rates{
+		{Start: start, End: slot, Value: 0.2},
+		{Start: slot, End: slot.Add(15 * time.Minute), Value: 0.4},
+	}
+	feedin := api.Rates{
+		{Start: start, End: start.Add(time.Hour), Value: 0.1},
+	}
+
+	// missing grid rate
+	assert.False(t, s.Reprice(grid[:1], feedin))
+	assert.Equal(t, 0.3, *s.Breakdown[0].Price)
+
+	require.True(t, s.Reprice(grid, feedin))
+	assert.InDelta(t, 0.8, *s.Price, 1e-9)
+	assert.InDelta(t, 0.2, *s.PricePerKWh, 1e-9)
+	assert.Equal(t, 0.4, *s.Breakdown[2].Price)
+	assert.Equal(t, 0.1, *s.Breakdown[3].Price)
+
+	// self-produced energy is free without feed-in rate
+	require.True(t, s.Reprice(grid, nil))
+	assert.InDelta(t, 0.6, *s.Price, 1e-9)
+}
@@ -25,6 +25,7 @@ type Session struct {
 	Finished        time.Time      `json:"finished"`
 	Loadpoint       string         `json:"loadpoint"`
 	Identifier      string         `json:"identifier"`
+	User            string         `json:"user"`
 	Vehicle         string         `json:"vehicle"`
 	Odometer        *float64       `json:"odometer" format:"int"`
 	MeterStart      *float64       `json:"meterStart" csv:"Meter Start (kWh)" gorm:"column:meter_start_kwh"`
@@ -35,6 +36,10 @@ type Session struct {
 	Price           *float64       `json:"price" csv:"Price" gorm:"column:price"`
 	PricePerKWh     *float64       `json:"pricePerKWh" csv:"Price/kWh" gorm:"column:price_per_kwh"`
 	Co2PerKWh       *float64       `json:"co2PerKWh" csv:"CO2/kWh (gCO2eq)" gorm:"column:co2_per_kwh"`
+	GridEnergy      *float64       `json:"gridEnergy" csv:"Grid (kWh)" gorm:"column:grid_kwh"`
+	SolarEnergy     *float64       `json:"solarEnergy" csv:"Solar (kWh)" gorm:"column:solar_kwh"`
+	BatteryEnergy   *float64       `json:"batteryEnergy" csv:"Battery (kWh)" gorm:"column:battery_kwh"`
+	Breakdown       Breakdown      `json:"breakdown" csv:"Breakdown" gorm:"column:breakdown;serializer:json"`
 }
 
 // Sessions is a list of sessions
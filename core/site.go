@@ -15,8 +15,10 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/cmd/shutdown"
+	"github.com/evcc-io/evcc/core/battery"
 	"github.com/evcc-io/evcc/core/circuit"
 	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/core/health"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/metrics"
@@ -29,6 +31,7 @@ import (
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/history"
 	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
@@ -46,6 +49,7 @@ const standbyPower = 10 // consider less than 10W as charger in standby
 type updater interface {
 	loadpoint.API
 	Update(sitePower, batteryBoostPower float64, consumption, feedin api.Rates, isBatteryBuffered, isBatteryStart bool, greenShare float64, effectivePrice, effectiveCo2 *float64)
+	setEnergySources(slot time.Time, batteryShare float64, gridPrice, feedInPrice *float64)
 }
 
 // measurement is used as slice element for publishing structured data
@@ -80,6 +84,10 @@ type Site struct {
 	ResidualPower float64      `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
 	Meters        MetersConfig `mapstructure:"meters"`        // Meter references
 
+	// battery schedule optimization
+	BatteryEfficiency float64 `mapstructure:"batteryEfficiency"` // Battery round-trip efficiency in %
+	BatteryCRate      float64 `mapstructure:"batteryCRate"`      // Battery max (dis)charge power relative to capacity
+
 	// meters
 	circuit       api.Circuit                // Circuit
 	gridMeter     api.Meter                  // Grid usage meter
@@ -94,21 +102,32 @@ type Site struct {
 	bufferStartSoc          float64  // start charging on battery above this Soc
 	batteryDischargeControl bool     // prevent battery discharge for fast and planned charging
 	batteryGridChargeLimit  *float64 // grid charging limit
-
-	loadpoints  []*Loadpoint             // Loadpoints
-	tariffs     *tariff.Tariffs          // Tariffs
-	coordinator *coordinator.Coordinator // Vehicles
-	prioritizer *prioritizer.Prioritizer // Power budgets
-	stats       *Stats                   // Stats
-	fcstEnergy  *meterEnergy
-	pvEnergy    map[string]*meterEnergy
+	batteryOptimizer        bool     // optimize battery mode using tariffs and forecasts
+
+	clock        clock.Clock              // mockable time
+	loadpoints   []*Loadpoint             // Loadpoints
+	tariffs      *tariff.Tariffs          // Tariffs
+	coordinator  *coordinator.Coordinator // Vehicles
+	prioritizer  *prioritizer.Prioritizer // Power budgets
+	jointPlanner *planner.Joint           // Joint charging plans
+	stats        *Stats                   // Stats
+	fcstEnergy   *meterEnergy
+	pvEnergy     map[string]*meterEnergy
 
 	// sitePower storage
-	sitePowerScheduler *sitepower.Scheduler // SitePower定时存储调度器
-	sitePowerAPI       *sitepower.API       // SitePower API实例
+	sitePowerAPI *sitepower.API // SitePower API实例
+
+	history *history.Recorder // Per-meter energy history
 
 	householdEnergy    *meterEnergy
 	householdSlotStart time.Time
+	householdFcst      api.Rates // household consumption forecast
+	householdFcstSlot  time.Time // household consumption forecast slot
+
+	solarSlotEnergy *meterEnergy // pv production of current slot
+	solarSlotStart  time.Time
+	solarSlotFcst   *float64 // forecasted pv production of current slot in Wh
+	solarSlotLimit  bool     // pv production of current slot was curtailed
 
 	// cached state
 	gridPower                float64         // Grid power
@@ -121,6 +140,8 @@ type Site struct {
 	batteryMode              api.BatteryMode // Battery mode (runtime only, not persisted)
 	batteryModeExternal      api.BatteryMode // Battery mode (external, runtime only, not persisted)
 	batteryModeExternalTimer time.Time       // Battery mode timer for external control
+
+	batterySchedule battery.Schedule // Battery schedule (runtime only, not persisted)
 }
 
 // MetersConfig contains the site's meter configuration
@@ -141,6 +162,14 @@ func NewSiteFromConfig(other map[string]interface{}) (*Site, error) {
 		return nil, err
 	}
 
+	if site.BatteryEfficiency <= 0 || site.BatteryEfficiency > 100 {
+		return nil, fmt.Errorf("invalid battery efficiency: %.0f%%", site.BatteryEfficiency)
+	}
+
+	if site.BatteryCRate <= 0 {
+		return nil, fmt.Errorf("invalid battery c-rate: %v", site.BatteryCRate)
+	}
+
 	// add meters from config
 	site.restoreMetersAndTitle()
 
@@ -158,6 +187,11 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 	site.coordinator = coordinator.New(log, config.Instances(handler.Devices()))
 	handler.Subscribe(site.updateVehicles)
 
+	// track all vehicles, stale data is only detected while connected
+	for _, dev := range handler.Devices() {
+		health.Track(health.Vehicle, dev.Config().Name).SetIdle(true)
+	}
+
 	site.prioritizer = prioritizer.New(log)
 	site.stats = NewStats()
 
@@ -169,11 +203,13 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 	}
 
 	tariff := site.GetTariff(api.TariffUsagePlanner)
+	site.jointPlanner = planner.NewJoint(log, tariff, planner.WithJointClock(site.clock))
 
 	// give loadpoints access to vehicles and database
 	for _, lp := range loadpoints {
 		lp.coordinator = coordinator.NewAdapter(lp, site.coordinator)
-		lp.planner = planner.New(lp.log, tariff)
+		lp.planner = planner.New(lp.log, tariff, planner.WithClock(site.clock))
+		lp.setClock(site.clock)
 
 		if db.Instance != nil {
 			var err error
@@ -216,7 +252,7 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 		site.pvMeters = append(site.pvMeters, dev)
 
 		// accumulator
-		site.pvEnergy[ref] = &meterEnergy{clock: clock.New()}
+		site.pvEnergy[ref] = &meterEnergy{clock: site.clock}
 	}
 
 	// multiple batteries
@@ -245,23 +281,15 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 		}
 		site.auxMeters = append(site.auxMeters, dev)
 	}
-	// 初始化sitePower存储
 	if db.Instance != nil {
-		// 直接使用evcc的数据库实例，自动创建sitepower表
-		sitePowerDB, err := sitepower.NewStore(db.Instance)
-		if err != nil {
-			site.log.ERROR.Printf("failed to initialize sitePower storage: %v", err)
-		} else {
-			// 创建1分钟间隔的调度器
-			site.sitePowerScheduler = sitepower.NewScheduler(sitePowerDB, 1*time.Minute)
-			site.sitePowerScheduler.Start()
-			
-			// 初始化sitePower API
-			site.sitePowerAPI = sitepower.NewAPI(sitePowerDB)
-			
-			site.log.INFO.Println("sitePower storage and API initialized with 1-minute interval")
-		}
+		site.history = history.NewRecorder(site.clock)
+	}
+
+	// sitePower API读取能量历史中的站点功率
+	if db.Instance != nil {
+		site.sitePowerAPI = sitepower.NewAPI(site.GetTitle)
 	}
+
 	// revert battery mode on shutdown
 	shutdown.Register(func() {
 		if mode := site.GetBatteryMode(); batteryModeModified(mode) {
@@ -269,10 +297,6 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 				site.log.ERROR.Println("battery mode:", err)
 			}
 		}
-		// 停止sitePower调度器
-		if site.sitePowerScheduler != nil {
-			site.sitePowerScheduler.Stop()
-		}
 	})
 
 	return nil
@@ -285,17 +309,32 @@ func (site *Site) GetSitePowerAPI() *sitepower.API {
 
 // NewSite creates a Site with sane defaults
 func NewSite() *Site {
+	clock := clock.New()
+
 	site := &Site{
 		log:             util.NewLogger("site"),
+		clock:           clock,
 		Voltage:         230, // V
 		pvEnergy:        make(map[string]*meterEnergy),
-		fcstEnergy:      &meterEnergy{clock: clock.New()},
-		householdEnergy: &meterEnergy{clock: clock.New()},
+		fcstEnergy:      &meterEnergy{clock: clock},
+		householdEnergy: &meterEnergy{clock: clock},
+		solarSlotEnergy: &meterEnergy{clock: clock},
+
+		BatteryEfficiency: 90, // %
+		BatteryCRate:      0.5,
 	}
 
 	return site
 }
 
+// SetClock replaces the site's clock, e.g. by a simulated clock. The clock is passed to the loadpoints on boot.
+func (site *Site) SetClock(clock clock.Clock) {
+	site.clock = clock
+	site.fcstEnergy.clock = clock
+	site.householdEnergy.clock = clock
+	site.solarSlotEnergy.clock = clock
+}
+
 // restoreMetersAndTitle restores site meter configuration
 func (site *Site) restoreMetersAndTitle() {
 	if testing.Testing() {
@@ -355,6 +394,20 @@ func (site *Site) restoreSettings() error {
 	if v, err := settings.Float(keys.BatteryGridChargeLimit); err == nil {
 		site.SetBatteryGridChargeLimit(&v)
 	}
+	if v, err := settings.String(keys.Prioritization); err == nil && v != "" {
+		strategy, err := prioritizer.StrategyString(v)
+		if err != nil {
+			return err
+		}
+		if err := site.SetPrioritization(strategy); err != nil {
+			return err
+		}
+	}
+	if v, err := settings.Bool(keys.BatteryOptimizer); err == nil {
+		if err := site.SetBatteryOptimizer(v); err != nil {
+			return err
+		}
+	}
 
 	// restore accumulated energy
 	pvEnergy := make(map[string]meterEnergy)
@@ -521,15 +574,19 @@ func (site *Site) collectMeters(key string, meters []config.Device[api.Meter]) [
 
 		// power
 		var b bytes.Buffer
+		tracker := health.Track(health.Meter, dev.Config().Name)
 		power, err := backoff.RetryWithData(func() (float64, error) {
 			start := time.Now()
 			f, err := meter.CurrentPower()
+			tracker.Request(start, err)
 			if err != nil {
 				d := time.Since(start)
 				fmt.Fprintf(&b, "%v !! %3dms %v\n", start, d.Milliseconds(), err)
 			}
 			return f, err
 		}, modbus.Backoff())
+		tracker.Result(err)
+
 		if err == nil {
 			site.log.DEBUG.Printf("%s %d power: %.0fW", key, i+1, power)
 		} else {
@@ -615,6 +672,10 @@ func (site *Site) updatePvMeters() {
 	site.publish(keys.PvEnergy, totalEnergy)
 	site.publish(keys.Pv, mm)
 
+	for i, dev := range site.pvMeters {
+		site.addHistory("pv", dev.Config().Name, mm[i].Power)
+	}
+
 	// update solar yield
 	for i, dev := range site.pvMeters {
 		// use stored devices, not ui-updated instances!
@@ -670,6 +731,8 @@ func (site *Site) updateBatteryMeters() {
 
 		_, controllable := meter.(api.BatteryController)
 
+		site.addHistory("battery", dev.Config().Name, mm[i].Power)
+
 		mm[i].Soc = lo.ToPtr(batSoc)
 		mm[i].Capacity = lo.ToPtr(capacity)
 		mm[i].Controllable = lo.ToPtr(controllable)
@@ -720,6 +783,10 @@ func (site *Site) updateAuxMeters() {
 	}
 
 	mm := site.collectMeters("aux", site.auxMeters)
+	for i, dev := range site.auxMeters {
+		site.addHistory("aux", dev.Config().Name, mm[i].Power)
+	}
+
 	site.auxPower = lo.SumBy(mm, func(m measurement) float64 {
 		return m.Power
 	})
@@ -739,6 +806,10 @@ func (site *Site) updateExtMeters() {
 	}
 
 	mm := site.collectMeters("ext", site.extMeters)
+	for i, dev := range site.extMeters {
+		site.addHistory("ext", dev.Config().Name, mm[i].Power)
+	}
+
 	site.publish(keys.Ext, mm)
 }
 
@@ -750,10 +821,15 @@ func (site *Site) updateGridMeter() error {
 
 	var mm measurement
 
-	if res, err := backoff.RetryWithData(site.gridMeter.CurrentPower, modbus.Backoff()); err == nil {
+	tracker := health.Track(health.Meter, site.GetGridMeterRef())
+	res, err := backoff.RetryWithData(health.Measure(tracker, site.gridMeter.CurrentPower), modbus.Backoff())
+	tracker.Result(err)
+
+	if err == nil {
 		mm.Power = res
 		site.gridPower = res
 		site.log.DEBUG.Printf("grid power: %.0fW", res)
+		site.addHistory("grid", "grid", res)
 	} else {
 		return fmt.Errorf("grid power: %v", err)
 	}
@@ -830,7 +906,7 @@ func (site *Site) updateHouseholdConsumption(totalChargePower float64) {
 		if slotStart.Sub(site.householdSlotStart) >= slotDuration {
 			// more or less full slot
 			site.log.DEBUG.Printf("15min household consumption: %.0fWh", site.householdEnergy.Accumulated)
-			if err := metrics.Persist(site.householdSlotStart, site.householdEnergy.Accumulated); err != nil {
+			if err := metrics.Persist(metrics.Household, site.householdSlotStart, site.householdEnergy.Accumulated); err != nil && !errors.Is(err, metrics.ErrIncomplete) {
 				site.log.ERROR.Printf("persist household consumption: %v", err)
 			}
 		}
@@ -840,6 +916,82 @@ func (site *Site) updateHouseholdConsumption(totalChargePower float64) {
 	}
 }
 
+// updateSolarProduction records forecasted and actual pv production per slot for forecast accuracy tracking
+func (site *Site) updateSolarProduction() {
+	if db.Instance == nil || len(site.pvMeters) == 0 {
+		return
+	}
+
+	site.solarSlotEnergy.AddPower(max(0, site.pvPower))
+
+	now := site.solarSlotEnergy.clock.Now()
+	site.solarSlotLimit = site.solarSlotLimit || site.pvCurtailed(now)
+	slotStart := now.Truncate(metrics.SlotDuration)
+
+	if site.solarSlotStart.IsZero() {
+		site.solarSlotStart = now
+		return
+	}
+
+	if !slotStart.After(site.solarSlotStart) {
+		return
+	}
+
+	// only full slots with forecast available at slot start
+	if fcst := site.solarSlotFcst; fcst != nil && slotStart.Sub(site.solarSlotStart) >= metrics.SlotDuration {
+		actual := site.solarSlotEnergy.Accumulated * 1e3
+		site.log.DEBUG.Printf("15min pv production: %.0fWh (forecast: %.0fWh)", actual, *fcst)
+
+		if err := metrics.PersistForecast(metrics.Solar, site.solarSlotStart, *fcst, actual, site.solarSlotLimit); err != nil {
+			site.log.ERROR.Printf("persist pv production: %v", err)
+		}
+	}
+
+	site.solarSlotStart = slotStart
+	site.solarSlotEnergy.Accumulated = 0
+	site.solarSlotFcst = nil
+	site.solarSlotLimit = false
+
+	if solar := site.solarForecast(); len(solar) > 0 {
+		site.solarSlotFcst = lo.ToPtr(solarEnergy(solar, slotStart, slotStart.Add(metrics.SlotDuration)))
+	}
+}
+
+// pvCurtailed returns true if pv production is likely curtailed as feed-in is charged at negative prices
+func (site *Site) pvCurtailed(now time.Time) bool {
+	r, err := tariff.At(site.GetTariff(api.TariffUsageFeedIn), now)
+	return err == nil && r.Value < 0
+}
+
+// solarForecast returns the uncorrected solar forecast
+func (site *Site) solarForecast() api.Rates {
+	t := site.GetTariff(api.TariffUsageSolar)
+	if c, ok := t.(*metrics.Corrected); ok {
+		t = c.Unwrap()
+	}
+	return tariff.Forecast(t)
+}
+
+// householdForecast returns the household consumption forecast, updated once per slot
+func (site *Site) householdForecast() api.Rates {
+	if db.Instance == nil {
+		return nil
+	}
+
+	now := site.clock.Now()
+	if slot := now.Truncate(metrics.SlotDuration); slot.After(site.householdFcstSlot) {
+		fcst, err := metrics.Forecast(metrics.Household, now, 48*time.Hour)
+		if err != nil {
+			site.log.DEBUG.Println("household forecast:", err)
+		}
+
+		site.householdFcst = fcst
+		site.householdFcstSlot = slot
+	}
+
+	return site.householdFcst
+}
+
 // sitePower returns
 //   - the net power exported by the site minus a residual margin
 //     (negative values mean grid: export, battery: charging
@@ -926,6 +1078,7 @@ func (site *Site) updateLoadpoints(rates api.Rates) float64 {
 		go func() {
 			power := lp.UpdateChargePowerAndCurrents()
 			site.prioritizer.UpdateChargePowerFlexibility(lp, rates)
+			site.addHistory("loadpoint", loadpointName(lp), power)
 
 			mu.Lock()
 			sum += power
@@ -954,6 +1107,9 @@ func (site *Site) update(lp updater) {
 		site.log.WARN.Println("feed-in:", err)
 	}
 
+	// plan competing loadpoints jointly
+	site.updateJointPlan()
+
 	// update loadpoints
 	totalChargePower := site.updateLoadpoints(consumption)
 
@@ -972,9 +1128,9 @@ func (site *Site) update(lp updater) {
 		flexiblePower = site.prioritizer.GetChargePowerFlexibility(lp)
 	}
 
-	rate, err := consumption.At(time.Now())
+	rate, err := consumption.At(site.clock.Now())
 	if consumption != nil && err != nil {
-		msg := fmt.Sprintf("no matching rate for: %s", time.Now().Format(time.RFC3339))
+		msg := fmt.Sprintf("no matching rate for: %s", site.clock.Now().Format(time.RFC3339))
 		if len(consumption) > 0 {
 			msg += fmt.Sprintf(", %d consumption rates (%s to %s)", len(consumption),
 				consumption[0].Start.Local().Format(time.RFC3339),
@@ -988,18 +1144,18 @@ func (site *Site) update(lp updater) {
 	// 根据当前电价和去电网功率设置更新电池模式
 	batteryGridChargeActive := site.batteryGridChargeActive(rate)
 	site.publish(keys.BatteryGridChargeActive, batteryGridChargeActive)
+	site.updateBatterySchedule()
 	site.updateBatteryMode(batteryGridChargeActive, rate)
 
 	if sitePower, batteryBuffered, batteryStart, err := site.sitePower(totalChargePower, flexiblePower); err == nil {
-		// 更新sitePower到调度器（用于定时存储）
-		if site.sitePowerScheduler != nil {
-			site.sitePowerScheduler.UpdatePower(site.GetTitle(), sitePower/1000.0) // 转换为kW
-		}
+		// 记录sitePower到能量历史
+		site.addHistory(sitepower.Group, sitepower.Group, sitePower)
 
 		// ignore negative pvPower values as that means it is not an energy source but consumption
 		homePower := site.gridPower + max(0, site.pvPower) + site.batteryPower - totalChargePower
 		homePower = max(homePower, 0)
 		site.publish(keys.HomePower, homePower)
+		site.addHistory("home", "home", homePower)
 
 		// add battery charging power to homePower to ignore all consumption which does not occur on loadpoints
 		// fix for: https://github.com/evcc-io/evcc/issues/11032
@@ -1010,6 +1166,10 @@ func (site *Site) update(lp updater) {
 		// 可用于充电桩(loadpoint)绿电
 		greenShareLoadpoints := site.greenShare(nonChargePower, nonChargePower+totalChargePower)
 
+		// energy sources for session cost breakdown
+		slot, gridPrice, feedInPrice := site.sourcePrices()
+		lp.setEnergySources(slot, site.batteryShare(nonChargePower, nonChargePower+totalChargePower), gridPrice, feedInPrice)
+
 		// TODO
 		// 调整充电桩(loadpoint)充电策略
 		lp.Update(
@@ -1029,6 +1189,8 @@ func (site *Site) update(lp updater) {
 	}
 
 	site.updateHouseholdConsumption(totalChargePower)
+	site.updateSolarProduction()
+	site.persistHistory()
 
 	site.stats.Update(site)
 }
@@ -1052,7 +1214,9 @@ func (site *Site) prepare() {
 	site.publish(keys.BufferStartSoc, site.bufferStartSoc)
 	site.publish(keys.BatteryMode, site.batteryMode)
 	site.publish(keys.BatteryDischargeControl, site.batteryDischargeControl)
+	site.publish(keys.BatteryOptimizer, site.batteryOptimizer)
 	site.publish(keys.ResidualPower, site.GetResidualPower())
+	site.publish(keys.Prioritization, site.prioritizer.GetStrategy())
 	site.publish(keys.SmartCostAvailable, site.isDynamicTariff(api.TariffUsagePlanner))
 	site.publish(keys.SmartFeedInPriorityAvailable, site.isDynamicTariff(api.TariffUsageFeedIn))
 
@@ -1137,9 +1301,12 @@ func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
 
 	site.update(<-loadpointChan) // start immediately
 
-	for tick := time.Tick(interval); ; {
+	ticker := site.clock.Ticker(interval)
+	defer ticker.Stop()
+
+	for {
 		select {
-		case <-tick:
+		case <-ticker.C:
 			site.update(<-loadpointChan)
 		case lp := <-site.lpUpdateChan:
 			site.update(lp)
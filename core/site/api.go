@@ -3,6 +3,7 @@ package site
 import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/prioritizer"
 )
 
 // API is the external site API
@@ -54,6 +55,11 @@ type API interface {
 	GetResidualPower() float64
 	SetResidualPower(float64) error
 
+	// GetPrioritization returns the strategy for distributing flexible charge power
+	GetPrioritization() prioritizer.Strategy
+	// SetPrioritization sets the strategy for distributing flexible charge power
+	SetPrioritization(prioritizer.Strategy) error
+
 	//
 	// tariffs and costs
 	//
@@ -68,6 +74,11 @@ type API interface {
 	GetBatteryDischargeControl() bool
 	SetBatteryDischargeControl(bool) error
 
+	// GetBatteryOptimizer returns if the battery mode is optimized
+	GetBatteryOptimizer() bool
+	// SetBatteryOptimizer sets if the battery mode is optimized
+	SetBatteryOptimizer(bool) error
+
 	//
 	// battery control external
 	//
@@ -2,12 +2,14 @@ package core
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/prioritizer"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util/config"
@@ -259,6 +261,28 @@ func (site *Site) SetBufferStartSoc(soc float64) error {
 	return nil
 }
 
+// GetPrioritization returns the strategy for distributing flexible charge power
+func (site *Site) GetPrioritization() prioritizer.Strategy {
+	return site.prioritizer.GetStrategy()
+}
+
+// SetPrioritization sets the strategy for distributing flexible charge power
+func (site *Site) SetPrioritization(strategy prioritizer.Strategy) error {
+	if !strategy.IsAStrategy() {
+		return fmt.Errorf("invalid prioritization: %d", strategy)
+	}
+
+	site.log.DEBUG.Println("set prioritization:", strategy)
+
+	if site.prioritizer.GetStrategy() != strategy {
+		site.prioritizer.SetStrategy(strategy)
+		settings.SetString(keys.Prioritization, strategy.String())
+		site.publish(keys.Prioritization, strategy)
+	}
+
+	return nil
+}
+
 // GetResidualPower returns the ResidualPower
 func (site *Site) GetResidualPower() float64 {
 	site.RLock()
@@ -312,6 +336,29 @@ func (site *Site) SetBatteryDischargeControl(val bool) error {
 	return nil
 }
 
+// GetBatteryOptimizer returns if the battery mode is optimized based on tariffs and forecasts
+func (site *Site) GetBatteryOptimizer() bool {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryOptimizer
+}
+
+// SetBatteryOptimizer sets if the battery mode is optimized based on tariffs and forecasts
+func (site *Site) SetBatteryOptimizer(val bool) error {
+	site.log.DEBUG.Println("set battery optimizer:", val)
+
+	site.Lock()
+	defer site.Unlock()
+
+	if site.batteryOptimizer != val {
+		site.batteryOptimizer = val
+		settings.SetBool(keys.BatteryOptimizer, val)
+		site.publish(keys.BatteryOptimizer, val)
+	}
+
+	return nil
+}
+
 func (site *Site) GetBatteryGridChargeLimit() *float64 {
 	site.RLock()
 	defer site.RUnlock()
@@ -81,6 +81,8 @@ func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rat
 		res = mapper(api.BatteryCharge)
 	case site.dischargeControlActive(rate):
 		res = mapper(api.BatteryHold)
+	case site.scheduledBatteryMode() != api.BatteryUnknown:
+		res = mapper(site.scheduledBatteryMode())
 	case batteryModeModified(batMode):
 		res = api.BatteryNormal
 	}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/battery"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+const batteryScheduleHorizon = 48 * time.Hour // battery schedule planning horizon
+
+// batteryParameters returns the combined capacity of all controllable batteries and their soc.
+// The soc range is limited by the batteries' soc limits and reserved up to the site's priority soc.
+func (site *Site) batteryParameters() (battery.Battery, bool) {
+	var capacity float64
+	var controllable bool
+
+	minSoc, maxSoc := site.GetPrioritySoc(), 100.0
+
+	for _, dev := range site.batteryMeters {
+		meter := dev.Instance()
+
+		if _, ok := meter.(api.BatteryController); ok {
+			controllable = true
+		}
+
+		if m, ok := meter.(api.BatteryCapacity); ok {
+			capacity += m.Capacity()
+		}
+
+		if m, ok := meter.(api.BatterySocLimiter); ok {
+			lower, upper := m.GetSocLimits()
+			minSoc, maxSoc = max(minSoc, lower), min(maxSoc, upper)
+		}
+	}
+
+	if !controllable || capacity <= 0 || minSoc >= maxSoc {
+		return battery.Battery{}, false
+	}
+
+	return battery.Battery{
+		Capacity:          capacity,
+		Soc:               site.batterySoc,
+		MinSoc:            minSoc,
+		MaxSoc:            maxSoc,
+		MaxChargePower:    capacity * 1e3 * site.BatteryCRate,
+		MaxDischargePower: capacity * 1e3 * site.BatteryCRate,
+		Efficiency:        site.BatteryEfficiency / 100,
+	}, true
+}
+
+// updateBatterySchedule re-optimizes the battery schedule once per schedule slot
+func (site *Site) updateBatterySchedule() {
+	if !site.GetBatteryOptimizer() || !site.batteryConfigured() {
+		if site.batterySchedule != nil {
+			site.batterySchedule = nil
+			site.publish(keys.BatterySchedule, site.batterySchedule)
+		}
+		return
+	}
+
+	now := site.clock.Now()
+	if slot, ok := site.batterySchedule.At(now); ok && !now.Truncate(battery.SlotDuration).After(slot.Start) {
+		return
+	}
+
+	bat, ok := site.batteryParameters()
+	if !ok {
+		site.log.DEBUG.Println("battery schedule: requires controllable battery with known capacity")
+		return
+	}
+
+	consumption := site.householdForecast()
+	if len(consumption) == 0 {
+		site.log.DEBUG.Println("battery schedule: consumption forecast not available")
+		return
+	}
+
+	rates := func(usage api.TariffUsage) api.Rates {
+		if t := site.GetTariff(usage); t != nil {
+			if rr, err := t.Rates(); err == nil {
+				return rr
+			}
+		}
+		return nil
+	}
+
+	fc := battery.Forecast{
+		Grid:        rates(api.TariffUsageGrid),
+		FeedIn:      rates(api.TariffUsageFeedIn),
+		Solar:       rates(api.TariffUsageSolar),
+		Consumption: consumption,
+	}
+
+	schedule, err := battery.Optimize(bat, fc, now, batteryScheduleHorizon)
+	if err != nil {
+		site.log.WARN.Println("battery schedule:", err)
+		return
+	}
+
+	if slot, ok := schedule.At(now); ok {
+		site.log.DEBUG.Printf("battery schedule: %d slots until %v, current mode %s, projected cost %.2f",
+			len(schedule), schedule[len(schedule)-1].End.Round(time.Second).Local(), slot.Mode, schedule.Cost())
+	}
+
+	site.batterySchedule = schedule
+	site.publish(keys.BatterySchedule, schedule)
+}
+
+// scheduledBatteryMode returns the battery mode of the current schedule slot or unknown
+func (site *Site) scheduledBatteryMode() api.BatteryMode {
+	if !site.GetBatteryOptimizer() {
+		return api.BatteryUnknown
+	}
+
+	if slot, ok := site.batterySchedule.At(site.clock.Now()); ok {
+		return slot.Mode
+	}
+
+	return api.BatteryUnknown
+}
@@ -115,3 +115,38 @@ func TestExternalBatteryModeChange(t *testing.T) {
 		assert.True(t, site.batteryModeExternalTimer.IsZero())
 	}
 }
+
+type scheduleBattery struct {
+	capacity       float64
+	minSoc, maxSoc float64
+}
+
+func (b *scheduleBattery) CurrentPower() (float64, error)       { return 0, nil }
+func (b *scheduleBattery) SetBatteryMode(api.BatteryMode) error { return nil }
+func (b *scheduleBattery) Capacity() float64                    { return b.capacity }
+func (b *scheduleBattery) GetSocLimits() (float64, float64)     { return b.minSoc, b.maxSoc }
+
+func TestBatteryParameters(t *testing.T) {
+	site := NewSite()
+	site.batterySoc = 50
+	site.prioritySoc = 10
+	site.BatteryEfficiency = 80
+	site.BatteryCRate = 1
+	site.batteryMeters = []config.Device[api.Meter]{
+		config.NewStaticDevice(config.Named{Name: "bat1"}, api.Meter(&scheduleBattery{capacity: 5, minSoc: 20, maxSoc: 95})),
+		config.NewStaticDevice(config.Named{Name: "bat2"}, api.Meter(&scheduleBattery{capacity: 5, minSoc: 5, maxSoc: 90})),
+	}
+
+	bat, ok := site.batteryParameters()
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, bat.Capacity)
+	assert.Equal(t, 20.0, bat.MinSoc)
+	assert.Equal(t, 90.0, bat.MaxSoc)
+	assert.Equal(t, 10e3, bat.MaxChargePower)
+	assert.Equal(t, 0.8, bat.Efficiency)
+
+	// battery is reserved up to priority soc
+	site.prioritySoc = 30
+	bat, _ = site.batteryParameters()
+	assert.Equal(t, 30.0, bat.MinSoc)
+}
@@ -7,12 +7,13 @@ import (
 )
 
 type circuitStruct struct {
-	Title      string   `json:"title,omitempty"`
-	Icon       string   `json:"icon,omitempty"`
-	Power      float64  `json:"power"`
-	Current    *float64 `json:"current,omitempty"`
-	MaxPower   float64  `json:"maxPower,omitempty"`
-	MaxCurrent float64  `json:"maxCurrent,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	Icon       string    `json:"icon,omitempty"`
+	Power      float64   `json:"power"`
+	Current    *float64  `json:"current,omitempty"`
+	Currents   []float64 `json:"currents,omitempty"`
+	MaxPower   float64   `json:"maxPower,omitempty"`
+	MaxCurrent float64   `json:"maxCurrent,omitempty"`
 }
 
 // publishCircuits returns a list of circuit titles
@@ -34,6 +35,8 @@ func (site *Site) publishCircuits() {
 
 		if instance.GetMaxCurrent() > 0 {
 			data.Current = lo.EmptyableToPtr(instance.GetMaxPhaseCurrent())
+			currents := instance.GetPhaseCurrents()
+			data.Currents = currents[:]
 		}
 
 		res[c.Config().Name] = data
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// addHistory adds a meter's power sample to the energy history
+func (site *Site) addHistory(group, name string, power float64) {
+	if site.history != nil {
+		site.history.Add(group, name, power)
+	}
+}
+
+// persistHistory stores the energy history once per slot
+func (site *Site) persistHistory() {
+	if site.history != nil {
+		site.history.Persist()
+	}
+}
+
+// loadpointName returns the loadpoint's config name as stable key since titles are editable
+func loadpointName(lp *Loadpoint) string {
+	if name := config.InstanceName(config.Loadpoints(), loadpoint.API(lp)); name != "" {
+		return name
+	}
+	return lp.GetTitle()
+}
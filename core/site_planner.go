@@ -0,0 +1,41 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/planner"
+)
+
+// updateJointPlan plans all loadpoints with active charging plans together,
+// taking shared tariff, circuit limits and priorities into account
+func (site *Site) updateJointPlan() {
+	if site.jointPlanner == nil {
+		return
+	}
+
+	var requests []planner.Request
+	for id, lp := range site.loadpoints {
+		if req, ok := lp.jointPlanRequest(id); ok {
+			requests = append(requests, req)
+		}
+	}
+
+	// single plans cannot compete for resources and are handled by the loadpoint's planner
+	if len(requests) < 2 {
+		for _, lp := range site.loadpoints {
+			lp.setJointPlan(nil)
+		}
+		return
+	}
+
+	plans := make(map[int]*planner.Result, len(requests))
+	for _, res := range site.jointPlanner.Plan(requests) {
+		site.log.DEBUG.Printf("joint plan: %s charge %v until %v (feasible: %t)", res.Title,
+			planner.Duration(res.Plan).Round(time.Second), res.TargetTime.Round(time.Second).Local(), res.Feasible())
+		plans[res.Id] = &res
+	}
+
+	for id, lp := range site.loadpoints {
+		lp.setJointPlan(plans[id])
+	}
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/metrics"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/sim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type simCharger struct {
+	sim *sim.Simulation
+}
+
+func (c *simCharger) Status() (api.ChargeStatus, error) { return c.sim.Status(), nil }
+func (c *simCharger) Enabled() (bool, error)            { return c.sim.Enabled(), nil }
+func (c *simCharger) Enable(enable bool) error          { c.sim.Enable(enable); return nil }
+func (c *simCharger) MaxCurrent(current int64) error    { c.sim.SetCurrent(float64(current)); return nil }
+func (c *simCharger) CurrentPower() (float64, error)    { power, _ := c.sim.Charge(); return power, nil }
+
+type simMeter struct {
+	power func() float64
+}
+
+func (m *simMeter) CurrentPower() (float64, error) { return m.power(), nil }
+
+func TestSiteSimulation(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	metrics.Init()
+
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 6, 1, 5, 0, 0, 0, time.Local))
+
+	s, err := sim.New(util.NewLogger("sim"), clock, sim.Config{
+		Vehicle: sim.Vehicle{Capacity: 50, Soc: 20},
+	})
+	require.NoError(t, err)
+
+	grid := &simMeter{func() float64 { p, _, _ := s.Grid(); return p }}
+	pv := &simMeter{func() float64 { p, _ := s.PV(); return p }}
+
+	require.NoError(t, config.Meters().Add(config.NewStaticDevice(config.Named{Name: "sim-grid"}, api.Meter(grid))))
+	require.NoError(t, config.Meters().Add(config.NewStaticDevice(config.Named{Name: "sim-pv"}, api.Meter(pv))))
+	require.NoError(t, config.Chargers().Add(config.NewStaticDevice(config.Named{Name: "sim-charger"}, api.Charger(&simCharger{s}))))
+	t.Cleanup(func() {
+		_ = config.Meters().Delete("sim-grid")
+		_ = config.Meters().Delete("sim-pv")
+		_ = config.Chargers().Delete("sim-charger")
+	})
+
+	lp, err := NewLoadpointFromConfig(util.NewLogger("lp"), nil, map[string]any{
+		"charger": "sim-charger",
+		"mode":    api.ModePV,
+	})
+	require.NoError(t, err)
+
+	site, err := NewSiteFromConfig(map[string]any{
+		"meters": map[string]any{"grid": "sim-grid", "pv": []string{"sim-pv"}},
+	})
+	require.NoError(t, err)
+
+	site.SetClock(clock)
+	require.NoError(t, site.Boot(util.NewLogger("site"), []*Loadpoint{lp}, new(tariff.Tariffs)))
+
+	uiChan := make(chan util.Param)
+	pushChan := make(chan push.Event)
+	go func() {
+		for {
+			select {
+			case <-uiChan:
+			case <-pushChan:
+			}
+		}
+	}()
+
+	site.Health = NewHealth(time.Minute)
+	site.Prepare(uiChan, pushChan)
+
+	var started time.Time
+
+	// run from dawn to morning on simulated time
+	for clock.Now().Hour() < 10 {
+		clock.Add(30 * time.Second)
+		site.update(lp)
+
+		if power, _ := s.Charge(); power > 0 && started.IsZero() {
+			started = clock.Now()
+		}
+	}
+
+	// pv mode starts charging after sunrise once the enable delay has elapsed on simulated time
+	require.False(t, started.IsZero(), "charging not started")
+	assert.True(t, started.After(time.Date(2025, 6, 1, 6, 0, 0, 0, time.Local)), "charging started at %v", started)
+	assert.Equal(t, api.StatusC, s.Status())
+	assert.Greater(t, s.Soc(), 20.0)
+	assert.Equal(t, clock.Now(), lp.clock.Now())
+}
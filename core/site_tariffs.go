@@ -49,6 +49,43 @@ func (site *Site) greenShare(powerFrom float64, powerTo float64) float64 {
 	return share
 }
 
+// batteryShare returns the part of the green share between powerFrom and powerTo supplied by the battery.
+// Solar power is assumed to be consumed before battery power.
+func (site *Site) batteryShare(powerFrom float64, powerTo float64) float64 {
+	solarAvailable := math.Max(0, math.Max(0, site.pvPower)-powerFrom)
+
+	power := powerTo - powerFrom
+	solarShare := math.Min(solarAvailable, power) / power
+
+	if math.IsNaN(solarShare) {
+		if solarAvailable > 0 {
+			solarShare = 1
+		} else {
+			solarShare = 0
+		}
+	}
+
+	return math.Max(0, site.greenShare(powerFrom, powerTo)-solarShare)
+}
+
+// sourcePrices returns the current grid tariff slot and the prices applied to grid and self-produced energy.
+// Self-produced energy is valued at the feed-in price consistent with effectivePrice.
+func (site *Site) sourcePrices() (time.Time, *float64, *float64) {
+	now := site.clock.Now()
+
+	grid, err := tariff.At(site.GetTariff(api.TariffUsageGrid), now)
+	if err != nil {
+		return now.Truncate(time.Hour), nil, nil
+	}
+
+	var feedin float64
+	if r, err := tariff.At(site.GetTariff(api.TariffUsageFeedIn), now); err == nil {
+		feedin = r.Value
+	}
+
+	return grid.Start, &grid.Value, &feedin
+}
+
 // effectivePrice calculates the real energy price based on self-produced and grid-imported energy.
 func (site *Site) effectivePrice(greenShare float64) *float64 {
 	if grid, err := tariff.Now(site.GetTariff(api.TariffUsageGrid)); err == nil {
@@ -109,16 +146,18 @@ func (site *Site) publishTariffs(greenShareHome float64, greenShareLoadpoints fl
 	}
 	// 构建未来电价预测结构体
 	fc := struct {
-		Co2     api.Rates     `json:"co2,omitempty"`
-		FeedIn  api.Rates     `json:"feedin,omitempty"`
-		Grid    api.Rates     `json:"grid,omitempty"`
-		Planner api.Rates     `json:"planner,omitempty"`
-		Solar   *solarDetails `json:"solar,omitempty"`
+		Co2         api.Rates     `json:"co2,omitempty"`
+		FeedIn      api.Rates     `json:"feedin,omitempty"`
+		Grid        api.Rates     `json:"grid,omitempty"`
+		Planner     api.Rates     `json:"planner,omitempty"`
+		Solar       *solarDetails `json:"solar,omitempty"`
+		Consumption api.Rates     `json:"consumption,omitempty"`
 	}{
-		Co2:     tariff.Forecast(site.GetTariff(api.TariffUsageCo2)),
-		FeedIn:  tariff.Forecast(site.GetTariff(api.TariffUsageFeedIn)),
-		Planner: tariff.Forecast(site.GetTariff(api.TariffUsagePlanner)),
-		Grid:    tariff.Forecast(site.GetTariff(api.TariffUsageGrid)),
+		Co2:         tariff.Forecast(site.GetTariff(api.TariffUsageCo2)),
+		FeedIn:      tariff.Forecast(site.GetTariff(api.TariffUsageFeedIn)),
+		Planner:     tariff.Forecast(site.GetTariff(api.TariffUsagePlanner)),
+		Grid:        tariff.Forecast(site.GetTariff(api.TariffUsageGrid)),
+		Consumption: site.householdForecast(),
 	}
 
 	// calculate adjusted solar forecast
@@ -140,7 +179,7 @@ func (site *Site) solarDetails(solar api.Rates) solarDetails {
 	eod := bod.AddDate(0, 0, 1)
 	eot := eod.AddDate(0, 0, 1)
 
-	remainingToday := solarEnergy(solar, time.Now(), eod)
+	remainingToday := solarEnergy(solar, site.clock.Now(), eod)
 	tomorrow := solarEnergy(solar, eod, eot)
 	dayAfterTomorrow := solarEnergy(solar, eot, eot.AddDate(0, 0, 1))
 
@@ -158,9 +197,9 @@ func (site *Site) solarDetails(solar api.Rates) solarDetails {
 	}
 
 	// accumulate forecasted energy since last update
-	energy := solarEnergy(solar, site.fcstEnergy.updated, time.Now()) / 1e3
+	energy := solarEnergy(solar, site.fcstEnergy.updated, site.clock.Now()) / 1e3
 	site.log.DEBUG.Printf("solar forecast: accumulated %.3fWh from %v to %v",
-		energy, site.fcstEnergy.updated.Truncate(time.Second), time.Now().Truncate(time.Second),
+		energy, site.fcstEnergy.updated.Truncate(time.Second), site.clock.Now().Truncate(time.Second),
 	)
 
 	site.fcstEnergy.AddEnergy(energy)
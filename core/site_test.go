@@ -8,10 +8,12 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/metrics"
 	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestGreenShare(t *testing.T) {
@@ -127,6 +129,24 @@ func TestGreenShare(t *testing.T) {
 	}
 }
 
+func TestBatteryShare(t *testing.T) {
+	s := &Site{
+		pvPower:      1000,
+		batteryPower: 1000,
+	}
+
+	// home consumes 500W solar, loadpoint uses remaining 500W solar, 1000W battery and 500W grid
+	assert.Equal(t, 0.75, s.greenShare(500, 2500))
+	assert.Equal(t, 0.5, s.batteryShare(500, 2500))
+
+	// home consumes all solar
+	assert.Equal(t, 1.0, s.batteryShare(1000, 1500))
+
+	// no battery discharge
+	s.batteryPower = -500
+	assert.Equal(t, 0.0, s.batteryShare(0, 1000))
+}
+
 func TestRequiredBatteryMode(t *testing.T) {
 	tc := []struct {
 		gridChargeActive bool
@@ -190,3 +210,45 @@ func TestUpdateHouseholdConsumption(t *testing.T) {
 	s.updateHouseholdConsumption(1e3)
 	require.Equal(t, 0.0, s.householdEnergy.AccumulatedEnergy()) // accumulator reset after 15 minutes
 }
+
+func TestUpdateSolarProduction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 6, 1, 12, 5, 0, 0, time.Local))
+
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, metrics.Init())
+
+	solar := api.NewMockTariff(ctrl)
+	solar.EXPECT().Type().Return(api.TariffTypeSolar).AnyTimes()
+	solar.EXPECT().Rates().Return(api.Rates{
+		{Start: clock.Now().Truncate(time.Hour), End: clock.Now().Truncate(time.Hour).Add(time.Hour), Value: 2000},
+		{Start: clock.Now().Truncate(time.Hour).Add(time.Hour), End: clock.Now().Truncate(time.Hour).Add(2 * time.Hour), Value: 2000},
+	}, nil).AnyTimes()
+
+	s := &Site{
+		log:             util.NewLogger("foo"),
+		pvMeters:        []config.Device[api.Meter]{config.NewStaticDevice[api.Meter](config.Named{Name: "pv"}, nil)},
+		pvPower:         1e3,
+		tariffs:         &tariff.Tariffs{Solar: solar},
+		solarSlotEnergy: &meterEnergy{clock: clock},
+	}
+
+	// partial slot is not recorded
+	s.updateSolarProduction()
+	for range 3 {
+		clock.Add(5 * time.Minute)
+		s.updateSolarProduction()
+	}
+
+	// full slot with forecast
+	for range 3 {
+		clock.Add(5 * time.Minute)
+		s.updateSolarProduction()
+	}
+
+	res, err := metrics.GetAccuracy(metrics.Solar, clock.Now().Add(-time.Hour), clock.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Count)
+	assert.InDelta(t, 250, res.Bias, 1) // 500Wh forecasted, 250Wh produced
+}
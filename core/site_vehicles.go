@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/health"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/core/vehicle"
@@ -78,9 +79,11 @@ func (site *Site) updateVehicles(op config.Operation, dev config.Device[api.Vehi
 	switch op {
 	case config.OpAdd:
 		site.coordinator.Add(vehicle)
+		health.Track(health.Vehicle, dev.Config().Name).SetIdle(true)
 
 	case config.OpDelete:
 		site.coordinator.Delete(vehicle)
+		health.Remove(health.Vehicle, dev.Config().Name)
 	}
 
 	// TODO remove vehicle from mqtt
@@ -2,29 +2,29 @@ package sitepower
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"time"
-
-	"github.com/gorilla/mux"
 )
 
 // API 提供sitePower数据的HTTP API接口
 type API struct {
-	db *DB
+	title func() string
 }
 
-// NewAPI 创建新的API实例
-func NewAPI(db *DB) *API {
-	return &API{db: db}
+// NewAPI 创建新的API实例，未指定站点时使用站点标题
+func NewAPI(title func() string) *API {
+	return &API{title: title}
 }
 
-// RegisterRoutes 注册API路由
-func (api *API) RegisterRoutes(router *mux.Router) {
-	router.HandleFunc("/sitepower/records", api.getRecords).Methods("GET")
-	router.HandleFunc("/sitepower/latest", api.getLatest).Methods("GET")
-	router.HandleFunc("/sitepower/cleanup", api.cleanup).Methods("POST")
+// siteTitle 返回请求的站点标题
+func (api *API) siteTitle(r *http.Request) string {
+	if title := r.URL.Query().Get("site"); title != "" {
+		return title
+	}
+	return api.title()
 }
 
 // RecordsResponse API响应结构
@@ -33,17 +33,12 @@ type RecordsResponse struct {
 	Count   int               `json:"count"`
 }
 
-// getRecords 获取指定时间范围内的记录
+// GetRecords 获取指定时间范围内每15分钟时段的平均功率
 // GET /api/sitepower/records?site=<siteTitle>&from=<timestamp>&to=<timestamp>&limit=<limit>
-func (api *API) getRecords(w http.ResponseWriter, r *http.Request) {
+func (api *API) GetRecords(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	// 解析查询参数
-	siteTitle := r.URL.Query().Get("site")
-	if siteTitle == "" {
-		http.Error(w, "site parameter is required", http.StatusBadRequest)
-		return
-	}
+	siteTitle := api.siteTitle(r)
 
 	// 解析时间范围
 	fromStr := r.URL.Query().Get("from")
@@ -80,7 +75,7 @@ func (api *API) getRecords(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取记录
-	records, err := api.db.GetRecords(siteTitle, from, to)
+	records, err := GetRecords(siteTitle, from, to)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("failed to get records: %v", err), http.StatusInternalServerError)
 		return
@@ -104,18 +99,18 @@ func (api *API) getRecords(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// getLatest 获取最新记录
+// GetLatest 获取最新记录
 // GET /api/sitepower/latest?site=<siteTitle>
-func (api *API) getLatest(w http.ResponseWriter, r *http.Request) {
+func (api *API) GetLatest(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	siteTitle := r.URL.Query().Get("site")
-	if siteTitle == "" {
-		http.Error(w, "site parameter is required", http.StatusBadRequest)
+	siteTitle := api.siteTitle(r)
+
+	record, err := GetLatestRecord(siteTitle)
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
-
-	record, err := api.db.GetLatestRecord(siteTitle)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("failed to get latest record: %v", err), http.StatusInternalServerError)
 		return
@@ -137,10 +132,10 @@ type CleanupResponse struct {
 	Message      string `json:"message"`
 }
 
-// cleanup 清理旧记录
+// Cleanup 清理旧记录
 // POST /api/sitepower/cleanup
 // Body: {"daysToKeep": 30}
-func (api *API) cleanup(w http.ResponseWriter, r *http.Request) {
+func (api *API) Cleanup(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	var req CleanupRequest
@@ -154,17 +149,18 @@ func (api *API) cleanup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	before := time.Now().AddDate(0, 0, -req.DaysToKeep)
-	err := api.db.DeleteOldRecords(before)
+	deleted, err := DeleteOldRecords(before)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("failed to cleanup records: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	response := CleanupResponse{
-		Message: fmt.Sprintf("Successfully cleaned up records older than %d days", req.DaysToKeep),
+		DeletedCount: deleted,
+		Message:      fmt.Sprintf("Successfully cleaned up records older than %d days", req.DaysToKeep),
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, "failed to encode response", http.StatusInternalServerError)
 	}
-}
\ No newline at end of file
+}
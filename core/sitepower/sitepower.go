@@ -1,82 +1,132 @@
 package sitepower
 
 import (
+	"errors"
 	"time"
 
-	"github.com/evcc-io/evcc/util"
-	"gorm.io/gorm"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/history"
 )
 
-// SitePowerRecord 存储站点功率数据的记录
+// Group 是站点功率在能量历史中的分组
+const Group = "site"
+
+// ErrNotFound 表示没有可用的记录
+var ErrNotFound = errors.New("no site power records")
+
+// SitePowerRecord 是一个历史时段的平均站点功率
 type SitePowerRecord struct {
-	ID        uint      `json:"id" gorm:"primarykey"`
-	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
-	SiteTitle string    `json:"siteTitle" gorm:"column:site_title"`
-	PowerKW   float64   `json:"powerKW" gorm:"column:power_kw"` // 功率，单位kW
+	CreatedAt time.Time `json:"createdAt"`
+	SiteTitle string    `json:"siteTitle"`
+	PowerKW   float64   `json:"powerKW"` // 功率，单位kW
 }
 
 // SitePowerRecords 是记录列表
 type SitePowerRecords []SitePowerRecord
 
-// DB 是SQLite数据库存储服务
-type DB struct {
-	log *util.Logger
-	db  *gorm.DB
+// legacyRecord 是已被能量历史取代的旧表结构
+type legacyRecord struct {
+	ID        uint      `gorm:"primarykey"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	SiteTitle string    `gorm:"column:site_title"`
+	PowerKW   float64   `gorm:"column:power_kw"`
 }
 
-// NewStore 创建一个sitePower存储实例
-func NewStore(db *gorm.DB) (*DB, error) {
-	err := db.AutoMigrate(new(SitePowerRecord))
+// TableName 实现gorm的tabler接口
+func (legacyRecord) TableName() string {
+	return "site_power_records"
+}
 
-	sitePowerDB := &DB{
-		log: util.NewLogger("sitepower"),
-		db:  db,
+// Init 将旧的site_power_records表迁移到能量历史，需要先初始化能量历史。已有的历史数据优先。
+func Init() error {
+	if !db.Instance.Migrator().HasTable(new(legacyRecord)) {
+		return nil
 	}
 
-	return sitePowerDB, err
-}
-
-// Save 保存sitePower记录到数据库
-func (s *DB) Save(siteTitle string, powerKW float64) error {
-	record := SitePowerRecord{
-		CreatedAt: time.Now(),
-		SiteTitle: siteTitle,
-		PowerKW:   powerKW,
+	var first history.Entry
+	if err := db.Instance.Where("grp = ? AND resolution = ?", Group, history.Slot).Order("ts").Limit(1).Find(&first).Error; err != nil {
+		return err
 	}
 
-	if err := s.db.Create(&record).Error; err != nil {
-		s.log.ERROR.Printf("save sitePower record failed: %v", err)
+	var rows []legacyRecord
+	if err := db.Instance.Order("created_at").Find(&rows).Error; err != nil {
 		return err
 	}
 
-	s.log.DEBUG.Printf("saved sitePower record: site=%s, power=%.3fkW", siteTitle, powerKW)
-	return nil
+	// 按时段求平均功率
+	type slot struct {
+		ts    time.Time
+		sum   float64
+		count int
+	}
+
+	var slots []slot
+	for _, r := range rows {
+		ts := history.Slot.Start(r.CreatedAt.Local())
+		if !first.Timestamp.IsZero() && !ts.Before(first.Timestamp) {
+			continue
+		}
+
+		if n := len(slots); n == 0 || !slots[n-1].ts.Equal(ts) {
+			slots = append(slots, slot{ts: ts})
+		}
+
+		slots[len(slots)-1].sum += r.PowerKW
+		slots[len(slots)-1].count++
+	}
+
+	for _, s := range slots {
+		imp, exp := energy(s.sum / float64(s.count))
+		if err := history.Persist(Group, Group, s.ts, imp, exp); err != nil {
+			return err
+		}
+	}
+
+	return db.Instance.Migrator().DropTable(new(legacyRecord))
 }
 
-// GetRecords 获取指定时间范围内的记录
-func (s *DB) GetRecords(siteTitle string, from, to time.Time) (SitePowerRecords, error) {
-	var records SitePowerRecords
-	tx := s.db.Where("site_title = ? AND created_at BETWEEN ? AND ?", siteTitle, from, to).Order("created_at").Find(&records)
-	return records, tx.Error
+// energy 将平均功率(kW)转换为一个时段的导入和导出能量(Wh)
+func energy(powerKW float64) (float64, float64) {
+	e := powerKW * 1e3 * history.SlotDuration.Hours()
+	return max(e, 0), max(-e, 0)
 }
 
-// GetLatestRecord 获取最新的记录
-func (s *DB) GetLatestRecord(siteTitle string) (*SitePowerRecord, error) {
-	var record SitePowerRecord
-	tx := s.db.Where("site_title = ?", siteTitle).Order("created_at DESC").First(&record)
-	if tx.Error != nil {
-		return nil, tx.Error
+// GetRecords 获取指定时间范围内每个时段的平均功率
+func GetRecords(siteTitle string, from, to time.Time) (SitePowerRecords, error) {
+	rows, err := history.Sum(Group, history.Slot, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(SitePowerRecords, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, SitePowerRecord{
+			CreatedAt: r.Timestamp,
+			SiteTitle: siteTitle,
+			PowerKW:   (r.Import - r.Export) / history.SlotDuration.Hours() / 1e3,
+		})
 	}
-	return &record, nil
+
+	return res, nil
 }
 
-// DeleteOldRecords 删除指定时间之前的旧记录
-func (s *DB) DeleteOldRecords(before time.Time) error {
-	tx := s.db.Where("created_at < ?", before).Delete(&SitePowerRecord{})
-	if tx.Error != nil {
-		s.log.ERROR.Printf("delete old sitePower records failed: %v", tx.Error)
-		return tx.Error
+// GetLatestRecord 获取最近一天内最新的记录
+func GetLatestRecord(siteTitle string) (*SitePowerRecord, error) {
+	now := time.Now()
+
+	records, err := GetRecords(siteTitle, now.Add(-24*time.Hour), now)
+	if err != nil {
+		return nil, err
 	}
-	s.log.INFO.Printf("deleted %d old sitePower records before %s", tx.RowsAffected, before.Format(time.RFC3339))
-	return nil
-}
\ No newline at end of file
+
+	if len(records) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return &records[len(records)-1], nil
+}
+
+// DeleteOldRecords 删除指定时间之前的旧记录
+func DeleteOldRecords(before time.Time) (int64, error) {
+	return history.Delete(Group, before)
+}
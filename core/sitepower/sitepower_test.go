@@ -4,124 +4,89 @@ import (
 	"testing"
 	"time"
 
-	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/history"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestSitePowerStorage(t *testing.T) {
+func setup(t *testing.T) {
 	// 创建内存数据库
-	db, err := serverdb.New("sqlite", ":memory:")
-	require.NoError(t, err)
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, history.Init())
+}
 
-	// 创建存储实例
-	store, err := NewStore(db)
-	require.NoError(t, err)
+func TestSitePowerRecords(t *testing.T) {
+	setup(t)
 
-	// 测试保存记录
 	siteTitle := "Test Site"
-	powerKW := 5.5
-	err = store.Save(siteTitle, powerKW)
-	require.NoError(t, err)
+	ts := history.Slot.Start(time.Now().Add(-time.Hour))
 
-	// 测试获取最新记录
-	record, err := store.GetLatestRecord(siteTitle)
-	require.NoError(t, err)
-	assert.Equal(t, siteTitle, record.SiteTitle)
-	assert.Equal(t, powerKW, record.PowerKW)
-	assert.WithinDuration(t, time.Now(), record.CreatedAt, time.Second)
-
-	// 测试获取时间范围内的记录
-	from := time.Now().Add(-time.Hour)
-	to := time.Now().Add(time.Hour)
-	records, err := store.GetRecords(siteTitle, from, to)
-	require.NoError(t, err)
-	assert.Len(t, records, 1)
-	assert.Equal(t, siteTitle, records[0].SiteTitle)
-	assert.Equal(t, powerKW, records[0].PowerKW)
-}
+	// 导入1.5kWh和导出0.5kWh分别对应6kW和-2kW的平均功率
+	require.NoError(t, history.Persist(Group, Group, ts, 1500, 0))
+	require.NoError(t, history.Persist(Group, Group, ts.Add(history.SlotDuration), 0, 500))
 
-func TestScheduler(t *testing.T) {
-	// 创建内存数据库
-	db, err := serverdb.New("sqlite", ":memory:")
+	records, err := GetRecords(siteTitle, ts, time.Now())
 	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, siteTitle, records[0].SiteTitle)
+	assert.True(t, ts.Equal(records[0].CreatedAt))
+	assert.InDelta(t, 6.0, records[0].PowerKW, 1e-9)
+	assert.InDelta(t, -2.0, records[1].PowerKW, 1e-9)
 
-	// 创建存储实例
-	store, err := NewStore(db)
+	// 测试获取最新记录
+	record, err := GetLatestRecord(siteTitle)
 	require.NoError(t, err)
+	assert.InDelta(t, -2.0, record.PowerKW, 1e-9)
+}
 
-	// 创建调度器（使用较短的间隔进行测试）
-	scheduler := NewScheduler(store, 100*time.Millisecond)
-
-	// 测试调度器状态
-	status := scheduler.GetStatus()
-	assert.False(t, status["running"].(bool))
+func TestLatestRecordNotFound(t *testing.T) {
+	setup(t)
 
-	// 启动调度器
-	scheduler.Start()
-	defer scheduler.Stop()
+	_, err := GetLatestRecord("Test Site")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
 
-	// 验证调度器已启动
-	status = scheduler.GetStatus()
-	assert.True(t, status["running"].(bool))
+func TestDeleteOldRecords(t *testing.T) {
+	setup(t)
 
-	// 更新功率数据
-	siteTitle := "Test Site"
-	powerKW := 3.2
-	scheduler.UpdatePower(siteTitle, powerKW)
+	ts := history.Slot.Start(time.Now().Add(-2 * time.Hour))
+	require.NoError(t, history.Persist(Group, Group, ts, 1000, 0))
+	require.NoError(t, history.Persist(Group, Group, ts.Add(time.Hour), 2000, 0))
 
-	// 等待调度器执行保存
-	time.Sleep(150 * time.Millisecond)
+	// 其他分组不受影响
+	require.NoError(t, history.Persist("pv", "pv", ts, 1000, 0))
 
-	// 验证数据已保存
-	record, err := store.GetLatestRecord(siteTitle)
+	_, err := DeleteOldRecords(ts.Add(time.Hour))
 	require.NoError(t, err)
-	assert.Equal(t, siteTitle, record.SiteTitle)
-	assert.Equal(t, powerKW, record.PowerKW)
-
-	// 测试停止调度器
-	scheduler.Stop()
-	status = scheduler.GetStatus()
-	assert.False(t, status["running"].(bool))
-}
 
-func TestDeleteOldRecords(t *testing.T) {
-	// 创建内存数据库
-	db, err := serverdb.New("sqlite", ":memory:")
+	records, err := GetRecords("", ts, time.Now())
 	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.InDelta(t, 8.0, records[0].PowerKW, 1e-9)
 
-	// 创建存储实例
-	store, err := NewStore(db)
+	pv, err := history.Sum("pv", history.Slot, ts, time.Now())
 	require.NoError(t, err)
+	assert.Len(t, pv, 1)
+}
 
-	// 创建一些测试记录
-	siteTitle := "Test Site"
-	
-	// 保存旧记录
-	oldRecord := SitePowerRecord{
-		CreatedAt: time.Now().Add(-2 * time.Hour),
-		SiteTitle: siteTitle,
-		PowerKW:   1.0,
-	}
-	err = db.Create(&oldRecord).Error
-	require.NoError(t, err)
+func TestMigrateLegacyRecords(t *testing.T) {
+	setup(t)
 
-	// 保存新记录
-	err = store.Save(siteTitle, 2.0)
-	require.NoError(t, err)
+	ts := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
 
-	// 验证有2条记录
-	records, err := store.GetRecords(siteTitle, time.Now().Add(-3*time.Hour), time.Now().Add(time.Hour))
-	require.NoError(t, err)
-	assert.Len(t, records, 2)
+	// 旧表中的功率记录
+	require.NoError(t, db.Instance.AutoMigrate(new(legacyRecord)))
+	for i, p := range []float64{2, 4, -4} {
+		require.NoError(t, db.Instance.Create(&legacyRecord{CreatedAt: ts.Add(time.Duration(i) * 10 * time.Minute), SiteTitle: "Test Site", PowerKW: p}).Error)
+	}
 
-	// 删除1小时前的记录
-	err = store.DeleteOldRecords(time.Now().Add(-time.Hour))
-	require.NoError(t, err)
+	require.NoError(t, Init())
+	assert.False(t, db.Instance.Migrator().HasTable(new(legacyRecord)))
 
-	// 验证只剩1条记录
-	records, err = store.GetRecords(siteTitle, time.Now().Add(-3*time.Hour), time.Now().Add(time.Hour))
+	records, err := GetRecords("Test Site", ts, ts.Add(time.Hour))
 	require.NoError(t, err)
-	assert.Len(t, records, 1)
-	assert.Equal(t, 2.0, records[0].PowerKW)
-}
\ No newline at end of file
+	require.Len(t, records, 2)
+	assert.InDelta(t, 3.0, records[0].PowerKW, 1e-9)
+	assert.InDelta(t, -4.0, records[1].PowerKW, 1e-9)
+}
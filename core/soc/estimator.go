@@ -5,11 +5,12 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/health"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/util"
 )
 
-const ChargeEfficiency = 0.9 // assume charge 90% efficiency
+const ChargeEfficiency = 0.9 // assume charge 90% efficiency unless learned
 
 // Estimator provides vehicle soc and charge duration
 // Vehicle Soc can be estimated to provide more granularity
@@ -30,6 +31,11 @@ type Estimator struct {
 	minChargePower    float64 // Lowest charge power (just before vehicle stops charging at 100%)
 	maxChargePower    float64 // Highest charge power the battery can handle on any charger
 	maxChargeSoc      float64 // SoC at/after which maxChargePower is degressive
+
+	model   Model   // learned vehicle charge model
+	session session // observations of the current session for learning the model
+
+	health *health.Tracker // vehicle api health
 }
 
 // NewEstimator creates new estimator
@@ -51,16 +57,62 @@ func (s *Estimator) Reset() {
 	s.prevSoc = 0
 	s.prevChargedEnergy = 0
 	s.initialSoc = 0
-	s.capacity = s.vehicle.Capacity() * 1e3           // cache to simplify debugging
-	s.virtualCapacity = s.capacity / ChargeEfficiency // initial capacity taking efficiency into account
+	s.capacity = s.vehicle.Capacity() * 1e3         // cache to simplify debugging
+	s.virtualCapacity = s.capacity / s.efficiency() // initial capacity taking efficiency into account
 	s.energyPerSocStep = s.virtualCapacity / 100
 	s.minChargePower = 1000  // default 1 kW
 	s.maxChargePower = 50000 // default 50 kW
 	s.maxChargeSoc = 50      // default 50%
+	s.session = session{}
+}
+
+// SetModel sets the learned vehicle charge model and resets the estimation
+func (s *Estimator) SetModel(model Model) {
+	s.model = model
+	s.Reset()
+}
+
+// SetHealth sets the tracker observing vehicle api requests
+func (s *Estimator) SetHealth(t *health.Tracker) {
+	s.health = t
+}
+
+// Sample records the charge power at the current soc for learning the charge curve
+func (s *Estimator) Sample(chargePower float64) {
+	if chargePower > 0 && s.vehicleSoc > 0 {
+		i := curveIndex(s.vehicleSoc)
+		s.session.power[i] = max(s.session.power[i], chargePower)
+	}
+}
+
+// Learn updates the vehicle charge model from the session's observations and starts a new session.
+// Returns true if the model has changed and should be persisted.
+func (s *Estimator) Learn() (Model, bool) {
+	model, updated := s.session.learn(s.model, s.capacity)
+	s.session = session{}
+
+	if updated {
+		s.model = model
+		s.log.DEBUG.Printf("charge model updated: efficiency: %.1f%%, sessions: %d", 100*model.Efficiency, model.Sessions)
+	}
+
+	return model, updated
+}
+
+// efficiency returns the learned or default charge efficiency
+func (s *Estimator) efficiency() float64 {
+	if s.model.Efficiency > 0 {
+		return s.model.Efficiency
+	}
+	return ChargeEfficiency
 }
 
 // RemainingChargeDuration returns the estimated remaining duration
 func (s *Estimator) RemainingChargeDuration(targetSoc int, chargePower float64) time.Duration {
+	if s.model.hasCurve() {
+		return s.learnedChargeDuration(targetSoc, chargePower)
+	}
+
 	const minChargeSoc = 100
 
 	dy := s.minChargePower - s.maxChargePower
@@ -91,6 +143,38 @@ func (s *Estimator) RemainingChargeDuration(targetSoc int, chargePower float64)
 	return max(0, time.Duration(float64(time.Hour)*(t1+t2))).Round(time.Second)
 }
 
+// learnedChargeDuration returns the remaining duration integrating the learned charge curve.
+// Steps outside the learned range fall back to the degressive default curve.
+func (s *Estimator) learnedChargeDuration(targetSoc int, chargePower float64) time.Duration {
+	if chargePower <= 0 {
+		return 0
+	}
+
+	// default curve declining linearly from maxChargePower at maxChargeSoc to minChargePower at 100%
+	m := (s.minChargePower - s.maxChargePower) / (100 - s.maxChargeSoc)
+	b := s.minChargePower - m*100
+
+	var hours float64
+
+	for soc := s.vehicleSoc; soc < float64(targetSoc); {
+		next := min(float64(targetSoc), float64((curveIndex(soc)+1)*curveStep))
+		if next <= soc {
+			break
+		}
+
+		power := s.model.curvePower(soc)
+		if power == 0 {
+			power = m*(soc+next)/2 + b
+		}
+		power = max(min(chargePower, power), s.minChargePower)
+
+		hours += (next - soc) / 100 * s.virtualCapacity / power
+		soc = next
+	}
+
+	return max(0, time.Duration(float64(time.Hour)*hours)).Round(time.Second)
+}
+
 // RemainingChargeEnergy returns the remaining charge energy in kWh
 func (s *Estimator) RemainingChargeEnergy(targetSoc int) float64 {
 	percentRemaining := float64(targetSoc) - s.vehicleSoc
@@ -129,6 +213,7 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 	}
 
 	if fetchedSoc == nil {
+		start := time.Now()
 		f, err := Guard(s.vehicle.Soc())
 		if err != nil {
 			// required for online APIs with refreshkey
@@ -136,6 +221,8 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 				return 0, err
 			}
 
+			s.health.Observe(start, err)
+
 			// never received a soc value
 			if s.prevSoc == 0 {
 				return 0, err
@@ -144,12 +231,16 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 			// recover from temporary api errors
 			f = s.prevSoc
 			s.log.WARN.Printf("vehicle soc: %v (ignored by estimator)", err)
+		} else {
+			s.health.Observe(start, nil)
 		}
 
 		fetchedSoc = &f
 		s.vehicleSoc = f
 	}
 
+	s.session.observe(*fetchedSoc, max(chargedEnergy, 0))
+
 	if s.estimate && s.virtualCapacity > 0 {
 		socDelta := s.vehicleSoc - s.prevSoc
 		energyDelta := max(chargedEnergy, 0) - s.prevChargedEnergy
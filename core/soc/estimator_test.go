@@ -242,3 +242,57 @@ func TestImprovedEstimatorRemainingChargeDuration(t *testing.T) {
 		assert.Equal(t, tc.duration, ce.RemainingChargeDuration(tc.targetsoc, tc.chargePower))
 	}
 }
+
+func TestLearnChargeModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	vehicle := api.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Capacity().Return(float64(10)).AnyTimes()
+
+	ce := NewEstimator(util.NewLogger("foo"), charger, vehicle, false)
+
+	// 10 kWh capacity charged from 50% to 100% using 5.5 kWh
+	for _, tc := range []struct {
+		chargedEnergy, vehicleSoc, chargePower float64
+	}{
+		{0, 50, 11000},
+		{1100, 60, 11000},
+		{2200, 70, 11000},
+		{3300, 80, 11000},
+		{4400, 90, 5500},
+		{5000, 95, 2000},
+		{5500, 100, 0},
+	} {
+		vehicle.EXPECT().Soc().Return(tc.vehicleSoc, nil)
+
+		_, err := ce.Soc(tc.chargedEnergy)
+		assert.NoError(t, err)
+
+		ce.Sample(tc.chargePower)
+	}
+
+	model, updated := ce.Learn()
+	assert.True(t, updated)
+	assert.Equal(t, 0.909, model.Efficiency)
+	assert.Equal(t, 1, model.Sessions)
+	assert.Equal(t, 11000.0, model.Curve[curveIndex(50)])
+	assert.Equal(t, 5500.0, model.Curve[curveIndex(90)])
+	assert.Equal(t, 2000.0, model.Curve[curveIndex(95)])
+	assert.Zero(t, model.Curve[curveIndex(20)])
+
+	// nothing learned without new observations
+	_, updated = ce.Learn()
+	assert.False(t, updated)
+
+	// learned efficiency and curve
+	ce.SetModel(model)
+	assert.InDelta(t, 10000/0.909, ce.virtualCapacity, 1e-6)
+
+	ce.vehicleSoc = 80
+	expected := (0.05/11000 + 0.05/8250 + 0.05/5500 + 0.05/2000) * ce.virtualCapacity
+	assert.Equal(t, time.Duration(float64(time.Hour)*expected).Round(time.Second), ce.RemainingChargeDuration(100, 11000))
+
+	// limited by available charge power
+	expected = 0.2 * ce.virtualCapacity / 1400
+	assert.Equal(t, time.Duration(float64(time.Hour)*expected).Round(time.Second), ce.RemainingChargeDuration(100, 1400))
+}
@@ -0,0 +1,124 @@
+package soc
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	CurveSteps  = 20               // number of soc steps of the learned charge curve
+	curveStep   = 100 / CurveSteps // soc % per curve step
+	modelAlpha  = 0.3              // weight of the latest session when learning efficiency
+	minLearnSoc = 10               // min soc % charged per session to learn efficiency
+)
+
+// Model is the learned charge model of a vehicle
+type Model struct {
+	Efficiency float64   `json:"efficiency"` // share of charged energy stored in the battery, 0 if unknown
+	Curve      []float64 `json:"curve"`      // max charge power in W per soc step, 0 if unknown
+	Sessions   int       `json:"sessions"`   // number of sessions the model was learned from
+}
+
+// curvePower returns the learned charge power at given soc or 0 if unknown.
+// Steps between learned steps are interpolated linearly.
+func (m *Model) curvePower(soc float64) float64 {
+	i := curveIndex(soc)
+	if i >= len(m.Curve) {
+		return 0
+	}
+
+	if m.Curve[i] > 0 {
+		return m.Curve[i]
+	}
+
+	lo, hi := i-1, i+1
+	for lo >= 0 && m.Curve[lo] == 0 {
+		lo--
+	}
+	for hi < len(m.Curve) && m.Curve[hi] == 0 {
+		hi++
+	}
+
+	if lo < 0 || hi >= len(m.Curve) {
+		return 0
+	}
+
+	return m.Curve[lo] + float64(i-lo)/float64(hi-lo)*(m.Curve[hi]-m.Curve[lo])
+}
+
+// hasCurve returns true if any charge power has been learned
+func (m *Model) hasCurve() bool {
+	for _, p := range m.Curve {
+		if p > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func curveIndex(soc float64) int {
+	return min(CurveSteps-1, max(0, int(soc)/curveStep))
+}
+
+// session collects the observations of a single charging session
+type session struct {
+	valid                 bool
+	startSoc, startEnergy float64
+	endSoc, endEnergy     float64
+	power                 [CurveSteps]float64
+}
+
+// observe records a vehicle soc at the given charged energy
+func (s *session) observe(soc, chargedEnergy float64) {
+	// sample energy at soc change only
+	if s.valid && soc == s.endSoc && chargedEnergy >= s.endEnergy {
+		return
+	}
+
+	// start or restart after unexpected energy reset
+	if !s.valid || chargedEnergy < s.endEnergy {
+		s.valid = true
+		s.startSoc, s.startEnergy = soc, chargedEnergy
+	}
+
+	s.endSoc, s.endEnergy = soc, chargedEnergy
+}
+
+// learn updates the model from the session observations
+func (s *session) learn(m Model, capacity float64) (Model, bool) {
+	var updated bool
+
+	// efficiency from soc gain and charged energy
+	if socDiff, energyDiff := s.endSoc-s.startSoc, s.endEnergy-s.startEnergy; s.valid && socDiff >= minLearnSoc && energyDiff > 0 && capacity > 0 {
+		efficiency := min(1, max(0.5, socDiff/100*capacity/energyDiff))
+
+		if m.Efficiency == 0 {
+			m.Efficiency = efficiency
+		} else {
+			m.Efficiency += modelAlpha * (efficiency - m.Efficiency)
+		}
+
+		m.Efficiency = math.Round(m.Efficiency*1e3) / 1e3
+		updated = true
+	}
+
+	// observed charge power is a lower bound of what the vehicle accepts
+	curve := slices.Clone(m.Curve)
+	if len(curve) != CurveSteps {
+		curve = make([]float64, CurveSteps)
+	}
+
+	for i, p := range s.power {
+		if p > curve[i] {
+			curve[i] = math.Round(p)
+			m.Curve = curve
+			updated = true
+		}
+	}
+
+	if updated {
+		m.Sessions++
+	}
+
+	return m, updated
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util"
 )
@@ -144,3 +145,17 @@ func (v *adapter) GetRepeatingPlans() []api.RepeatingPlanStruct {
 
 	return []api.RepeatingPlanStruct{}
 }
+
+// GetChargeModel returns the learned charge model
+func (v *adapter) GetChargeModel() soc.Model {
+	var model soc.Model
+	if err := settings.Json(v.key()+keys.ChargeModel, &model); err != nil {
+		return soc.Model{}
+	}
+	return model
+}
+
+// SetChargeModel stores the learned charge model
+func (v *adapter) SetChargeModel(model soc.Model) {
+	settings.SetJson(v.key()+keys.ChargeModel, model)
+}
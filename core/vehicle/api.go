@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/soc"
 )
 
 //go:generate go tool mockgen -package vehicle -destination mock.go -mock_names API=MockAPI github.com/evcc-io/evcc/core/vehicle API
@@ -48,6 +49,11 @@ type API interface {
 	// SetRepeatingPlans stores every repeating plan
 	SetRepeatingPlans([]api.RepeatingPlanStruct) error
 
+	// GetChargeModel returns the learned charge model
+	GetChargeModel() soc.Model
+	// SetChargeModel stores the learned charge model
+	SetChargeModel(soc.Model)
+
 	// // GetMinCurrent returns the min charging current
 	// GetMinCurrent() float64
 	// // SetMinCurrent sets the min charging current
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/soc"
 )
 
 var _ API = (*dummy)(nil)
@@ -56,3 +57,12 @@ func (v *dummy) SetRepeatingPlans(plans []api.RepeatingPlanStruct) error {
 func (v *dummy) GetRepeatingPlans() []api.RepeatingPlanStruct {
 	return []api.RepeatingPlanStruct{}
 }
+
+// GetChargeModel returns the learned charge model
+func (v *dummy) GetChargeModel() soc.Model {
+	return soc.Model{}
+}
+
+// SetChargeModel stores the learned charge model
+func (v *dummy) SetChargeModel(model soc.Model) {
+}
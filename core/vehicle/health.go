@@ -0,0 +1,25 @@
+package vehicle
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/health"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// Health returns the health tracker of a configured vehicle
+func Health(v api.Vehicle) *health.Tracker {
+	if dev := device(v); dev != nil {
+		return health.Track(health.Vehicle, dev.Config().Name)
+	}
+	return nil
+}
+
+// Observe records a vehicle api request started at the given time.
+// Acceptable errors like sleeping vehicles are not recorded.
+func Observe(v api.Vehicle, start time.Time, err error) {
+	if !loadpoint.AcceptableError(err) {
+		Health(v).Observe(start, err)
+	}
+}
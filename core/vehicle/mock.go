@@ -14,6 +14,7 @@ import (
 	time "time"
 
 	api "github.com/evcc-io/evcc/api"
+	soc "github.com/evcc-io/evcc/core/soc"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +42,20 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// GetChargeModel mocks base method.
+func (m *MockAPI) GetChargeModel() soc.Model {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChargeModel")
+	ret0, _ := ret[0].(soc.Model)
+	return ret0
+}
+
+// GetChargeModel indicates an expected call of GetChargeModel.
+func (mr *MockAPIMockRecorder) GetChargeModel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargeModel", reflect.TypeOf((*MockAPI)(nil).GetChargeModel))
+}
+
 // GetLimitSoc mocks base method.
 func (m *MockAPI) GetLimitSoc() int {
 	m.ctrl.T.Helper()
@@ -127,28 +142,40 @@ func (mr *MockAPIMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockAPI)(nil).Name))
 }
 
+// SetChargeModel mocks base method.
+func (m *MockAPI) SetChargeModel(arg0 soc.Model) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetChargeModel", arg0)
+}
+
+// SetChargeModel indicates an expected call of SetChargeModel.
+func (mr *MockAPIMockRecorder) SetChargeModel(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChargeModel", reflect.TypeOf((*MockAPI)(nil).SetChargeModel), arg0)
+}
+
 // SetLimitSoc mocks base method.
-func (m *MockAPI) SetLimitSoc(soc int) {
+func (m *MockAPI) SetLimitSoc(arg0 int) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetLimitSoc", soc)
+	m.ctrl.Call(m, "SetLimitSoc", arg0)
 }
 
 // SetLimitSoc indicates an expected call of SetLimitSoc.
-func (mr *MockAPIMockRecorder) SetLimitSoc(soc any) *gomock.Call {
+func (mr *MockAPIMockRecorder) SetLimitSoc(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimitSoc", reflect.TypeOf((*MockAPI)(nil).SetLimitSoc), soc)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimitSoc", reflect.TypeOf((*MockAPI)(nil).SetLimitSoc), arg0)
 }
 
 // SetMinSoc mocks base method.
-func (m *MockAPI) SetMinSoc(soc int) {
+func (m *MockAPI) SetMinSoc(arg0 int) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetMinSoc", soc)
+	m.ctrl.Call(m, "SetMinSoc", arg0)
 }
 
 // SetMinSoc indicates an expected call of SetMinSoc.
-func (mr *MockAPIMockRecorder) SetMinSoc(soc any) *gomock.Call {
+func (mr *MockAPIMockRecorder) SetMinSoc(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinSoc", reflect.TypeOf((*MockAPI)(nil).SetMinSoc), soc)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinSoc", reflect.TypeOf((*MockAPI)(nil).SetMinSoc), arg0)
 }
 
 // SetPlanSoc mocks base method.
@@ -9,6 +9,7 @@ import (
 	ucapi "github.com/enbility/eebus-go/usecases/api"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/circuit"
+	"github.com/evcc-io/evcc/core/dimming"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/eebus"
 	"github.com/evcc-io/evcc/util"
@@ -32,6 +33,7 @@ type EEBus struct {
 	failsafeDuration time.Duration
 
 	heartbeat *util.Value[struct{}]
+	recorder  *dimming.Recorder
 }
 
 type Limits struct {
@@ -82,11 +84,11 @@ func New(ctx context.Context, other map[string]interface{}, site site.API) (*EEB
 	}
 	site.SetCircuit(lpc)
 
-	return NewEEBus(ctx, cc.Ski, cc.Limits, lpc)
+	return NewEEBus(ctx, cc.Ski, cc.Limits, lpc, dimming.TariffModule(site.GetTariff(api.TariffUsageGrid)))
 }
 
 // NewEEBus creates EEBus charger
-func NewEEBus(ctx context.Context, ski string, limits Limits, root api.Circuit) (*EEBus, error) {
+func NewEEBus(ctx context.Context, ski string, limits Limits, root api.Circuit, module dimming.Module) (*EEBus, error) {
 	if eebus.Instance == nil {
 		return nil, errors.New("eebus not configured")
 	}
@@ -97,6 +99,7 @@ func NewEEBus(ctx context.Context, ski string, limits Limits, root api.Circuit)
 		uc:        eebus.Instance.ControllableSystem(),
 		Connector: eebus.NewConnector(),
 		heartbeat: util.NewValue[struct{}](2 * time.Minute), // LPC-031
+		recorder:  dimming.NewRecorder(root.GetChargePower, module),
 
 		consumptionLimit: &ucapi.LoadLimit{
 			Value:        limits.ConsumptionLimit,
@@ -163,6 +166,8 @@ func (c *EEBus) run() error {
 
 	c.log.TRACE.Println("status:", c.status)
 
+	defer c.recorder.Update()
+
 	// check heartbeat
 	_, heartbeatErr := c.heartbeat.Get()
 	if heartbeatErr != nil && c.status != StatusFailsafe {
@@ -195,6 +200,7 @@ func (c *EEBus) run() error {
 		}
 
 		c.setLimit(c.consumptionLimit.Value)
+		c.recorder.SetLimit("eebus", c.consumptionLimit.Value, c.consumptionLimit.Duration)
 
 		// LPC-914/1
 		if d := c.consumptionLimit.Duration; d > 0 && time.Since(c.statusUpdated) > d {
@@ -220,6 +226,15 @@ func (c *EEBus) setStatusAndLimit(status status, limit float64) {
 	c.statusUpdated = time.Now()
 
 	c.setLimit(limit)
+
+	switch status {
+	case StatusLimited:
+		c.recorder.SetLimit("eebus", limit, c.consumptionLimit.Duration)
+	case StatusFailsafe:
+		c.recorder.SetLimit("eebus failsafe", limit, c.failsafeDuration)
+	default:
+		c.recorder.SetLimit("eebus", 0, 0)
+	}
 }
 
 func (c *EEBus) setLimit(limit float64) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/circuit"
+	"github.com/evcc-io/evcc/core/dimming"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/plugin"
 	"github.com/evcc-io/evcc/util"
@@ -19,6 +20,7 @@ type Relay struct {
 	root     api.Circuit
 	limit    func() (bool, error)
 	maxPower float64
+	recorder *dimming.Recorder
 }
 
 // New creates an Relay HEMS from generic config
@@ -61,16 +63,17 @@ func New(ctx context.Context, other map[string]interface{}, site site.API) (*Rel
 		return nil, err
 	}
 
-	return NewRelay(lpc, limitG, cc.MaxPower)
+	return NewRelay(lpc, limitG, cc.MaxPower, dimming.TariffModule(site.GetTariff(api.TariffUsageGrid)))
 }
 
 // NewRelay creates Relay HEMS
-func NewRelay(root api.Circuit, limit func() (bool, error), maxPower float64) (*Relay, error) {
+func NewRelay(root api.Circuit, limit func() (bool, error), maxPower float64, module dimming.Module) (*Relay, error) {
 	c := &Relay{
 		log:      util.NewLogger("relay"),
 		root:     root,
 		maxPower: maxPower,
 		limit:    limit,
+		recorder: dimming.NewRecorder(root.GetChargePower, module),
 	}
 
 	return c, nil
@@ -96,6 +99,8 @@ func (c *Relay) run() error {
 	}
 
 	c.root.SetMaxPower(power)
+	c.recorder.SetLimit("relay", power, 0)
+	c.recorder.Update()
 
 	return nil
 }
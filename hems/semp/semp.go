@@ -30,6 +30,8 @@ const (
 	sempDeviceId     = "F-%s-%.12x-00" // 6 bytes
 	sempSerialNumber = "%s-%d"
 	sempCharger      = "EVCharger"
+	sempHeatPump     = "HeatPump"
+	sempOther        = "Other"
 	basePath         = "/semp"
 	maxAge           = 1800
 )
@@ -363,6 +365,18 @@ func (s *SEMP) deviceID(id int) string {
 	return fmt.Sprintf(sempDeviceId, s.vid, ^uint64(0xffff<<48)&(binary.BigEndian.Uint64(did)+uint64(id)))
 }
 
+// deviceType returns the SEMP device type of the loadpoint
+func deviceType(lp loadpoint.API) string {
+	switch {
+	case lp.HasChargerFeature(api.Heating):
+		return sempHeatPump
+	case lp.HasChargerFeature(api.IntegratedDevice):
+		return sempOther
+	default:
+		return sempCharger
+	}
+}
+
 func (s *SEMP) deviceInfo(id int, lp loadpoint.API) DeviceInfo {
 	method := MethodEstimation
 	if lp.HasChargeMeter() {
@@ -373,7 +387,7 @@ func (s *SEMP) deviceInfo(id int, lp loadpoint.API) DeviceInfo {
 		Identification: Identification{
 			DeviceID:     s.deviceID(id),
 			DeviceName:   lp.GetTitle(),
-			DeviceType:   sempCharger,
+			DeviceType:   deviceType(lp),
 			DeviceSerial: s.serialNumber(id),
 			DeviceVendor: "github.com/evcc-io/evcc",
 		},
@@ -439,6 +453,10 @@ func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 	charging := lp.GetStatus() == api.StatusC
 	connected := charging || lp.GetStatus() == api.StatusB
 
+	if mode == api.ModeOff || !connected {
+		return res
+	}
+
 	// remaining max demand duration in seconds
 	chargeRemainingDuration := lp.GetRemainingDuration()
 	latestEnd := int(chargeRemainingDuration / time.Second)
@@ -446,6 +464,34 @@ func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 		latestEnd = 24 * 3600
 	}
 
+	// active plan requires charging until plan time
+	planEnd, planDuration := s.planDemand(lp)
+	if mode == api.ModeNow {
+		planDuration = 0
+	}
+	if planDuration > 0 {
+		latestEnd = planEnd
+	}
+
+	// non-EV devices request running time instead of energy
+	if deviceType(lp) != sempCharger {
+		maxRunningTime := latestEnd
+		minRunningTime := min(maxRunningTime, int(planDuration/time.Second))
+		if mode == api.ModeNow {
+			minRunningTime = maxRunningTime
+		}
+
+		return PlanningRequest{
+			Timeframe: []Timeframe{{
+				DeviceID:       s.deviceID(id),
+				EarliestStart:  0,
+				LatestEnd:      latestEnd,
+				MinRunningTime: &minRunningTime,
+				MaxRunningTime: &maxRunningTime,
+			}},
+		}
+	}
+
 	// remaining max energy demand in Wh
 	chargeRemainingEnergy := lp.GetRemainingEnergy()
 	maxEnergy := int(chargeRemainingEnergy)
@@ -466,7 +512,13 @@ func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 		minPowerConsumption = maxPowerConsumption
 	}
 
-	if mode != api.ModeOff && connected && maxEnergy > 0 {
+	// plan energy is mandatory
+	if planDuration > 0 {
+		minEnergy = int(planDuration.Hours() * lp.EffectiveMaxPower())
+		maxEnergy = max(maxEnergy, minEnergy)
+	}
+
+	if maxEnergy > 0 {
 		res = PlanningRequest{
 			Timeframe: []Timeframe{{
 				DeviceID:            s.deviceID(id),
@@ -483,6 +535,26 @@ func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 	return res
 }
 
+// planDemand returns the seconds until plan time and the charging duration required by the active plan
+func (s *SEMP) planDemand(lp loadpoint.API) (int, time.Duration) {
+	planTime := lp.EffectivePlanTime()
+	if planTime.IsZero() {
+		return 0, 0
+	}
+
+	planEnd := int(time.Until(planTime) / time.Second)
+	if planEnd <= 0 {
+		return 0, 0
+	}
+
+	goal, _ := lp.GetPlanGoal()
+	if goal <= 0 {
+		return 0, 0
+	}
+
+	return planEnd, lp.GetPlanRequiredDuration(goal, lp.EffectiveMaxPower())
+}
+
 func (s *SEMP) allPlanningRequest() (res []PlanningRequest) {
 	for id, lp := range s.site.Loadpoints() {
 		if pr := s.planningRequest(id, lp); len(pr.Timeframe) > 0 {
@@ -0,0 +1,65 @@
+package semp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPlanningRequestPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+	lp.EXPECT().GetStatus().Return(api.StatusB).AnyTimes()
+	lp.EXPECT().HasChargerFeature(gomock.Any()).Return(false).AnyTimes()
+	lp.EXPECT().GetRemainingDuration().Return(time.Duration(0))
+	lp.EXPECT().GetRemainingEnergy().Return(30e3)
+	lp.EXPECT().EffectiveMinPower().Return(4140.0).AnyTimes()
+	lp.EXPECT().EffectiveMaxPower().Return(11e3).AnyTimes()
+	lp.EXPECT().EffectivePlanTime().Return(time.Now().Add(5 * time.Hour))
+	lp.EXPECT().GetPlanGoal().Return(80.0, true)
+	lp.EXPECT().GetPlanRequiredDuration(80.0, 11e3).Return(2 * time.Hour)
+
+	s := &SEMP{vid: "28081973", did: make([]byte, 6)}
+
+	res := s.planningRequest(0, lp)
+	require.Len(t, res.Timeframe, 1)
+
+	tf := res.Timeframe[0]
+	assert.InDelta(t, 5*3600, tf.LatestEnd, 2)
+	assert.Equal(t, 22000, *tf.MinEnergy)
+	assert.Equal(t, 30000, *tf.MaxEnergy)
+}
+
+func TestPlanningRequestHeatPump(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetTitle().Return("heat pump").AnyTimes()
+	lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+	lp.EXPECT().GetStatus().Return(api.StatusB).AnyTimes()
+	lp.EXPECT().HasChargerFeature(api.Heating).Return(true).AnyTimes()
+	lp.EXPECT().HasChargeMeter().Return(true).AnyTimes()
+	lp.EXPECT().GetRemainingDuration().Return(time.Duration(0))
+	lp.EXPECT().EffectiveMinPower().Return(1000.0).AnyTimes()
+	lp.EXPECT().EffectiveMaxPower().Return(3000.0).AnyTimes()
+	lp.EXPECT().EffectivePlanTime().Return(time.Time{})
+
+	s := &SEMP{vid: "28081973", did: make([]byte, 6), uid: "00000000-0000-0000-0000-000000000000"}
+
+	assert.Equal(t, sempHeatPump, s.deviceInfo(0, lp).Identification.DeviceType)
+
+	res := s.planningRequest(0, lp)
+	require.Len(t, res.Timeframe, 1)
+
+	tf := res.Timeframe[0]
+	assert.Nil(t, tf.MinEnergy)
+	assert.Equal(t, 0, *tf.MinRunningTime)
+	assert.Equal(t, 24*3600, *tf.MaxRunningTime)
+}
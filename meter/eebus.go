@@ -3,24 +3,42 @@ package meter
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	eebusapi "github.com/enbility/eebus-go/api"
 	"github.com/enbility/eebus-go/usecases/ma/mgcp"
+	"github.com/enbility/eebus-go/usecases/ma/mpc"
 	spineapi "github.com/enbility/spine-go/api"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/server/eebus"
 	"github.com/evcc-io/evcc/util"
 )
 
+const (
+	useCaseMGCP = "mgcp" // monitoring of grid connection point
+	useCaseMPC  = "mpc"  // monitoring of power consumption
+)
+
+// measurements are the measurements common to the MGCP and MPC use cases
+type measurements interface {
+	Power(entity spineapi.EntityRemoteInterface) (float64, error)
+	EnergyConsumed(entity spineapi.EntityRemoteInterface) (float64, error)
+	CurrentPerPhase(entity spineapi.EntityRemoteInterface) ([]float64, error)
+	VoltagePerPhase(entity spineapi.EntityRemoteInterface) ([]float64, error)
+}
+
 type EEBus struct {
 	log *util.Logger
 
 	*eebus.Connector
-	uc *eebus.UseCasesCS
+	uc      *eebus.UseCasesCS
+	useCase string
+	ma      measurements
 
-	power, energy      *util.Value[float64]
-	voltages, currents *util.Value[[]float64]
+	power, energy              *util.Value[float64]
+	voltages, currents, powers *util.Value[[]float64]
 }
 
 func init() {
@@ -32,8 +50,10 @@ func NewEEBusFromConfig(ctx context.Context, other map[string]interface{}) (api.
 	cc := struct {
 		Ski     string
 		Ip      string
+		Usecase string
 		Timeout time.Duration
 	}{
+		Usecase: useCaseMGCP,
 		Timeout: 10 * time.Second,
 	}
 
@@ -41,11 +61,20 @@ func NewEEBusFromConfig(ctx context.Context, other map[string]interface{}) (api.
 		return nil, err
 	}
 
-	return NewEEBus(ctx, cc.Ski, cc.Ip, cc.Timeout)
+	m, err := NewEEBus(ctx, cc.Ski, cc.Ip, cc.Usecase, cc.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.useCase == useCaseMPC {
+		return &EEBusMPC{m}, nil
+	}
+
+	return m, nil
 }
 
-// NewEEBus creates EEBus charger
-func NewEEBus(ctx context.Context, ski, ip string, timeout time.Duration) (*EEBus, error) {
+// NewEEBus creates EEBus meter
+func NewEEBus(ctx context.Context, ski, ip, useCase string, timeout time.Duration) (*EEBus, error) {
 	if eebus.Instance == nil {
 		return nil, errors.New("eebus not configured")
 	}
@@ -53,11 +82,22 @@ func NewEEBus(ctx context.Context, ski, ip string, timeout time.Duration) (*EEBu
 	c := &EEBus{
 		log:       util.NewLogger("eebus"),
 		uc:        eebus.Instance.ControllableSystem(),
+		useCase:   strings.ToLower(useCase),
 		Connector: eebus.NewConnector(),
 		power:     util.NewValue[float64](timeout),
 		energy:    util.NewValue[float64](timeout),
 		voltages:  util.NewValue[[]float64](timeout),
 		currents:  util.NewValue[[]float64](timeout),
+		powers:    util.NewValue[[]float64](timeout),
+	}
+
+	switch c.useCase {
+	case useCaseMGCP:
+		c.ma = c.uc.MGCP
+	case useCaseMPC:
+		c.ma = c.uc.MPC
+	default:
+		return nil, fmt.Errorf("invalid usecase: %s", useCase)
 	}
 
 	if err := eebus.Instance.RegisterDevice(ski, ip, c); err != nil {
@@ -76,6 +116,22 @@ var _ eebus.Device = (*EEBus)(nil)
 
 // UseCaseEvent implements the eebus.Device interface
 func (c *EEBus) UseCaseEvent(_ spineapi.DeviceRemoteInterface, entity spineapi.EntityRemoteInterface, event eebusapi.EventType) {
+	if c.useCase == useCaseMPC {
+		switch event {
+		case mpc.DataUpdatePower:
+			c.dataUpdatePower(entity)
+		case mpc.DataUpdatePowerPerPhase:
+			c.dataUpdatePowerPerPhase(entity)
+		case mpc.DataUpdateEnergyConsumed:
+			c.dataUpdateEnergyConsumed(entity)
+		case mpc.DataUpdateCurrentsPerPhase:
+			c.dataUpdateCurrentPerPhase(entity)
+		case mpc.DataUpdateVoltagePerPhase:
+			c.dataUpdateVoltagePerPhase(entity)
+		}
+		return
+	}
+
 	switch event {
 	case mgcp.DataUpdatePower:
 		c.dataUpdatePower(entity)
@@ -89,36 +145,45 @@ func (c *EEBus) UseCaseEvent(_ spineapi.DeviceRemoteInterface, entity spineapi.E
 }
 
 func (c *EEBus) dataUpdatePower(entity spineapi.EntityRemoteInterface) {
-	data, err := c.uc.MGCP.Power(entity)
+	data, err := c.ma.Power(entity)
 	if err != nil {
-		c.log.ERROR.Println("MGCP.Power:", err)
+		c.log.ERROR.Printf("%s.Power: %v", strings.ToUpper(c.useCase), err)
 		return
 	}
 	c.power.Set(data)
 }
 
+func (c *EEBus) dataUpdatePowerPerPhase(entity spineapi.EntityRemoteInterface) {
+	data, err := c.uc.MPC.PowerPerPhase(entity)
+	if err != nil {
+		c.log.ERROR.Println("MPC.PowerPerPhase:", err)
+		return
+	}
+	c.powers.Set(data)
+}
+
 func (c *EEBus) dataUpdateEnergyConsumed(entity spineapi.EntityRemoteInterface) {
-	data, err := c.uc.MGCP.EnergyConsumed(entity)
+	data, err := c.ma.EnergyConsumed(entity)
 	if err != nil {
-		c.log.ERROR.Println("MGCP.EnergyConsumed:", err)
+		c.log.ERROR.Printf("%s.EnergyConsumed: %v", strings.ToUpper(c.useCase), err)
 		return
 	}
 	c.energy.Set(data)
 }
 
 func (c *EEBus) dataUpdateCurrentPerPhase(entity spineapi.EntityRemoteInterface) {
-	data, err := c.uc.MGCP.CurrentPerPhase(entity)
+	data, err := c.ma.CurrentPerPhase(entity)
 	if err != nil {
-		c.log.ERROR.Println("MGCP.CurrentPerPhase:", err)
+		c.log.ERROR.Printf("%s.CurrentPerPhase: %v", strings.ToUpper(c.useCase), err)
 		return
 	}
 	c.currents.Set(data)
 }
 
 func (c *EEBus) dataUpdateVoltagePerPhase(entity spineapi.EntityRemoteInterface) {
-	data, err := c.uc.MGCP.VoltagePerPhase(entity)
+	data, err := c.ma.VoltagePerPhase(entity)
 	if err != nil {
-		c.log.ERROR.Println("MGCP.VoltagePerPhase:", err)
+		c.log.ERROR.Printf("%s.VoltagePerPhase: %v", strings.ToUpper(c.useCase), err)
 		return
 	}
 	c.voltages.Set(data)
@@ -128,28 +193,47 @@ func (c *EEBus) CurrentPower() (float64, error) {
 	return c.power.Get()
 }
 
+var _ api.MeterEnergy = (*EEBus)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
 func (c *EEBus) TotalEnergy() (float64, error) {
-	return c.energy.Get()
+	res, err := c.energy.Get()
+	return res / 1e3, err
 }
 
-func (c *EEBus) PhaseCurrents() (float64, float64, float64, error) {
-	res, err := c.currents.Get()
-	if err == nil && len(res) != 3 {
-		err = errors.New("invalid phase currents")
-	}
-	if err != nil {
-		return 0, 0, 0, err
-	}
-	return res[0], res[1], res[2], nil
+var _ api.PhaseCurrents = (*EEBus)(nil)
+
+// Currents implements the api.PhaseCurrents interface
+func (c *EEBus) Currents() (float64, float64, float64, error) {
+	return phaseValues(c.currents, "currents")
 }
 
-func (c *EEBus) PhaseVoltages() (float64, float64, float64, error) {
-	res, err := c.voltages.Get()
+var _ api.PhaseVoltages = (*EEBus)(nil)
+
+// Voltages implements the api.PhaseVoltages interface
+func (c *EEBus) Voltages() (float64, float64, float64, error) {
+	return phaseValues(c.voltages, "voltages")
+}
+
+func phaseValues(v *util.Value[[]float64], name string) (float64, float64, float64, error) {
+	res, err := v.Get()
 	if err == nil && len(res) != 3 {
-		err = errors.New("invalid phase voltages")
+		err = fmt.Errorf("invalid phase %s", name)
 	}
 	if err != nil {
 		return 0, 0, 0, err
 	}
 	return res[0], res[1], res[2], nil
 }
+
+// EEBusMPC is an EEBus meter providing per-phase powers
+type EEBusMPC struct {
+	*EEBus
+}
+
+var _ api.PhasePowers = (*EEBusMPC)(nil)
+
+// Powers implements the api.PhasePowers interface
+func (c *EEBusMPC) Powers() (float64, float64, float64, error) {
+	return phaseValues(c.powers, "powers")
+}
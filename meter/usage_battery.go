@@ -22,6 +22,13 @@ type batterySocLimits struct {
 	MinSoc, MaxSoc float64
 }
 
+// Decorator returns the soc limits decorator
+func (m *batterySocLimits) Decorator() func() (float64, float64) {
+	return func() (float64, float64) {
+		return m.MinSoc, m.MaxSoc
+	}
+}
+
 // LimitController returns an api.BatteryController decorator
 func (m *batterySocLimits) LimitController(socG func() (float64, error), limitSocS func(float64) error) func(api.BatteryMode) error {
 	return func(mode api.BatteryMode) error {
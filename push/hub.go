@@ -2,6 +2,7 @@ package push
 
 import (
 	"fmt"
+	"maps"
 	"strings"
 	"text/template"
 
@@ -12,8 +13,9 @@ import (
 
 // Event is a notification event
 type Event struct {
-	Loadpoint *int // optional loadpoint id
-	Event     string
+	Loadpoint  *int // optional loadpoint id
+	Event      string
+	Attributes map[string]any // optional event attributes, take precedence over cached values
 }
 
 // EventTemplateConfig is the push message configuration for an event
@@ -76,6 +78,8 @@ func (h *Hub) apply(ev Event, tmpl string) (string, error) {
 		}
 	}
 
+	maps.Copy(attr, ev.Attributes)
+
 	// add missing attributes
 	if name, ok := attr["vehicleName"].(string); ok {
 		if v, err := h.vehicles.ByName(name); err == nil {
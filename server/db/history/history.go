@@ -0,0 +1,201 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Resolution is the aggregation interval of history entries
+type Resolution string
+
+const (
+	Slot  Resolution = "15m"
+	Hour  Resolution = "hour"
+	Day   Resolution = "day"
+	Month Resolution = "month"
+)
+
+// Resolutions lists all resolutions from finest to coarsest
+var Resolutions = []Resolution{Slot, Hour, Day, Month}
+
+// SlotDuration is the finest history resolution
+const SlotDuration = 15 * time.Minute
+
+// Retention is the duration entries are kept per resolution. Zero means forever.
+// Slots cover the history used for household consumption profiles.
+var Retention = map[Resolution]time.Duration{
+	Slot:  60 * 24 * time.Hour,
+	Hour:  400 * 24 * time.Hour,
+	Day:   0,
+	Month: 0,
+}
+
+// ErrInvalidResolution is returned for unknown resolutions
+var ErrInvalidResolution = errors.New("invalid resolution")
+
+// ParseResolution parses a resolution string
+func ParseResolution(s string) (Resolution, error) {
+	if res := Resolution(s); slices.Contains(Resolutions, res) {
+		return res, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrInvalidResolution, s)
+}
+
+// SetRetention updates the retention of the given resolutions
+func SetRetention(retention map[string]time.Duration) error {
+	for s, d := range retention {
+		res, err := ParseResolution(s)
+		if err != nil {
+			return err
+		}
+		if d < 0 {
+			return fmt.Errorf("invalid %s retention: %v", res, d)
+		}
+		Retention[res] = d
+	}
+
+	return nil
+}
+
+// Start returns the start of the resolution's interval containing ts in local time.
+// Intervals are aligned to the local wall clock which also applies to time zones with fractional hour offsets.
+func (r Resolution) Start(ts time.Time) time.Time {
+	ts = ts.Local()
+
+	// offset since start of local hour, avoids ambiguous wall clock times during DST changes
+	sinceHour := time.Duration(ts.Minute())*time.Minute + time.Duration(ts.Second())*time.Second + time.Duration(ts.Nanosecond())
+
+	switch r {
+	case Hour:
+		return ts.Add(-sinceHour)
+	case Day:
+		return time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.Local)
+	case Month:
+		return time.Date(ts.Year(), ts.Month(), 1, 0, 0, 0, 0, time.Local)
+	default:
+		return ts.Add(-sinceHour % SlotDuration)
+	}
+}
+
+// Entry is the energy of a single meter within a resolution interval
+type Entry struct {
+	Group      string     `json:"-" gorm:"column:grp;uniqueIndex:history_key"`
+	Name       string     `json:"-" gorm:"column:name;uniqueIndex:history_key"`
+	Resolution Resolution `json:"-" gorm:"column:resolution;uniqueIndex:history_key"`
+	Timestamp  time.Time  `json:"ts" gorm:"column:ts;uniqueIndex:history_key"`
+	Import     float64    `json:"import" gorm:"column:import"` // energy in Wh for positive power
+	Export     float64    `json:"export" gorm:"column:export"` // energy in Wh for negative power
+}
+
+// TableName implements gorm's tabler interface
+func (Entry) TableName() string {
+	return "history"
+}
+
+// Series is the history of a single meter
+type Series struct {
+	Group string  `json:"group"`
+	Name  string  `json:"name"`
+	Data  []Entry `json:"data"`
+}
+
+func Init() error {
+	return db.Instance.AutoMigrate(new(Entry))
+}
+
+// Persist adds the slot's energy to all resolutions of the given meter
+func Persist(group, name string, ts time.Time, imp, exp float64) error {
+	return db.Instance.Transaction(func(tx *gorm.DB) error {
+		for _, res := range Resolutions {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "grp"}, {Name: "name"}, {Name: "resolution"}, {Name: "ts"}},
+				DoUpdates: clause.Assignments(map[string]any{
+					"import": gorm.Expr("import + excluded.import"),
+					"export": gorm.Expr("export + excluded.export"),
+				}),
+			}).Create(&Entry{
+				Group:      group,
+				Name:       name,
+				Resolution: res,
+				Timestamp:  res.Start(ts).UTC(),
+				Import:     imp,
+				Export:     exp,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Prune removes entries exceeding their resolution's retention
+func Prune(now time.Time) error {
+	for res, retention := range Retention {
+		if retention == 0 {
+			continue
+		}
+
+		if err := db.Instance.Where("resolution = ? AND ts < ?", res, now.Add(-retention).UTC()).Delete(new(Entry)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the group's entries of all resolutions starting before the given time
+func Delete(group string, before time.Time) (int64, error) {
+	res := db.Instance.Where("grp = ? AND ts < ?", group, before.UTC()).Delete(new(Entry))
+	return res.RowsAffected, res.Error
+}
+
+// Sum returns the group's energy summed over all meters in given time range
+func Sum(group string, res Resolution, from, to time.Time) ([]Entry, error) {
+	var rows []Entry
+	if err := db.Instance.Model(new(Entry)).
+		Select("ts, SUM(import) AS import, SUM(export) AS export").
+		Where("grp = ? AND resolution = ? AND ts >= ? AND ts < ?", group, res, res.Start(from).UTC(), to.UTC()).
+		Group("ts").Order("ts").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].Group = group
+		rows[i].Resolution = res
+		rows[i].Timestamp = rows[i].Timestamp.Local()
+	}
+
+	return rows, nil
+}
+
+// Get returns the history of all meters, optionally filtered by group, in given time range
+func Get(group string, res Resolution, from, to time.Time) ([]Series, error) {
+	tx := db.Instance.Where("resolution = ? AND ts >= ? AND ts < ?", res, res.Start(from).UTC(), to.UTC())
+	if group != "" {
+		tx = tx.Where("grp = ?", group)
+	}
+
+	var rows []Entry
+	if err := tx.Order("grp, name, ts").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var series []Series
+	for _, row := range rows {
+		if n := len(series); n == 0 || series[n-1].Group != row.Group || series[n-1].Name != row.Name {
+			series = append(series, Series{Group: row.Group, Name: row.Name})
+		}
+
+		row.Timestamp = row.Timestamp.Local()
+		series[len(series)-1].Data = append(series[len(series)-1].Data, row)
+	}
+
+	return series, nil
+}
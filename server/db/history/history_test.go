@@ -0,0 +1,141 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, Init())
+}
+
+func TestRollups(t *testing.T) {
+	setup(t)
+
+	start := time.Date(2025, 1, 31, 23, 0, 0, 0, time.Local)
+
+	// two hours of 400Wh slots crossing the month boundary
+	for ts := start; ts.Before(start.Add(2 * time.Hour)); ts = ts.Add(SlotDuration) {
+		require.NoError(t, Persist("pv", "roof", ts, 400, 0))
+	}
+	require.NoError(t, Persist("grid", "grid", start, 100, 50))
+
+	series, err := Get("pv", Slot, start, start.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	assert.Len(t, series[0].Data, 8)
+
+	series, err = Get("pv", Hour, start, start.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	require.Len(t, series[0].Data, 2)
+	assert.Equal(t, 1600.0, series[0].Data[0].Import)
+
+	series, err = Get("", Month, start.AddDate(0, -1, 0), start.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+	assert.Equal(t, "grid", series[0].Group)
+	assert.Equal(t, 50.0, series[0].Data[0].Export)
+	require.Len(t, series[1].Data, 2)
+	assert.Equal(t, 1600.0, series[1].Data[0].Import)
+	assert.Equal(t, 1600.0, series[1].Data[1].Import)
+}
+
+func TestPrune(t *testing.T) {
+	setup(t)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.Local)
+	old := now.Add(-Retention[Slot] - time.Hour)
+
+	require.NoError(t, Persist("pv", "roof", old, 100, 0))
+	require.NoError(t, Persist("pv", "roof", now, 100, 0))
+	require.NoError(t, Prune(now))
+
+	series, err := Get("", Slot, old.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	assert.Len(t, series[0].Data, 1)
+
+	// coarser resolutions are retained
+	series, err = Get("", Day, old.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	assert.Len(t, series[0].Data, 2)
+}
+
+func TestRecorder(t *testing.T) {
+	setup(t)
+
+	clk := clock.NewMock()
+	clk.Set(time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local))
+
+	r := NewRecorder(clk)
+
+	r.Add("battery", "bat", 0)
+	r.Persist()
+
+	for range 15 {
+		clk.Add(time.Minute)
+		r.Add("battery", "bat", -2000)
+		r.Persist()
+	}
+
+	series, err := Get("battery", Slot, clk.Now().Add(-time.Hour), clk.Now())
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	require.Len(t, series[0].Data, 1)
+	assert.InDelta(t, 500, series[0].Data[0].Export, 1e-6)
+	assert.Zero(t, series[0].Data[0].Import)
+}
+
+func TestStartHalfHourOffset(t *testing.T) {
+	loc := time.Local
+	t.Cleanup(func() { time.Local = loc })
+	time.Local = time.FixedZone("IST", 5*3600+1800)
+
+	ts := time.Date(2025, 1, 6, 12, 40, 10, 0, time.Local)
+
+	assert.Equal(t, time.Date(2025, 1, 6, 12, 30, 0, 0, time.Local), Slot.Start(ts))
+	assert.Equal(t, time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local), Hour.Start(ts))
+	assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local), Day.Start(ts))
+
+	// utc input is aligned in local time
+	assert.Equal(t, time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local), Hour.Start(ts.UTC()).In(time.Local))
+}
+
+func TestSetRetention(t *testing.T) {
+	retention := Retention
+	t.Cleanup(func() { Retention = retention })
+	Retention = map[Resolution]time.Duration{Slot: time.Hour, Hour: time.Hour}
+
+	require.NoError(t, SetRetention(map[string]time.Duration{"15m": 48 * time.Hour, "day": 0}))
+	assert.Equal(t, 48*time.Hour, Retention[Slot])
+	assert.Equal(t, time.Hour, Retention[Hour])
+	assert.Equal(t, time.Duration(0), Retention[Day])
+
+	assert.ErrorIs(t, SetRetention(map[string]time.Duration{"week": time.Hour}), ErrInvalidResolution)
+	assert.Error(t, SetRetention(map[string]time.Duration{"hour": -time.Hour}))
+}
+
+func TestSum(t *testing.T) {
+	setup(t)
+
+	ts := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+
+	require.NoError(t, Persist("loadpoint", "lp-1", ts, 100, 0))
+	require.NoError(t, Persist("loadpoint", "lp-1", ts.Add(SlotDuration), 200, 0))
+	require.NoError(t, Persist("loadpoint", "lp-2", ts, 300, 0))
+
+	rows, err := Sum("loadpoint", Slot, ts, ts.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 400.0, rows[0].Import)
+	assert.Equal(t, 200.0, rows[1].Import)
+	assert.True(t, ts.Equal(rows[0].Timestamp))
+}
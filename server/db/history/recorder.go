@@ -0,0 +1,103 @@
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+)
+
+// maxGap limits the duration a single power sample is integrated over
+const maxGap = 2 * time.Minute
+
+type key struct {
+	group, name string
+}
+
+type energy struct {
+	updated     time.Time
+	imp, export float64 // Wh
+}
+
+// Recorder integrates meter power samples and persists the energy per slot
+type Recorder struct {
+	mu     sync.Mutex
+	log    *util.Logger
+	clock  clock.Clock
+	slot   time.Time
+	pruned time.Time
+	meters map[key]*energy
+}
+
+// NewRecorder creates a history recorder using the given clock
+func NewRecorder(clock clock.Clock) *Recorder {
+	return &Recorder{
+		log:    util.NewLogger("history"),
+		clock:  clock,
+		meters: make(map[key]*energy),
+	}
+}
+
+// Add adds a power sample in W for the given meter. It is safe for concurrent use.
+func (r *Recorder) Add(group, name string, power float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+
+	k := key{group, name}
+	e, ok := r.meters[k]
+	if !ok {
+		r.meters[k] = &energy{updated: now}
+		return
+	}
+
+	d := min(now.Sub(e.updated), maxGap)
+	e.updated = now
+
+	if wh := power * d.Hours(); wh >= 0 {
+		e.imp += wh
+	} else {
+		e.export -= wh
+	}
+}
+
+// Persist stores the accumulated energy once the current slot has ended
+func (r *Recorder) Persist() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	slot := now.Truncate(SlotDuration)
+
+	if r.slot.IsZero() {
+		r.slot = slot
+		return
+	}
+
+	if !slot.After(r.slot) {
+		return
+	}
+
+	for k, e := range r.meters {
+		if e.imp == 0 && e.export == 0 {
+			continue
+		}
+
+		if err := Persist(k.group, k.name, r.slot, e.imp, e.export); err != nil {
+			r.log.ERROR.Printf("persist %s %s: %v", k.group, k.name, err)
+		}
+
+		e.imp, e.export = 0, 0
+	}
+
+	r.slot = slot
+
+	if now.Sub(r.pruned) >= time.Hour {
+		if err := Prune(now); err != nil {
+			r.log.ERROR.Println("prune:", err)
+		}
+		r.pruned = now
+	}
+}
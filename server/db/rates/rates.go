@@ -0,0 +1,101 @@
+package rates
+
+import (
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db"
+	"gorm.io/gorm/clause"
+)
+
+// Retention is the duration rates are kept. Zero means forever.
+var Retention = 2 * 365 * 24 * time.Hour
+
+var clk = clock.New()
+
+// Entry is a single persisted tariff rate
+type Entry struct {
+	Usage   string    `json:"usage" gorm:"column:usage;uniqueIndex:rates_key"`
+	Tariff  string    `json:"tariff" gorm:"column:tariff;uniqueIndex:rates_key"`
+	Start   time.Time `json:"start" gorm:"column:start_time;uniqueIndex:rates_key"`
+	End     time.Time `json:"end" gorm:"column:end_time"`
+	Value   float64   `json:"value" gorm:"column:value"`
+	Updated time.Time `json:"updated" gorm:"column:updated"`
+}
+
+// TableName implements gorm's tabler interface
+func (Entry) TableName() string {
+	return "rates"
+}
+
+func Init() error {
+	return db.Instance.AutoMigrate(new(Entry))
+}
+
+// Persist stores the tariff's rates for the given usage. Existing rates are updated.
+func Persist(usage, tariff string, rr api.Rates) error {
+	if db.Instance == nil || len(rr) == 0 {
+		return nil
+	}
+
+	updated := clk.Now().UTC()
+
+	entries := make([]Entry, 0, len(rr))
+	for _, r := range rr {
+		entries = append(entries, Entry{
+			Usage:   usage,
+			Tariff:  tariff,
+			Start:   r.Start.UTC(),
+			End:     r.End.UTC(),
+			Value:   r.Value,
+			Updated: updated,
+		})
+	}
+
+	return db.Instance.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "usage"}, {Name: "tariff"}, {Name: "start_time"}},
+		DoUpdates: clause.AssignmentColumns([]string{"end_time", "value", "updated"}),
+	}).CreateInBatches(entries, 100).Error
+}
+
+// Prune removes rates exceeding the retention
+func Prune(now time.Time) error {
+	if db.Instance == nil || Retention == 0 {
+		return nil
+	}
+
+	return db.Instance.Where("end_time < ?", now.Add(-Retention).UTC()).Delete(new(Entry)).Error
+}
+
+// Get returns the rates of the given usage and optional tariff overlapping the given time range.
+// If multiple tariffs provided rates for the same period, the most recently updated rate is used.
+func Get(usage, tariff string, from, to time.Time) (api.Rates, error) {
+	tx := db.Instance.Where("usage = ? AND end_time > ? AND start_time < ?", usage, from.UTC(), to.UTC())
+	if tariff != "" {
+		tx = tx.Where("tariff = ?", tariff)
+	}
+
+	var rows []Entry
+	if err := tx.Order("start_time, updated").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	res := make(api.Rates, 0, len(rows))
+	for _, row := range rows {
+		r := api.Rate{
+			Start: row.Start.Local(),
+			End:   row.End.Local(),
+			Value: row.Value,
+		}
+
+		if n := len(res); n > 0 && res[n-1].Start.Equal(r.Start) {
+			res[n-1] = r
+			continue
+		}
+
+		res = append(res, r)
+	}
+
+	return res, nil
+}
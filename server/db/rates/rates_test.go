@@ -0,0 +1,89 @@
+package rates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, Init())
+}
+
+func rate(start time.Time, i int, val float64) api.Rate {
+	return api.Rate{
+		Start: start.Add(time.Duration(i) * time.Hour),
+		End:   start.Add(time.Duration(i+1) * time.Hour),
+		Value: val,
+	}
+}
+
+func TestPersist(t *testing.T) {
+	setup(t)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+
+	require.NoError(t, Persist("grid", "awattar", api.Rates{rate(start, 0, 0.1), rate(start, 1, 0.2)}))
+	require.NoError(t, Persist("feedin", "fixed", api.Rates{rate(start, 0, 0.08)}))
+
+	// updated forecast overwrites existing rates
+	require.NoError(t, Persist("grid", "awattar", api.Rates{rate(start, 1, 0.25), rate(start, 2, 0.3)}))
+
+	rr, err := Get("grid", "", start, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(start, 0, 0.1), rate(start, 1, 0.25), rate(start, 2, 0.3)}, rr)
+
+	// overlapping range
+	rr, err = Get("grid", "", start.Add(90*time.Minute), start.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(start, 1, 0.25)}, rr)
+
+	rr, err = Get("grid", "fixed", start, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, rr)
+}
+
+func TestChangedTariff(t *testing.T) {
+	setup(t)
+
+	mock := clock.NewMock()
+	clk = mock
+	t.Cleanup(func() { clk = clock.New() })
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+
+	require.NoError(t, Persist("grid", "fixed", api.Rates{rate(start, 0, 0.3)}))
+	mock.Add(time.Minute)
+	require.NoError(t, Persist("grid", "tibber", api.Rates{rate(start, 0, 0.2)}))
+
+	// most recently updated tariff wins
+	rr, err := Get("grid", "", start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(start, 0, 0.2)}, rr)
+
+	rr, err = Get("grid", "fixed", start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(start, 0, 0.3)}, rr)
+}
+
+func TestPrune(t *testing.T) {
+	setup(t)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+	require.NoError(t, Persist("grid", "fixed", api.Rates{rate(start, 0, 0.3), rate(start, 48, 0.3)}))
+
+	Retention = 24 * time.Hour
+	t.Cleanup(func() { Retention = 2 * 365 * 24 * time.Hour })
+
+	require.NoError(t, Prune(start.Add(50*time.Hour)))
+
+	rr, err := Get("grid", "", start, start.Add(50*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(start, 48, 0.3)}, rr)
+}
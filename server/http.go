@@ -9,8 +9,11 @@ import (
 	eapi "github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/core"
+	"github.com/evcc-io/evcc/core/driver"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/prioritizer"
+	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/assets"
 	"github.com/evcc-io/evcc/server/eebus"
@@ -27,6 +30,7 @@ type route struct {
 	Method      string
 	Pattern     string
 	HandlerFunc http.HandlerFunc
+	Scope       auth.Role // minimum role, empty for public routes
 }
 
 func (r route) Methods() []string {
@@ -116,7 +120,7 @@ func (s *HTTPd) Router() *mux.Router {
 }
 
 // RegisterSiteHandlers connects the http handlers to the site
-func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param) {
+func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param, authObject auth.Auth) {
 	router := s.Server.Handler.(*mux.Router)
 
 	// api
@@ -127,18 +131,6 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 		handlers.AllowedHeaders([]string{"Content-Type"}),
 	))
 
-	// register sitepower API routes
-	if coreSite, ok := site.(*core.Site); ok {
-		if sitePowerAPI := coreSite.GetSitePowerAPI(); sitePowerAPI != nil {
-			sitePowerAPI.RegisterRoutes(api)
-			fmt.Println("[DEBUG] sitePower API routes registered successfully")
-		} else {
-			fmt.Println("[DEBUG] sitePowerAPI is nil, routes not registered")
-		}
-	} else {
-		fmt.Println("[DEBUG] site type assertion failed")
-	}
-
 	// site api
 	smartCostLimit := func(lp loadpoint.API, limit *float64) {
 		lp.SetSmartCostLimit(limit)
@@ -148,38 +140,60 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 	}
 
 	routes := map[string]route{
-		"health":                  {"GET", "/health", healthHandler(site)},
-		"buffersoc":               {"POST", "/buffersoc/{value:[0-9.]+}", floatHandler(site.SetBufferSoc, site.GetBufferSoc)},
-		"bufferstartsoc":          {"POST", "/bufferstartsoc/{value:[0-9.]+}", floatHandler(site.SetBufferStartSoc, site.GetBufferStartSoc)},
-		"batterydischargecontrol": {"POST", "/batterydischargecontrol/{value:[01truefalse]+}", boolHandler(site.SetBatteryDischargeControl, site.GetBatteryDischargeControl)},
-		"batterygridcharge":       {"POST", "/batterygridchargelimit/{value:-?[0-9.]+}", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
-		"batterygridchargedelete": {"DELETE", "/batterygridchargelimit", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
-		"batterymode":             {"POST", "/batterymode/{value:[a-z]+}", updateBatteryMode(site)},
-		"batterymodedelete":       {"DELETE", "/batterymode", updateBatteryMode(site)},
-		"prioritysoc":             {"POST", "/prioritysoc/{value:[0-9.]+}", floatHandler(site.SetPrioritySoc, site.GetPrioritySoc)},
-		"residualpower":           {"POST", "/residualpower/{value:-?[0-9.]+}", floatHandler(site.SetResidualPower, site.GetResidualPower)},
-		"smartcost":               {"POST", "/smartcostlimit/{value:-?[0-9.]+}", updateSmartCostLimit(site, smartCostLimit)},
-		"smartcostdelete":         {"DELETE", "/smartcostlimit", updateSmartCostLimit(site, smartCostLimit)},
-		"smartfeedin":             {"POST", "/smartfeedinprioritylimit/{value:-?[0-9.]+}", updateSmartCostLimit(site, smartFeedInPriorityLimit)},
-		"smartfeedindelete":       {"DELETE", "/smartfeedinprioritylimit", updateSmartCostLimit(site, smartFeedInPriorityLimit)},
-		"tariff":                  {"GET", "/tariff/{tariff:[a-z]+}", tariffHandler(site)},
-		"sessions":                {"GET", "/sessions", sessionHandler},
-		"updatesession":           {"PUT", "/session/{id:[0-9]+}", updateSessionHandler},
-		"deletesession":           {"DELETE", "/session/{id:[0-9]+}", deleteSessionHandler},
-		"telemetry2":              {"POST", "/settings/telemetry/{value:[01truefalse]+}", boolHandler(telemetry.Enable, telemetry.Enabled)},
+		"health":                  {"GET", "/health", healthHandler(site), auth.RoleViewer},
+		"buffersoc":               {"POST", "/buffersoc/{value:[0-9.]+}", floatHandler(site.SetBufferSoc, site.GetBufferSoc), auth.RoleAdmin},
+		"bufferstartsoc":          {"POST", "/bufferstartsoc/{value:[0-9.]+}", floatHandler(site.SetBufferStartSoc, site.GetBufferStartSoc), auth.RoleAdmin},
+		"batterydischargecontrol": {"POST", "/batterydischargecontrol/{value:[01truefalse]+}", boolHandler(site.SetBatteryDischargeControl, site.GetBatteryDischargeControl), auth.RoleAdmin},
+		"batteryoptimizer":        {"POST", "/batteryoptimizer/{value:[01truefalse]+}", boolHandler(site.SetBatteryOptimizer, site.GetBatteryOptimizer), auth.RoleAdmin},
+		"batterygridcharge":       {"POST", "/batterygridchargelimit/{value:-?[0-9.]+}", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit), auth.RoleAdmin},
+		"batterygridchargedelete": {"DELETE", "/batterygridchargelimit", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit), auth.RoleAdmin},
+		"batterymode":             {"POST", "/batterymode/{value:[a-z]+}", updateBatteryMode(site), auth.RoleAdmin},
+		"batterymodedelete":       {"DELETE", "/batterymode", updateBatteryMode(site), auth.RoleAdmin},
+		"prioritization":          {"POST", "/prioritization/{value:[a-z]+}", handler(prioritizer.StrategyString, site.SetPrioritization, site.GetPrioritization), auth.RoleAdmin},
+		"prioritysoc":             {"POST", "/prioritysoc/{value:[0-9.]+}", floatHandler(site.SetPrioritySoc, site.GetPrioritySoc), auth.RoleAdmin},
+		"residualpower":           {"POST", "/residualpower/{value:-?[0-9.]+}", floatHandler(site.SetResidualPower, site.GetResidualPower), auth.RoleAdmin},
+		"smartcost":               {"POST", "/smartcostlimit/{value:-?[0-9.]+}", updateSmartCostLimit(site, smartCostLimit), auth.RoleAdmin},
+		"smartcostdelete":         {"DELETE", "/smartcostlimit", updateSmartCostLimit(site, smartCostLimit), auth.RoleAdmin},
+		"smartfeedin":             {"POST", "/smartfeedinprioritylimit/{value:-?[0-9.]+}", updateSmartCostLimit(site, smartFeedInPriorityLimit), auth.RoleAdmin},
+		"smartfeedindelete":       {"DELETE", "/smartfeedinprioritylimit", updateSmartCostLimit(site, smartFeedInPriorityLimit), auth.RoleAdmin},
+		"tariff":                  {"GET", "/tariff/{tariff:[a-z]+}", tariffHandler(site), auth.RoleViewer},
+		"tariffhistory":           {"GET", "/tariff/{tariff:[a-z]+}/history", rateHistoryHandler, auth.RoleViewer},
+		"consumptionforecast":     {"GET", "/forecast/consumption", consumptionForecastHandler, auth.RoleViewer},
+		"consumptionaccuracy":     {"GET", "/forecast/consumption/accuracy", consumptionAccuracyHandler, auth.RoleViewer},
+		"solaraccuracy":           {"GET", "/forecast/solar/accuracy", solarAccuracyHandler, auth.RoleViewer},
+		"history":                 {"GET", "/history", historyHandler, auth.RoleViewer},
+		"dimming":                 {"GET", "/dimming", dimmingHandler, auth.RoleViewer},
+		"sessions":                {"GET", "/sessions", sessionHandler, auth.RoleViewer},
+		"updatesession":           {"PUT", "/session/{id:[0-9]+}", updateSessionHandler, auth.RoleAdmin},
+		"deletesession":           {"DELETE", "/session/{id:[0-9]+}", deleteSessionHandler, auth.RoleAdmin},
+		"sessionledger":           {"GET", "/sessions/ledger", sessionLedgerHandler, auth.RoleViewer},
+		"sessionledgersignature":  {"GET", "/sessions/ledger/signature", sessionLedgerSignatureHandler, auth.RoleViewer},
+		"sessionledger2":          {"POST", "/settings/sessionledger/{value:[01truefalse]+}", boolHandler(session.SetLedgerEnabled, session.LedgerEnabled), auth.RoleAdmin},
+		"telemetry2":              {"POST", "/settings/telemetry/{value:[01truefalse]+}", boolHandler(telemetry.Enable, telemetry.Enabled), auth.RoleAdmin},
+		"rejectunknowntags":       {"POST", "/settings/rejectunknowntags/{value:[01truefalse]+}", boolHandler(driver.SetRejectUnknown, driver.RejectUnknown), auth.RoleAdmin},
+	}
+
+	// sitepower api
+	if coreSite, ok := site.(*core.Site); ok {
+		if sitePowerAPI := coreSite.GetSitePowerAPI(); sitePowerAPI != nil {
+			routes["sitepowerrecords"] = route{"GET", "/sitepower/records", sitePowerAPI.GetRecords, auth.RoleViewer}
+			routes["sitepowerlatest"] = route{"GET", "/sitepower/latest", sitePowerAPI.GetLatest, auth.RoleViewer}
+			routes["sitepowercleanup"] = route{"POST", "/sitepower/cleanup", sitePowerAPI.Cleanup, auth.RoleAdmin}
+		}
 	}
 
 	for _, r := range routes {
-		api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+		api.Methods(r.Methods()...).Path(r.Pattern).Handler(ensureScopeHandler(authObject, r.Scope, "")(r.HandlerFunc))
 	}
 
 	// vehicle api
 	vehicles := map[string]route{
-		"minsoc":         {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/minsoc/{value:[0-9]+}", minSocHandler(site)},
-		"limitsoc":       {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/limitsoc/{value:[0-9]+}", limitSocHandler(site)},
-		"plan":           {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc/{value:[0-9]+}/{time:[0-9TZ:.+-]+}", planSocHandler(site)},
-		"plan2":          {"DELETE", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc", planSocRemoveHandler(site)},
-		"repeatingPlans": {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/repeating", addRepeatingPlansHandler(site)},
+		"minsoc":         {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/minsoc/{value:[0-9]+}", minSocHandler(site), auth.RoleDriver},
+		"limitsoc":       {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/limitsoc/{value:[0-9]+}", limitSocHandler(site), auth.RoleDriver},
+		"plan":           {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc/{value:[0-9]+}/{time:[0-9TZ:.+-]+}", planSocHandler(site), auth.RoleDriver},
+		"plan2":          {"DELETE", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc", planSocRemoveHandler(site), auth.RoleDriver},
+		"repeatingPlans": {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/repeating", addRepeatingPlansHandler(site), auth.RoleDriver},
+		"chargeModel":    {"GET", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/chargemodel", chargeModelHandler(site), auth.RoleViewer},
 
 		// config ui
 		// "mode":       {"POST", "/mode/{value:[a-z]+}", chargeModeHandler(v)},
@@ -189,7 +203,7 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 	}
 
 	for _, r := range vehicles {
-		api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+		api.Methods(r.Methods()...).Path(r.Pattern).Handler(ensureScopeHandler(authObject, r.Scope, "")(r.HandlerFunc))
 	}
 
 	// loadpoint api
@@ -197,42 +211,45 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 	for id, lp := range site.Loadpoints() {
 		api := api.PathPrefix(fmt.Sprintf("/loadpoints/%d", id+1)).Subrouter()
 
+		// restrict access by stable config name
+		name := config.InstanceName(config.Loadpoints(), lp)
+
 		routes := map[string]route{
-			"mode":                      {"POST", "/mode/{value:[a-z]+}", handler(eapi.ChargeModeString, pass(lp.SetMode), lp.GetMode)},
-			"limitsoc":                  {"POST", "/limitsoc/{value:[0-9]+}", intHandler(pass(lp.SetLimitSoc), lp.GetLimitSoc)},
-			"limitenergy":               {"POST", "/limitenergy/{value:[0-9.]+}", floatHandler(pass(lp.SetLimitEnergy), lp.GetLimitEnergy)},
-			"mincurrent":                {"POST", "/mincurrent/{value:[0-9.]+}", floatHandler(lp.SetMinCurrent, lp.GetMinCurrent)},
-			"maxcurrent":                {"POST", "/maxcurrent/{value:[0-9.]+}", floatHandler(lp.SetMaxCurrent, lp.GetMaxCurrent)},
-			"phases":                    {"POST", "/phases/{value:[0-9]+}", intHandler(lp.SetPhasesConfigured, lp.GetPhasesConfigured)},
-			"plan":                      {"GET", "/plan", planHandler(lp)},
-			"staticPlanPreview":         {"GET", "/plan/static/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", staticPlanPreviewHandler(lp)},
-			"repeatingPlanPreview":      {"GET", "/plan/repeating/preview/{soc:[0-9]+}/{weekdays:[0-6,]+}/{time:[0-2][0-9]:[0-5][0-9]}/{tz:[a-zA-Z0-9_./:-]+}", repeatingPlanPreviewHandler(lp)},
-			"planenergy":                {"POST", "/plan/energy/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", planEnergyHandler(lp)},
-			"planenergy2":               {"DELETE", "/plan/energy", planRemoveHandler(lp)},
-			"vehicle":                   {"POST", "/vehicle/{name:[a-zA-Z0-9_.:-]+}", vehicleSelectHandler(site, lp)},
-			"vehicle2":                  {"DELETE", "/vehicle", vehicleRemoveHandler(lp)},
-			"vehicleDetect":             {"PATCH", "/vehicle", vehicleDetectHandler(lp)},
-			"remotedemand":              {"POST", "/remotedemand/{demand:[a-z]+}/{source:[0-9a-zA-Z_-]+}", remoteDemandHandler(lp)},
-			"enableThreshold":           {"POST", "/enable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetEnableThreshold), lp.GetEnableThreshold)},
-			"enableDelay":               {"POST", "/enable/delay/{value:[0-9]+}", durationHandler(pass(lp.SetEnableDelay), lp.GetEnableDelay)},
-			"disableThreshold":          {"POST", "/disable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetDisableThreshold), lp.GetDisableThreshold)},
-			"disableDelay":              {"POST", "/disable/delay/{value:[0-9]+}", durationHandler(pass(lp.SetDisableDelay), lp.GetDisableDelay)},
-			"smartCost":                 {"POST", "/smartcostlimit/{value:-?[0-9.]+}", floatPtrHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit)},
-			"smartCostDelete":           {"DELETE", "/smartcostlimit", floatPtrHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit)},
-			"smartFeedInPriority":       {"POST", "/smartfeedinprioritylimit/{value:-?[0-9.]+}", floatPtrHandler(pass(lp.SetSmartFeedInPriorityLimit), lp.GetSmartFeedInPriorityLimit)},
-			"smartFeedInPriorityDelete": {"DELETE", "/smartfeedinprioritylimit", floatPtrHandler(pass(lp.SetSmartFeedInPriorityLimit), lp.GetSmartFeedInPriorityLimit)},
-			"priority":                  {"POST", "/priority/{value:[0-9]+}", intHandler(pass(lp.SetPriority), lp.GetPriority)},
-			"batteryBoost":              {"POST", "/batteryboost/{value:[01truefalse]+}", boolHandler(lp.SetBatteryBoost, func() bool { return lp.GetBatteryBoost() > 0 })},
+			"mode":                      {"POST", "/mode/{value:[a-z]+}", handler(eapi.ChargeModeString, pass(lp.SetMode), lp.GetMode), auth.RoleDriver},
+			"limitsoc":                  {"POST", "/limitsoc/{value:[0-9]+}", intHandler(pass(lp.SetLimitSoc), lp.GetLimitSoc), auth.RoleDriver},
+			"limitenergy":               {"POST", "/limitenergy/{value:[0-9.]+}", floatHandler(pass(lp.SetLimitEnergy), lp.GetLimitEnergy), auth.RoleDriver},
+			"mincurrent":                {"POST", "/mincurrent/{value:[0-9.]+}", floatHandler(lp.SetMinCurrent, lp.GetMinCurrent), auth.RoleAdmin},
+			"maxcurrent":                {"POST", "/maxcurrent/{value:[0-9.]+}", floatHandler(lp.SetMaxCurrent, lp.GetMaxCurrent), auth.RoleAdmin},
+			"phases":                    {"POST", "/phases/{value:[0-9]+}", intHandler(lp.SetPhasesConfigured, lp.GetPhasesConfigured), auth.RoleDriver},
+			"plan":                      {"GET", "/plan", planHandler(lp), auth.RoleViewer},
+			"staticPlanPreview":         {"GET", "/plan/static/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", staticPlanPreviewHandler(lp), auth.RoleViewer},
+			"repeatingPlanPreview":      {"GET", "/plan/repeating/preview/{soc:[0-9]+}/{weekdays:[0-6,]+}/{time:[0-2][0-9]:[0-5][0-9]}/{tz:[a-zA-Z0-9_./:-]+}", repeatingPlanPreviewHandler(lp), auth.RoleViewer},
+			"planenergy":                {"POST", "/plan/energy/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", planEnergyHandler(lp), auth.RoleDriver},
+			"planenergy2":               {"DELETE", "/plan/energy", planRemoveHandler(lp), auth.RoleDriver},
+			"vehicle":                   {"POST", "/vehicle/{name:[a-zA-Z0-9_.:-]+}", vehicleSelectHandler(site, lp), auth.RoleDriver},
+			"vehicle2":                  {"DELETE", "/vehicle", vehicleRemoveHandler(lp), auth.RoleDriver},
+			"vehicleDetect":             {"PATCH", "/vehicle", vehicleDetectHandler(lp), auth.RoleDriver},
+			"remotedemand":              {"POST", "/remotedemand/{demand:[a-z]+}/{source:[0-9a-zA-Z_-]+}", remoteDemandHandler(lp), auth.RoleAdmin},
+			"enableThreshold":           {"POST", "/enable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetEnableThreshold), lp.GetEnableThreshold), auth.RoleAdmin},
+			"enableDelay":               {"POST", "/enable/delay/{value:[0-9]+}", durationHandler(pass(lp.SetEnableDelay), lp.GetEnableDelay), auth.RoleAdmin},
+			"disableThreshold":          {"POST", "/disable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetDisableThreshold), lp.GetDisableThreshold), auth.RoleAdmin},
+			"disableDelay":              {"POST", "/disable/delay/{value:[0-9]+}", durationHandler(pass(lp.SetDisableDelay), lp.GetDisableDelay), auth.RoleAdmin},
+			"smartCost":                 {"POST", "/smartcostlimit/{value:-?[0-9.]+}", floatPtrHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit), auth.RoleDriver},
+			"smartCostDelete":           {"DELETE", "/smartcostlimit", floatPtrHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit), auth.RoleDriver},
+			"smartFeedInPriority":       {"POST", "/smartfeedinprioritylimit/{value:-?[0-9.]+}", floatPtrHandler(pass(lp.SetSmartFeedInPriorityLimit), lp.GetSmartFeedInPriorityLimit), auth.RoleDriver},
+			"smartFeedInPriorityDelete": {"DELETE", "/smartfeedinprioritylimit", floatPtrHandler(pass(lp.SetSmartFeedInPriorityLimit), lp.GetSmartFeedInPriorityLimit), auth.RoleDriver},
+			"priority":                  {"POST", "/priority/{value:[0-9]+}", intHandler(pass(lp.SetPriority), lp.GetPriority), auth.RoleAdmin},
+			"batteryBoost":              {"POST", "/batteryboost/{value:[01truefalse]+}", boolHandler(lp.SetBatteryBoost, func() bool { return lp.GetBatteryBoost() > 0 }), auth.RoleDriver},
 		}
 
 		for _, r := range routes {
-			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(ensureScopeHandler(authObject, r.Scope, name)(r.HandlerFunc))
 		}
 	}
 }
 
 // RegisterSystemHandler provides system level handlers
-func (s *HTTPd) RegisterSystemHandler(site *core.Site, valueChan chan<- util.Param, cache *util.ParamCache, auth auth.Auth, shutdown func()) {
+func (s *HTTPd) RegisterSystemHandler(site *core.Site, valueChan chan<- util.Param, cache *util.ParamCache, authObject auth.Auth, shutdown func()) {
 	router := s.Server.Handler.(*mux.Router)
 
 	// api
@@ -256,11 +273,11 @@ func (s *HTTPd) RegisterSystemHandler(site *core.Site, valueChan chan<- util.Par
 
 	{ // /api
 		routes := map[string]route{
-			"state": {"GET", "/state", stateHandler(cache)},
+			"state": {"GET", "/state", stateHandler(cache), ""},
 		}
 
 		for _, r := range routes {
-			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(ensureAuthHandler(authObject, r.Scope)(r.HandlerFunc))
 		}
 	}
 
@@ -269,36 +286,46 @@ func (s *HTTPd) RegisterSystemHandler(site *core.Site, valueChan chan<- util.Par
 		api := api.PathPrefix("/auth").Subrouter()
 
 		routes := map[string]route{
-			"password": {"PUT", "/password", updatePasswordHandler(auth)},
-			"auth":     {"GET", "/status", authStatusHandler(auth)},
-			"login":    {"POST", "/login", loginHandler(auth)},
-			"logout":   {"POST", "/logout", logoutHandler},
+			"password":    {"PUT", "/password", updatePasswordHandler(authObject), ""},
+			"auth":        {"GET", "/status", authStatusHandler(authObject), ""},
+			"login":       {"POST", "/login", loginHandler(authObject), ""},
+			"logout":      {"POST", "/logout", logoutHandler, ""},
+			"principal":   {"GET", "/me", principalHandler, auth.RoleViewer},
+			"users":       {"GET", "/users", usersHandler(authObject), auth.RoleAdmin},
+			"newuser":     {"POST", "/users", saveUserHandler(authObject), auth.RoleAdmin},
+			"updateuser":  {"PUT", "/users/{user:[a-zA-Z0-9_.@-]+}", saveUserHandler(authObject), auth.RoleAdmin},
+			"deleteuser":  {"DELETE", "/users/{user:[a-zA-Z0-9_.@-]+}", deleteUserHandler(authObject), auth.RoleAdmin},
+			"tokens":      {"GET", "/tokens", tokensHandler(authObject), auth.RoleViewer},
+			"newtoken":    {"POST", "/tokens", createTokenHandler(authObject), auth.RoleViewer},
+			"revoketoken": {"DELETE", "/tokens/{id:[0-9]+}", revokeTokenHandler(authObject), auth.RoleViewer},
 		}
 
 		for _, r := range routes {
-			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(ensureAuthHandler(authObject, r.Scope)(r.HandlerFunc))
 		}
 	}
 
 	{ // api/config
 		api := api.PathPrefix("/config").Subrouter()
-		api.Use(ensureAuthHandler(auth))
 
 		routes := map[string]route{
-			"templates":          {"GET", "/templates/{class:[a-z]+}", templatesHandler},
-			"products":           {"GET", "/products/{class:[a-z]+}", productsHandler},
-			"devices":            {"GET", "/devices/{class:[a-z]+}", devicesConfigHandler},
-			"device":             {"GET", "/devices/{class:[a-z]+}/{id:[0-9.]+}", deviceConfigHandler},
-			"devicestatus":       {"GET", "/devices/{class:[a-z]+}/{name:[a-zA-Z0-9_.:-]+}/status", deviceStatusHandler},
-			"dirty":              {"GET", "/dirty", getHandler(ConfigDirty)},
-			"newdevice":          {"POST", "/devices/{class:[a-z]+}", newDeviceHandler},
-			"updatedevice":       {"PUT", "/devices/{class:[a-z]+}/{id:[0-9.]+}", updateDeviceHandler},
-			"deletedevice":       {"DELETE", "/devices/{class:[a-z]+}/{id:[0-9.]+}", deleteDeviceHandler(site)},
-			"testconfig":         {"POST", "/test/{class:[a-z]+}", testConfigHandler},
-			"testmerged":         {"POST", "/test/{class:[a-z]+}/merge/{id:[0-9.]+}", testConfigHandler},
-			"interval":           {"POST", "/interval/{value:[0-9.]+}", settingsSetDurationHandler(keys.Interval)},
-			"updatesponsortoken": {"POST", "/sponsortoken", updateSponsortokenHandler},
-			"deletesponsortoken": {"DELETE", "/sponsortoken", deleteSponsorTokenHandler},
+			"templates":          {"GET", "/templates/{class:[a-z]+}", templatesHandler, auth.RoleAdmin},
+			"products":           {"GET", "/products/{class:[a-z]+}", productsHandler, auth.RoleAdmin},
+			"devices":            {"GET", "/devices/{class:[a-z]+}", devicesConfigHandler, auth.RoleAdmin},
+			"device":             {"GET", "/devices/{class:[a-z]+}/{id:[0-9.]+}", deviceConfigHandler, auth.RoleAdmin},
+			"devicestatus":       {"GET", "/devices/{class:[a-z]+}/{name:[a-zA-Z0-9_.:-]+}/status", deviceStatusHandler, auth.RoleAdmin},
+			"dirty":              {"GET", "/dirty", getHandler(ConfigDirty), auth.RoleAdmin},
+			"newdevice":          {"POST", "/devices/{class:[a-z]+}", newDeviceHandler, auth.RoleAdmin},
+			"updatedevice":       {"PUT", "/devices/{class:[a-z]+}/{id:[0-9.]+}", updateDeviceHandler, auth.RoleAdmin},
+			"deletedevice":       {"DELETE", "/devices/{class:[a-z]+}/{id:[0-9.]+}", deleteDeviceHandler(site), auth.RoleAdmin},
+			"testconfig":         {"POST", "/test/{class:[a-z]+}", testConfigHandler, auth.RoleAdmin},
+			"testmerged":         {"POST", "/test/{class:[a-z]+}/merge/{id:[0-9.]+}", testConfigHandler, auth.RoleAdmin},
+			"interval":           {"POST", "/interval/{value:[0-9.]+}", settingsSetDurationHandler(keys.Interval), auth.RoleAdmin},
+			"drivers":            {"GET", "/drivers", driversHandler, auth.RoleAdmin},
+			"savedriver":         {"POST", "/drivers", saveDriverHandler, auth.RoleAdmin},
+			"deletedriver":       {"DELETE", "/drivers/{tag:[a-zA-Z0-9_.:-]+}", deleteDriverHandler, auth.RoleAdmin},
+			"updatesponsortoken": {"POST", "/sponsortoken", updateSponsortokenHandler, auth.RoleAdmin},
+			"deletesponsortoken": {"DELETE", "/sponsortoken", deleteSponsorTokenHandler, auth.RoleAdmin},
 		}
 
 		// yaml handlers
@@ -311,9 +338,9 @@ func (s *HTTPd) RegisterSystemHandler(site *core.Site, valueChan chan<- util.Par
 			keys.Circuits:    func() (any, any) { return []map[string]any{}, []config.Named{} },             // slice
 		} {
 			other, struc := fun()
-			routes[key] = route{Method: "GET", Pattern: "/" + key, HandlerFunc: settingsGetStringHandler(key)}
-			routes["update"+key] = route{Method: "POST", Pattern: "/" + key, HandlerFunc: settingsSetYamlHandler(key, other, struc)}
-			routes["delete"+key] = route{Method: "DELETE", Pattern: "/" + key, HandlerFunc: settingsDeleteHandler(key)}
+			routes[key] = route{Method: "GET", Pattern: "/" + key, HandlerFunc: settingsGetStringHandler(key), Scope: auth.RoleAdmin}
+			routes["update"+key] = route{Method: "POST", Pattern: "/" + key, HandlerFunc: settingsSetYamlHandler(key, other, struc), Scope: auth.RoleAdmin}
+			routes["delete"+key] = route{Method: "DELETE", Pattern: "/" + key, HandlerFunc: settingsDeleteHandler(key), Scope: auth.RoleAdmin}
 		}
 
 		// json handlers
@@ -322,54 +349,53 @@ func (s *HTTPd) RegisterSystemHandler(site *core.Site, valueChan chan<- util.Par
 			keys.Mqtt:    func() any { return new(globalconfig.Mqtt) },    // has default
 			keys.Influx:  func() any { return new(globalconfig.Influx) },
 		} {
-			routes["update"+key] = route{Method: "POST", Pattern: "/" + key, HandlerFunc: settingsSetJsonHandler(key, valueChan, fun)}
-			routes["delete"+key] = route{Method: "DELETE", Pattern: "/" + key, HandlerFunc: settingsDeleteJsonHandler(key, valueChan, fun())}
+			routes["update"+key] = route{Method: "POST", Pattern: "/" + key, HandlerFunc: settingsSetJsonHandler(key, valueChan, fun), Scope: auth.RoleAdmin}
+			routes["delete"+key] = route{Method: "DELETE", Pattern: "/" + key, HandlerFunc: settingsDeleteJsonHandler(key, valueChan, fun()), Scope: auth.RoleAdmin}
 		}
 
 		for _, r := range routes {
-			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(ensureAuthHandler(authObject, r.Scope)(r.HandlerFunc))
 		}
 
 		// site
 		for _, r := range map[string]route{
-			"site":       {"GET", "/site", siteHandler(site)},
-			"updatesite": {"PUT", "/site", updateSiteHandler(site)},
+			"site":       {"GET", "/site", siteHandler(site), auth.RoleAdmin},
+			"updatesite": {"PUT", "/site", updateSiteHandler(site), auth.RoleAdmin},
 		} {
-			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(ensureAuthHandler(authObject, r.Scope)(r.HandlerFunc))
 		}
 
 		// loadpoints
 		for _, r := range map[string]route{
-			"loadpoints":      {"GET", "/loadpoints", loadpointsConfigHandler()},
-			"loadpoint":       {"GET", "/loadpoints/{id:[0-9.]+}", loadpointConfigHandler()},
-			"updateloadpoint": {"PUT", "/loadpoints/{id:[0-9.]+}", updateLoadpointHandler()},
-			"deleteloadpoint": {"DELETE", "/loadpoints/{id:[0-9.]+}", deleteLoadpointHandler()},
-			"newloadpoint":    {"POST", "/loadpoints", newLoadpointHandler()},
+			"loadpoints":      {"GET", "/loadpoints", loadpointsConfigHandler(), auth.RoleAdmin},
+			"loadpoint":       {"GET", "/loadpoints/{id:[0-9.]+}", loadpointConfigHandler(), auth.RoleAdmin},
+			"updateloadpoint": {"PUT", "/loadpoints/{id:[0-9.]+}", updateLoadpointHandler(), auth.RoleAdmin},
+			"deleteloadpoint": {"DELETE", "/loadpoints/{id:[0-9.]+}", deleteLoadpointHandler(), auth.RoleAdmin},
+			"newloadpoint":    {"POST", "/loadpoints", newLoadpointHandler(), auth.RoleAdmin},
 		} {
-			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(ensureAuthHandler(authObject, r.Scope)(r.HandlerFunc))
 		}
 	}
 
 	{ // api/system
 		api := api.PathPrefix("/system").Subrouter()
-		api.Use(ensureAuthHandler(auth))
 
 		// system api
 		routes := map[string]route{
-			"log":        {"GET", "/log", logHandler},
-			"logareas":   {"GET", "/log/areas", logAreasHandler},
-			"clearcache": {"DELETE", "/cache", clearCacheHandler},
-			"backup":     {"POST", "/backup", getBackup(auth)},
-			"restore":    {"POST", "/restore", restoreDatabase(auth, shutdown)},
-			"reset":      {"POST", "/reset", resetDatabase(auth, shutdown)},
+			"log":        {"GET", "/log", logHandler, auth.RoleAdmin},
+			"logareas":   {"GET", "/log/areas", logAreasHandler, auth.RoleAdmin},
+			"clearcache": {"DELETE", "/cache", clearCacheHandler, auth.RoleAdmin},
+			"backup":     {"POST", "/backup", getBackup(authObject), auth.RoleAdmin},
+			"restore":    {"POST", "/restore", restoreDatabase(authObject, shutdown), auth.RoleAdmin},
+			"reset":      {"POST", "/reset", resetDatabase(authObject, shutdown), auth.RoleAdmin},
 			"shutdown": {"POST", "/shutdown", func(w http.ResponseWriter, r *http.Request) {
 				shutdown()
 				w.WriteHeader(http.StatusNoContent)
-			}},
+			}, auth.RoleAdmin},
 		}
 
 		for _, r := range routes {
-			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+			api.Methods(r.Methods()...).Path(r.Pattern).Handler(ensureAuthHandler(authObject, r.Scope)(r.HandlerFunc))
 		}
 	}
 }
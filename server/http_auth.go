@@ -1,8 +1,12 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,9 +22,18 @@ type updatePasswordRequest struct {
 }
 
 type loginRequest struct {
+	Username string `json:"username,omitempty"` // empty for admin
 	Password string `json:"password"`
 }
 
+type principalContextKey struct{}
+
+// principalFromContext returns the authenticated caller attached by the auth middleware
+func principalFromContext(ctx context.Context) *auth.Principal {
+	p, _ := ctx.Value(principalContextKey{}).(*auth.Principal)
+	return p
+}
+
 func updatePasswordHandler(authObject auth.Auth) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if authObject.GetAuthMode() == auth.Locked {
@@ -75,7 +88,7 @@ func jwtFromRequest(r *http.Request) string {
 	return ""
 }
 
-// authStatusHandler login status (true/false) based on jwt token. Error if admin password is not configured
+// authStatusHandler admin login status (true/false) based on jwt token. Error if admin password is not configured
 func authStatusHandler(authObject auth.Auth) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if authObject.GetAuthMode() == auth.Disabled {
@@ -94,8 +107,8 @@ func authStatusHandler(authObject auth.Auth) http.HandlerFunc {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		ok, err := authObject.ValidateJwtToken(jwtFromRequest(r))
-		if err != nil || !ok {
+		p, err := authObject.Authenticate(jwtFromRequest(r))
+		if err != nil || !p.Allows(auth.RoleAdmin) {
 			w.Write([]byte("false"))
 			return
 		}
@@ -116,13 +129,25 @@ func loginHandler(authObject auth.Auth) http.HandlerFunc {
 			return
 		}
 
-		if !authObject.IsAdminPasswordValid(req.Password) {
-			http.Error(w, "Invalid password", http.StatusUnauthorized)
-			return
+		lifetime := time.Hour * 24 * 90 // 90 day valid
+
+		var tokenString string
+		var err error
+
+		if req.Username == "" {
+			if !authObject.IsAdminPasswordValid(req.Password) {
+				http.Error(w, "Invalid password", http.StatusUnauthorized)
+				return
+			}
+			tokenString, err = authObject.GenerateJwtToken(lifetime)
+		} else {
+			if !authObject.IsUserPasswordValid(req.Username, req.Password) {
+				http.Error(w, "Invalid user or password", http.StatusUnauthorized)
+				return
+			}
+			tokenString, err = authObject.GenerateUserJwtToken(req.Username, lifetime)
 		}
 
-		lifetime := time.Hour * 24 * 90 // 90 day valid
-		tokenString, err := authObject.GenerateJwtToken(lifetime)
 		if err != nil {
 			http.Error(w, "Failed to generate JWT token.", http.StatusInternalServerError)
 			return
@@ -147,28 +172,239 @@ func logoutHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func ensureAuthHandler(authObject auth.Auth) mux.MiddlewareFunc {
+// ensureAuthHandler requires the caller to be authenticated with at least the given role.
+// Routes without role are public. The principal is attached to the request context.
+func ensureAuthHandler(authObject auth.Auth, role auth.Role) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if authObject.GetAuthMode() == auth.Disabled {
+			if role == "" {
 				next.ServeHTTP(w, r)
 				return
 			}
 
+			if authObject.GetAuthMode() == auth.Disabled {
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalContextKey{}, adminPrincipal)))
+				return
+			}
+
 			if authObject.GetAuthMode() == auth.Locked {
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
 
-			// check jwt token
-			ok, err := authObject.ValidateJwtToken(jwtFromRequest(r))
-			if !ok || err != nil {
+			// check jwt or api token
+			p, err := authObject.Authenticate(jwtFromRequest(r))
+			if err != nil {
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
 
+			if !p.Allows(role) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
 			// all clear, continue
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalContextKey{}, p)))
 		})
 	}
 }
+
+// ensureScopeHandler enforces the role and the loadpoint (config name, empty for none) and vehicle restrictions of site routes.
+// Unless user accounts exist, anonymous callers keep unrestricted access. Otherwise they are viewers.
+func ensureScopeHandler(authObject auth.Auth, role auth.Role, loadpoint string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if authObject.GetAuthMode() != auth.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var p *auth.Principal
+
+			if token := jwtFromRequest(r); token != "" {
+				var err error
+				if p, err = authObject.Authenticate(token); err != nil {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+			} else if !authObject.HasUsers() {
+				next.ServeHTTP(w, r)
+				return
+			} else {
+				p = anonymousPrincipal
+			}
+
+			if !p.Allows(role) || loadpoint != "" && !p.AllowsLoadpoint(loadpoint) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			if name, ok := mux.Vars(r)["name"]; ok && !p.AllowsVehicle(name) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalContextKey{}, p)))
+		})
+	}
+}
+
+var (
+	adminPrincipal     = &auth.Principal{User: "admin", Role: auth.RoleAdmin, AllLoadpoints: true, AllVehicles: true}
+	anonymousPrincipal = &auth.Principal{Role: auth.RoleViewer, AllLoadpoints: true, AllVehicles: true}
+)
+
+// principalHandler returns the authenticated caller
+func principalHandler(w http.ResponseWriter, r *http.Request) {
+	jsonWrite(w, principalFromContext(r.Context()))
+}
+
+type userRequest struct {
+	auth.User
+	Password string `json:"password,omitempty"`
+}
+
+// usersHandler returns all user accounts
+func usersHandler(authObject auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := authObject.Users()
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		jsonWrite(w, res)
+	}
+}
+
+// saveUserHandler creates or updates a user account
+func saveUserHandler(authObject auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req userRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if name, ok := mux.Vars(r)["user"]; ok {
+			req.Name = name
+		}
+
+		if err := authObject.SaveUser(req.User, req.Password); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonWrite(w, req.User)
+	}
+}
+
+// deleteUserHandler deletes a user account and its api tokens
+func deleteUserHandler(authObject auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := authObject.DeleteUser(mux.Vars(r)["user"]); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, auth.ErrUnknownUser) {
+				status = http.StatusNotFound
+			}
+			jsonError(w, status, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// tokensHandler returns the caller's api tokens or all tokens for admins
+func tokensHandler(authObject auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p := principalFromContext(r.Context())
+
+		var user string
+		if !p.Allows(auth.RoleAdmin) {
+			user = p.User
+		}
+
+		res, err := authObject.Tokens(user)
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		jsonWrite(w, res)
+	}
+}
+
+// createTokenHandler creates an api token owned by the caller. The secret is only returned once.
+func createTokenHandler(authObject auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p := principalFromContext(r.Context())
+		if p.Token != 0 {
+			jsonError(w, http.StatusForbidden, errors.New("api tokens cannot create tokens"))
+			return
+		}
+
+		var req auth.Token
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		secret, token, err := authObject.CreateToken(p, req)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, auth.ErrScope) {
+				status = http.StatusForbidden
+			}
+			jsonError(w, status, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		jsonWrite(w, struct {
+			auth.Token
+			Secret string `json:"secret"`
+		}{
+			Token:  token,
+			Secret: secret,
+		})
+	}
+}
+
+// revokeTokenHandler revokes an api token owned by the caller or any token for admins
+func revokeTokenHandler(authObject auth.Auth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p := principalFromContext(r.Context())
+
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if !p.Allows(auth.RoleAdmin) {
+			tokens, err := authObject.Tokens(p.User)
+			if err != nil {
+				jsonError(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			if !slices.ContainsFunc(tokens, func(t auth.Token) bool { return t.ID == uint(id) }) {
+				jsonError(w, http.StatusNotFound, auth.ErrUnknownToken)
+				return
+			}
+		}
+
+		if err := authObject.RevokeToken(uint(id)); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, auth.ErrUnknownToken) {
+				status = http.StatusNotFound
+			}
+			jsonError(w, status, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util/auth"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEnsureScopeHandler(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, auth.Init())
+
+	ctrl := gomock.NewController(t)
+	mock := settings.NewMockAPI(ctrl)
+	mock.EXPECT().String(keys.JwtSecret).Return("somesecret", nil).AnyTimes()
+	authObject := auth.NewMock(mock)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/loadpoints/1/plan").Handler(ensureScopeHandler(authObject, auth.RoleViewer, "lp-1")(ok))
+	router.Methods("POST").Path("/loadpoints/1/mode/{value}").Handler(ensureScopeHandler(authObject, auth.RoleDriver, "lp-1")(ok))
+	router.Methods("POST").Path("/loadpoints/2/mode/{value}").Handler(ensureScopeHandler(authObject, auth.RoleDriver, "lp-2")(ok))
+	router.Methods("POST").Path("/vehicles/{name}/minsoc/{value}").Handler(ensureScopeHandler(authObject, auth.RoleDriver, "")(ok))
+	router.Methods("POST").Path("/batterymode/{value}").Handler(ensureScopeHandler(authObject, auth.RoleAdmin, "")(ok))
+
+	status := func(method, path, token string) int {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// without users, anonymous access is unrestricted
+	assert.Equal(t, http.StatusOK, status("POST", "/batterymode/hold", ""))
+	assert.Equal(t, http.StatusUnauthorized, status("POST", "/batterymode/hold", "invalid"))
+
+	require.NoError(t, authObject.SaveUser(auth.User{Name: "alice", Role: auth.RoleDriver, Loadpoints: []string{"lp-1"}, Vehicles: []string{"car1"}}, "secret"))
+	jwt, err := authObject.GenerateUserJwtToken("alice", time.Hour)
+	require.NoError(t, err)
+
+	// with users, anonymous callers are viewers
+	assert.Equal(t, http.StatusOK, status("GET", "/loadpoints/1/plan", ""))
+	assert.Equal(t, http.StatusForbidden, status("POST", "/loadpoints/1/mode/pv", ""))
+
+	// drivers are restricted to their loadpoints and vehicles
+	assert.Equal(t, http.StatusOK, status("POST", "/loadpoints/1/mode/pv", jwt))
+	assert.Equal(t, http.StatusForbidden, status("POST", "/loadpoints/2/mode/pv", jwt))
+	assert.Equal(t, http.StatusOK, status("POST", "/vehicles/car1/minsoc/20", jwt))
+	assert.Equal(t, http.StatusForbidden, status("POST", "/vehicles/car2/minsoc/20", jwt))
+	assert.Equal(t, http.StatusForbidden, status("POST", "/batterymode/hold", jwt))
+
+	// api tokens narrow the owner's scope
+	p, err := authObject.Authenticate(jwt)
+	require.NoError(t, err)
+	token, _, err := authObject.CreateToken(p, auth.Token{Role: auth.RoleViewer, AllLoadpoints: true, AllVehicles: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, status("GET", "/loadpoints/1/plan", token))
+	assert.Equal(t, http.StatusForbidden, status("POST", "/loadpoints/1/mode/pv", token))
+
+	// admin
+	admin, err := authObject.GenerateJwtToken(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status("POST", "/batterymode/hold", admin))
+	assert.Equal(t, http.StatusOK, status("POST", "/loadpoints/2/mode/pv", admin))
+}
+
+func TestEnsureAuthHandler(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, auth.Init())
+
+	ctrl := gomock.NewController(t)
+	mock := settings.NewMockAPI(ctrl)
+	mock.EXPECT().String(keys.JwtSecret).Return("somesecret", nil).AnyTimes()
+	authObject := auth.NewMock(mock)
+
+	require.NoError(t, authObject.SaveUser(auth.User{Name: "alice", Role: auth.RoleDriver}, "secret"))
+	jwt, err := authObject.GenerateUserJwtToken("alice", time.Hour)
+	require.NoError(t, err)
+
+	handler := ensureAuthHandler(authObject, auth.RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, tc := range []struct {
+		token  string
+		status int
+	}{
+		{"", http.StatusUnauthorized},
+		{jwt, http.StatusForbidden},
+		{must(authObject.GenerateJwtToken(time.Hour)), http.StatusOK},
+	} {
+		req := httptest.NewRequest("POST", "/system/reset", nil)
+		req.AddCookie(&http.Cookie{Name: authCookieName, Value: tc.token})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, tc.status, w.Code)
+	}
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/charger"
 	"github.com/evcc-io/evcc/core/circuit"
+	"github.com/evcc-io/evcc/core/health"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/meter"
 	"github.com/evcc-io/evcc/util"
@@ -244,6 +245,13 @@ func deviceStatusHandler(w http.ResponseWriter, r *http.Request) {
 
 	case templates.Circuit:
 		instance, err = deviceStatus(name, config.Circuits())
+
+	case templates.Tariff:
+		// tariffs are identified by configured name and only provide health status
+		if _, err = health.Get(class.String(), name); err != nil {
+			jsonError(w, http.StatusNotFound, err)
+			return
+		}
 	}
 
 	if err != nil {
@@ -251,7 +259,17 @@ func deviceStatusHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jsonWrite(w, testInstance(instance))
+	res := testInstance(instance)
+
+	if status, err := health.Get(class.String(), name); err == nil {
+		tr := testResult{Value: status}
+		if !status.Healthy {
+			tr.Error = status.LastError
+		}
+		res["health"] = tr
+	}
+
+	jsonWrite(w, res)
 }
 
 func newDevice[T any](ctx context.Context, class templates.Class, req configReq, newFromConf newFromConfFunc[T], h config.Handler[T]) (*config.Config, error) {
@@ -532,6 +550,8 @@ func deleteDeviceHandler(site site.API) func(w http.ResponseWriter, r *http.Requ
 			return
 		}
 
+		health.Remove(class.String(), config.NameForID(id))
+
 		res := struct {
 			ID int `json:"id"`
 		}{
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/core/dimming"
+	"github.com/evcc-io/evcc/server/db"
+)
+
+// dimmingRange is the default time range of the dimming report
+const dimmingRange = 365 * 24 * time.Hour
+
+// dimmingHandler returns the consumption limits received from the grid operator and their compliance
+func dimmingHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	from, to, err := parseTimeRange(r, dimmingRange)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := dimming.Report(from, to)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "csv" {
+		csvResult(r.Context(), w, &res, "dimming")
+		return
+	}
+
+	jsonWrite(w, struct {
+		Summary dimming.Summary `json:"summary"`
+		Periods dimming.Periods `json:"periods"`
+	}{
+		Summary: res.Summary(),
+		Periods: res,
+	})
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/evcc-io/evcc/core/driver"
+	"github.com/gorilla/mux"
+)
+
+// driversHandler returns the driver registry
+func driversHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := driver.All()
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonWrite(w, res)
+}
+
+// saveDriverHandler creates or updates a driver
+func saveDriverHandler(w http.ResponseWriter, r *http.Request) {
+	var req driver.Driver
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := driver.Save(req)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonWrite(w, res)
+}
+
+// deleteDriverHandler removes a driver
+func deleteDriverHandler(w http.ResponseWriter, r *http.Request) {
+	if err := driver.Delete(mux.Vars(r)["tag"]); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, driver.ErrUnknownTag) {
+			status = http.StatusNotFound
+		}
+		jsonError(w, status, err)
+		return
+	}
+
+	jsonWrite(w, struct{}{})
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/core/metrics"
+	"github.com/evcc-io/evcc/server/db"
+)
+
+// parseTimeRange parses the optional from/to query parameters with given default duration until now
+func parseTimeRange(r *http.Request, def time.Duration) (time.Time, time.Time, error) {
+	to := time.Now()
+	from := to.Add(-def)
+
+	query := r.URL.Query()
+
+	if val := query.Get("from"); val != "" {
+		ts, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return from, to, err
+		}
+		from = ts
+	}
+
+	if val := query.Get("to"); val != "" {
+		ts, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return from, to, err
+		}
+		to = ts
+	}
+
+	if !from.Before(to) {
+		return from, to, errors.New("invalid time range")
+	}
+
+	return from, to, nil
+}
+
+// consumptionForecastHandler returns the household consumption forecast
+func consumptionForecastHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	rates, err := metrics.Forecast(metrics.Household, time.Now(), 48*time.Hour)
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	jsonWrite(w, rates)
+}
+
+// consumptionAccuracyHandler returns the household consumption forecast accuracy
+func consumptionAccuracyHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	from, to, err := parseTimeRange(r, 30*24*time.Hour)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := metrics.GetAccuracy(metrics.Household, from, to)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonWrite(w, res)
+}
+
+// solarAccuracyHandler returns the solar forecast accuracy, per hour of day and the learned correction factors
+func solarAccuracyHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	from, to, err := parseTimeRange(r, 30*24*time.Hour)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	total, err := metrics.GetAccuracy(metrics.Solar, from, to)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	hourly, err := metrics.GetHourlyAccuracy(metrics.Solar, from, to)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	correction, err := metrics.Correction(metrics.Solar, time.Now())
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := struct {
+		metrics.Accuracy
+		Hourly     []metrics.Accuracy `json:"hourly"`
+		Correction []float64          `json:"correction"`
+	}{
+		Accuracy:   total,
+		Hourly:     hourly,
+		Correction: correction,
+	}
+
+	jsonWrite(w, res)
+}
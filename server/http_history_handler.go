@@ -0,0 +1,86 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/history"
+	"github.com/evcc-io/evcc/server/db/rates"
+	"github.com/gorilla/mux"
+)
+
+// historyRange is the default time range per resolution
+var historyRange = map[history.Resolution]time.Duration{
+	history.Slot:  24 * time.Hour,
+	history.Hour:  7 * 24 * time.Hour,
+	history.Day:   31 * 24 * time.Hour,
+	history.Month: 365 * 24 * time.Hour,
+}
+
+// historyHandler returns the per-meter energy history
+func historyHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	res := history.Hour
+	if val := r.URL.Query().Get("resolution"); val != "" {
+		var err error
+		if res, err = history.ParseResolution(val); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	from, to, err := parseTimeRange(r, historyRange[res])
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	series, err := history.Get(r.URL.Query().Get("group"), res, from, to)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonWrite(w, series)
+}
+
+// rateHistoryHandler returns the persisted rates of a tariff
+func rateHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	usage, err := api.TariffUsageString(mux.Vars(r)["tariff"])
+	if err != nil {
+		jsonError(w, http.StatusNotFound, err)
+		return
+	}
+
+	from, to, err := parseTimeRange(r, 7*24*time.Hour)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rr, err := rates.Get(usage.String(), r.URL.Query().Get("name"), from, to)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := struct {
+		Rates api.Rates `json:"rates"`
+	}{
+		Rates: rr,
+	}
+
+	jsonWrite(w, res)
+}
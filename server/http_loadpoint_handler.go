@@ -52,6 +52,7 @@ func planHandler(lp loadpoint.API) http.HandlerFunc {
 		precondition := lp.GetPlanPreCondDuration()
 		requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
 		plan := lp.GetPlan(planTime, requiredDuration, precondition)
+		shortfall := lp.GetPlanShortfall(planTime, requiredDuration, precondition)
 
 		res := struct {
 			PlanId       int       `json:"planId"`
@@ -60,6 +61,8 @@ func planHandler(lp loadpoint.API) http.HandlerFunc {
 			Precondition int64     `json:"precondition"`
 			Plan         api.Rates `json:"plan"`
 			Power        float64   `json:"power"`
+			Feasible     bool      `json:"feasible"`
+			Shortfall    int64     `json:"shortfall"`
 		}{
 			PlanId:       id,
 			PlanTime:     planTime,
@@ -67,6 +70,8 @@ func planHandler(lp loadpoint.API) http.HandlerFunc {
 			Precondition: int64(precondition.Seconds()),
 			Plan:         plan,
 			Power:        maxPower,
+			Feasible:     shortfall <= 0,
+			Shortfall:    int64(shortfall.Seconds()),
 		}
 
 		jsonWrite(w, res)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/evcc-io/evcc/api"
@@ -57,6 +58,10 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if user := r.URL.Query().Get("user"); user != "" {
+		push("user = ?", user)
+	}
+
 	// TODO support other databases than Sqlite
 	query := strings.Join(append([]string{"charged_kwh>=0.05"}, cond...), " AND ")
 	if txn := db.Instance.Where(query, args...).Order("created DESC").Find(&res); txn.Error != nil {
@@ -102,6 +107,10 @@ func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
+	if !checkLedgered(w, id) {
+		return
+	}
+
 	if txn := db.Instance.Table("sessions").Delete(&res, id); txn.Error != nil {
 		jsonError(w, http.StatusBadRequest, txn.Error)
 		return
@@ -119,6 +128,10 @@ func updateSessionHandler(w http.ResponseWriter, r *http.Request) {
 
 	id := mux.Vars(r)["id"]
 
+	if !checkLedgered(w, id) {
+		return
+	}
+
 	var data map[string]interface{}
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		jsonError(w, http.StatusBadRequest, errors.New("invalid JSON"))
@@ -143,3 +156,72 @@ func updateSessionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// checkLedgered rejects modification of sessions recorded in the ledger
+func checkLedgered(w http.ResponseWriter, id string) bool {
+	val, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return false
+	}
+
+	ledgered, err := session.Ledgered(uint(val))
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return false
+	}
+
+	if ledgered {
+		jsonError(w, http.StatusForbidden, errors.New("session is recorded in ledger and cannot be modified"))
+		return false
+	}
+
+	return true
+}
+
+// sessionLedgerHandler returns the session ledger export
+func sessionLedgerHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	res, err := session.LedgerEntries()
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "csv" {
+		csvResult(r.Context(), w, &res, "session-ledger")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="session-ledger.json"`)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// sessionLedgerSignatureHandler returns the detached signature of the session ledger
+func sessionLedgerSignatureHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	entries, err := session.LedgerEntries()
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res, err := entries.Sign()
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="session-ledger.sig"`)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
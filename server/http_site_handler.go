@@ -297,11 +297,19 @@ func tariffHandler(site site.API) http.HandlerFunc {
 		}
 
 		res := struct {
-			Rates api.Rates `json:"rates"`
+			Rates      api.Rates             `json:"rates"`
+			Components []api.TariffComponent `json:"components,omitempty"`
 		}{
 			Rates: rates,
 		}
 
+		if tc, ok := t.(api.TariffComponents); ok {
+			if res.Components, err = tc.Components(); err != nil && !errors.Is(err, api.ErrNotAvailable) {
+				jsonError(w, http.StatusNotFound, err)
+				return
+			}
+		}
+
 		jsonWrite(w, res)
 	}
 }
@@ -170,3 +170,18 @@ func planSocRemoveHandler(site site.API) http.HandlerFunc {
 		jsonWrite(w, res)
 	}
 }
+
+// chargeModelHandler returns the learned charge model
+func chargeModelHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		v, err := site.Vehicles().ByName(vars["name"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonWrite(w, v.GetChargeModel())
+	}
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"cmp"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/auth"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsIgnore are time series and aggregates not suitable as gauges
+var metricsIgnore = []string{keys.Forecast, keys.BatterySchedule, keys.Statistics}
+
+// metricsMaps are site values keyed by device name, exported with their own namespace and label
+var metricsMaps = map[string]string{
+	keys.Circuits: "circuit",
+	keys.Vehicles: "vehicle",
+}
+
+// RegisterMetricsHandler exposes prometheus metrics including the site and loadpoint values published to the UI.
+// If requireAuth is set, viewer access is required unless authentication is disabled.
+func (s *HTTPd) RegisterMetricsHandler(cache *util.ParamCache, authObject auth.Auth, requireAuth bool) {
+	prometheus.MustRegister(&cacheCollector{cache: cache})
+
+	var role auth.Role
+	if requireAuth {
+		role = auth.RoleViewer
+	}
+
+	s.Router().Handle("/metrics", ensureAuthHandler(authObject, role)(promhttp.Handler()))
+}
+
+// cacheCollector exports numeric values of the param cache as gauges
+type cacheCollector struct {
+	cache *util.ParamCache
+}
+
+// Describe implements prometheus.Collector. Metrics are dynamic, hence the collector is unchecked.
+func (c *cacheCollector) Describe(chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	params := c.cache.All()
+	slices.SortFunc(params, func(a, b util.Param) int {
+		return cmp.Compare(a.UniqueID(), b.UniqueID())
+	})
+
+	titles := make(map[int]string)
+	for _, p := range params {
+		if p.Loadpoint != nil && p.Key == keys.Title {
+			titles[*p.Loadpoint], _ = p.Val.(string)
+		}
+	}
+
+	g := &gaugeCollector{ch: ch, labels: make(map[string][]string), seen: make(map[string]bool)}
+
+	for _, p := range params {
+		if slices.Contains(metricsIgnore, p.Key) {
+			continue
+		}
+
+		if p.Loadpoint != nil {
+			labels := []metricLabel{{"loadpoint", strconv.Itoa(*p.Loadpoint + 1)}, {"title", titles[*p.Loadpoint]}}
+			g.collect("evcc_loadpoint_"+snakeCase(p.Key), labels, reflect.ValueOf(p.Val))
+			continue
+		}
+
+		if label, ok := metricsMaps[p.Key]; ok {
+			g.collectMap("evcc_"+label, label, nil, reflect.ValueOf(p.Val))
+			continue
+		}
+
+		g.collect("evcc_site_"+snakeCase(p.Key), nil, reflect.ValueOf(p.Val))
+	}
+}
+
+type metricLabel struct {
+	name, value string
+}
+
+// gaugeCollector flattens values into gauges. Since each metric name must have
+// consistent label names and unique label values, samples conflicting with earlier ones are dropped.
+type gaugeCollector struct {
+	ch     chan<- prometheus.Metric
+	labels map[string][]string
+	seen   map[string]bool
+}
+
+func (g *gaugeCollector) send(name string, labels []metricLabel, val float64) {
+	names := make([]string, 0, len(labels))
+	values := make([]string, 0, len(labels))
+	for _, l := range labels {
+		names = append(names, l.name)
+		values = append(values, l.value)
+	}
+
+	if known, ok := g.labels[name]; ok && !slices.Equal(known, names) {
+		return
+	}
+	g.labels[name] = names
+
+	key := name + "\x00" + strings.Join(values, "\x00")
+	if g.seen[key] {
+		return
+	}
+	g.seen[key] = true
+
+	desc := prometheus.NewDesc(name, "evcc value "+name, names, nil)
+	if m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, val, values...); err == nil {
+		g.ch <- m
+	}
+}
+
+func hasLabel(labels []metricLabel, name string) bool {
+	return slices.ContainsFunc(labels, func(l metricLabel) bool { return l.name == name })
+}
+
+func (g *gaugeCollector) collectMap(name, label string, labels []metricLabel, v reflect.Value) {
+	if v.Kind() != reflect.Map || hasLabel(labels, label) {
+		return
+	}
+
+	for iter := v.MapRange(); iter.Next(); {
+		g.collect(name, append(slices.Clone(labels), metricLabel{label, iter.Key().String()}), iter.Value())
+	}
+}
+
+func (g *gaugeCollector) collect(name string, labels []metricLabel, v reflect.Value) {
+	if !v.IsValid() {
+		return
+	}
+
+	switch val := v.Interface().(type) {
+	case time.Duration:
+		g.send(name, labels, val.Seconds())
+		return
+	case time.Time:
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		var f float64
+		if v.Bool() {
+			f = 1
+		}
+		g.send(name, labels, f)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		g.send(name, labels, float64(v.Int()))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		g.send(name, labels, float64(v.Uint()))
+
+	case reflect.Float32, reflect.Float64:
+		g.send(name, labels, v.Float())
+
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			g.collect(name, labels, v.Elem())
+		}
+
+	case reflect.Struct:
+		typ := v.Type()
+		for i := range typ.NumField() {
+			if f := typ.Field(i); f.IsExported() {
+				field, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+				if field == "-" {
+					continue
+				}
+				if field == "" {
+					field = f.Name
+				}
+				g.collect(name+"_"+snakeCase(field), labels, v.Field(i))
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		// phase values
+		if v.Len() == 3 && v.Type().Elem().Kind() == reflect.Float64 {
+			if !hasLabel(labels, "phase") {
+				for i := range 3 {
+					g.send(name, append(slices.Clone(labels), metricLabel{"phase", strconv.Itoa(i + 1)}), v.Index(i).Float())
+				}
+			}
+			return
+		}
+
+		if hasLabel(labels, "id") {
+			return
+		}
+
+		for i := range v.Len() {
+			g.collect(name, append(slices.Clone(labels), metricLabel{"id", strconv.Itoa(i + 1)}), v.Index(i))
+		}
+
+	case reflect.Map:
+		g.collectMap(name, "name", labels, v)
+	}
+}
+
+var (
+	snakeMatchFirst = regexp.MustCompile(`(.)([A-Z][a-z]+)`)
+	snakeMatchAll   = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+	snakeInvalid    = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+)
+
+// snakeCase converts camel case keys to prometheus metric names
+func snakeCase(s string) string {
+	s = snakeMatchFirst.ReplaceAllString(s, "${1}_${2}")
+	s = snakeMatchAll.ReplaceAllString(s, "${1}_${2}")
+	return strings.ToLower(snakeInvalid.ReplaceAllString(s, "_"))
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnakeCase(t *testing.T) {
+	for in, out := range map[string]string{
+		"pvPower":           "pv_power",
+		"chargeTotalImport": "charge_total_import",
+		"batterySoc":        "battery_soc",
+		"tariffCo2":         "tariff_co2",
+		"excessdcpower":     "excessdcpower",
+	} {
+		assert.Equal(t, out, snakeCase(in))
+	}
+}
+
+func TestCacheCollector(t *testing.T) {
+	lp := 0
+	cache := util.NewParamCache()
+
+	for _, p := range []util.Param{
+		{Key: "pvPower", Val: 5000.0},
+		{Key: "tariffGrid", Val: 0.3},
+		{Key: "siteTitle", Val: "Home"},
+		{Key: "grid", Val: measurement{Power: -1200, Currents: []float64{1, 2, 3}}},
+		{Key: "circuits", Val: map[string]struct {
+			Power    float64 `json:"power"`
+			MaxPower float64 `json:"maxPower,omitempty"`
+		}{"main": {Power: 7000, MaxPower: 11000}}},
+		{Loadpoint: &lp, Key: "title", Val: "Garage"},
+		{Loadpoint: &lp, Key: "chargePower", Val: 3700.0},
+		{Loadpoint: &lp, Key: "enabled", Val: true},
+		{Loadpoint: &lp, Key: "enableDelay", Val: time.Minute},
+	} {
+		cache.Add(p.UniqueID(), p)
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(&cacheCollector{cache: cache}))
+
+	expected := `
+# HELP evcc_circuit_max_power evcc value evcc_circuit_max_power
+# TYPE evcc_circuit_max_power gauge
+evcc_circuit_max_power{circuit="main"} 11000
+# HELP evcc_circuit_power evcc value evcc_circuit_power
+# TYPE evcc_circuit_power gauge
+evcc_circuit_power{circuit="main"} 7000
+# HELP evcc_loadpoint_charge_power evcc value evcc_loadpoint_charge_power
+# TYPE evcc_loadpoint_charge_power gauge
+evcc_loadpoint_charge_power{loadpoint="1",title="Garage"} 3700
+# HELP evcc_loadpoint_enable_delay evcc value evcc_loadpoint_enable_delay
+# TYPE evcc_loadpoint_enable_delay gauge
+evcc_loadpoint_enable_delay{loadpoint="1",title="Garage"} 60
+# HELP evcc_loadpoint_enabled evcc value evcc_loadpoint_enabled
+# TYPE evcc_loadpoint_enabled gauge
+evcc_loadpoint_enabled{loadpoint="1",title="Garage"} 1
+# HELP evcc_site_grid_currents evcc value evcc_site_grid_currents
+# TYPE evcc_site_grid_currents gauge
+evcc_site_grid_currents{phase="1"} 1
+evcc_site_grid_currents{phase="2"} 2
+evcc_site_grid_currents{phase="3"} 3
+# HELP evcc_site_grid_energy evcc value evcc_site_grid_energy
+# TYPE evcc_site_grid_energy gauge
+evcc_site_grid_energy 0
+# HELP evcc_site_grid_power evcc value evcc_site_grid_power
+# TYPE evcc_site_grid_power gauge
+evcc_site_grid_power -1200
+# HELP evcc_site_pv_power evcc value evcc_site_pv_power
+# TYPE evcc_site_pv_power gauge
+evcc_site_pv_power 5000
+# HELP evcc_site_tariff_grid evcc value evcc_site_tariff_grid
+# TYPE evcc_site_tariff_grid gauge
+evcc_site_tariff_grid 0.3
+`
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected)))
+}
@@ -0,0 +1,130 @@
+package modbus
+
+import (
+	"math"
+	"slices"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// SunSpec-like register map exposed by the evcc modbus server.
+//
+// The map starts at register 40000 with the "SunS" marker, followed by a list of
+// models each consisting of model id, model length and model data. The list is
+// terminated by the end model (id 0xFFFF, length 0).
+//
+// Site model (id 64900, length 10):
+//
+//	offset type   unit  access  description
+//	0      int32  W     r       grid power (import positive)
+//	2      int32  W     r       pv power
+//	4      int32  W     r       battery power (discharge positive)
+//	6      uint16 %     r       battery soc
+//	7      int32  W     r       home power
+//	9      uint16 -     r       number of loadpoints
+//
+// Loadpoint model (id 64901, length 8), one per loadpoint:
+//
+//	offset type   unit  access  description
+//	0      int32  W     r       charge power
+//	2      uint16 -     r       status (0 unknown, 1 A, 2 B, 3 C, 4 E)
+//	3      uint16 -     rw      mode (0 off, 1 now, 2 minpv, 3 pv)
+//	4      uint16 %     r       limit soc
+//	5      uint16 0.1A  rw      max current
+//	6      uint16 -     r       active phases
+//	7      uint16 -     r       reserved
+//
+// Offsets are relative to the first register after the model header.
+// 32 bit values are big endian (high word first). Unavailable values read as 0.
+const (
+	SunSpecBase = 40000
+
+	SiteModelID      = 64900
+	SiteModelLength  = 10
+	LoadpointModelID = 64901
+	LoadpointLength  = 8
+
+	endModelID = 0xFFFF
+)
+
+// loadpoint model register offsets
+const (
+	lpChargePower = 0
+	lpStatus      = 2
+	lpMode        = 3
+	lpLimitSoc    = 4
+	lpMaxCurrent  = 5
+	lpPhases      = 6
+)
+
+var (
+	modes    = []api.ChargeMode{api.ModeOff, api.ModeNow, api.ModeMinPV, api.ModePV}
+	statuses = []api.ChargeStatus{api.StatusNone, api.StatusA, api.StatusB, api.StatusC, api.StatusE}
+)
+
+// siteValues are the site values exposed by the site model
+type siteValues struct {
+	GridPower    float64
+	PVPower      float64
+	BatteryPower float64
+	BatterySoc   float64
+	HomePower    float64
+}
+
+func int32Regs(f float64) []uint16 {
+	v := uint32(int32(math.Round(f)))
+	return []uint16{uint16(v >> 16), uint16(v)}
+}
+
+func uint16Reg(f float64) uint16 {
+	return uint16(max(0, min(math.MaxUint16, math.Round(f))))
+}
+
+// registers returns the complete register map starting at SunSpecBase
+func registers(site siteValues, loadpoints []loadpoint.API) []uint16 {
+	res := []uint16{0x5375, 0x6e53} // SunS
+
+	// site model
+	res = append(res, SiteModelID, SiteModelLength)
+	res = append(res, int32Regs(site.GridPower)...)
+	res = append(res, int32Regs(site.PVPower)...)
+	res = append(res, int32Regs(site.BatteryPower)...)
+	res = append(res, uint16Reg(site.BatterySoc))
+	res = append(res, int32Regs(site.HomePower)...)
+	res = append(res, uint16(len(loadpoints)))
+
+	// loadpoint models
+	for _, lp := range loadpoints {
+		res = append(res, LoadpointModelID, LoadpointLength)
+		res = append(res, int32Regs(lp.GetChargePower())...)
+		res = append(res, uint16(max(0, slices.Index(statuses, lp.GetStatus()))))
+		res = append(res, uint16(max(0, slices.Index(modes, lp.GetMode()))))
+		res = append(res, uint16Reg(float64(lp.GetLimitSoc())))
+		res = append(res, uint16Reg(10*lp.GetMaxCurrent()))
+		res = append(res, uint16Reg(float64(lp.ActivePhases())))
+		res = append(res, 0)
+	}
+
+	// end model
+	res = append(res, endModelID, 0)
+
+	return res
+}
+
+// loadpointRegister returns the loadpoint index and model offset of a register address
+func loadpointRegister(addr uint16, loadpoints int) (int, int, bool) {
+	first := SunSpecBase + 2 + 2 + SiteModelLength
+
+	offset := int(addr) - first
+	if offset < 0 {
+		return 0, 0, false
+	}
+
+	lp, reg := offset/(2+LoadpointLength), offset%(2+LoadpointLength)-2
+	if lp >= loadpoints || reg < 0 {
+		return 0, 0, false
+	}
+
+	return lp, reg, true
+}
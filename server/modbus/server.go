@@ -0,0 +1,181 @@
+package modbus
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+)
+
+// server exposes site and loadpoint state as modbus slave
+type server struct {
+	mbserver.DummyHandler
+	log      *util.Logger
+	readOnly ReadOnlyMode
+	site     site.API
+
+	mu     sync.RWMutex
+	values siteValues
+}
+
+// StartServer starts a modbus tcp server exposing the SunSpec-like register map
+func StartServer(port int, site site.API, in <-chan util.Param, readOnly ReadOnlyMode) error {
+	h := &server{
+		log:      util.NewLogger(fmt.Sprintf("modbus-%d", port)),
+		readOnly: readOnly,
+		site:     site,
+	}
+
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+
+	h.log.DEBUG.Printf("modbus server listening at :%d", port)
+
+	srv, err := mbserver.New(h, mbserver.Logger(&logger{log: h.log}))
+	if err != nil {
+		return err
+	}
+
+	go h.run(in)
+
+	return srv.Start(l)
+}
+
+// power returns the power of a float or measurement struct value
+func power(val any) (float64, bool) {
+	if f, ok := val.(float64); ok {
+		return f, true
+	}
+
+	if v := reflect.ValueOf(val); v.Kind() == reflect.Struct {
+		if f := v.FieldByName("Power"); f.IsValid() && f.Kind() == reflect.Float64 {
+			return f.Float(), true
+		}
+	}
+
+	return 0, false
+}
+
+// run collects the published site values
+func (h *server) run(in <-chan util.Param) {
+	for p := range in {
+		if p.Loadpoint != nil {
+			continue
+		}
+
+		f, ok := power(p.Val)
+		if !ok {
+			continue
+		}
+
+		h.mu.Lock()
+		switch p.Key {
+		case keys.Grid:
+			h.values.GridPower = f
+		case keys.PvPower:
+			h.values.PVPower = f
+		case keys.BatteryPower:
+			h.values.BatteryPower = f
+		case keys.BatterySoc:
+			h.values.BatterySoc = f
+		case keys.HomePower:
+			h.values.HomePower = f
+		}
+		h.mu.Unlock()
+	}
+}
+
+func (h *server) read(addr, qty uint16) ([]uint16, error) {
+	h.mu.RLock()
+	values := h.values
+	h.mu.RUnlock()
+
+	regs := registers(values, h.site.Loadpoints())
+
+	if addr < SunSpecBase || int(addr)+int(qty) > SunSpecBase+len(regs) {
+		return nil, mbserver.ErrIllegalDataAddress
+	}
+
+	start := int(addr) - SunSpecBase
+	return regs[start : start+int(qty)], nil
+}
+
+func (h *server) write(addr uint16, args []uint16) error {
+	loadpoints := h.site.Loadpoints()
+
+	// validate all registers before applying any value
+	updates := make([]func() error, 0, len(args))
+
+	for i, val := range args {
+		id, reg, ok := loadpointRegister(addr+uint16(i), len(loadpoints))
+		if !ok {
+			return mbserver.ErrIllegalDataAddress
+		}
+
+		lp := loadpoints[id]
+
+		switch reg {
+		case lpMode:
+			if int(val) >= len(modes) {
+				return mbserver.ErrIllegalDataValue
+			}
+
+			updates = append(updates, func() error {
+				h.log.DEBUG.Printf("loadpoint %d: set mode: %s", id+1, modes[val])
+				lp.SetMode(modes[val])
+				return nil
+			})
+
+		case lpMaxCurrent:
+			current := float64(val) / 10
+
+			updates = append(updates, func() error {
+				h.log.DEBUG.Printf("loadpoint %d: set max current: %.1fA", id+1, current)
+				return lp.SetMaxCurrent(current)
+			})
+
+		default:
+			return mbserver.ErrIllegalDataAddress
+		}
+	}
+
+	for _, update := range updates {
+		if err := update(); err != nil {
+			h.log.WARN.Printf("write holdings: %v", err)
+			return mbserver.ErrIllegalDataValue
+		}
+	}
+
+	return nil
+}
+
+func (h *server) HandleInputRegisters(req *mbserver.InputRegistersRequest) ([]uint16, error) {
+	h.log.TRACE.Printf("read input: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
+	return h.read(req.Addr, req.Quantity)
+}
+
+func (h *server) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) ([]uint16, error) {
+	if req.IsWrite {
+		switch h.readOnly {
+		case ReadOnlyDeny:
+			h.log.TRACE.Printf("deny: write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
+			return nil, mbserver.ErrIllegalFunction
+		case ReadOnlyTrue:
+			h.log.TRACE.Printf("ignore: write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
+			return req.Args, nil
+		}
+
+		h.log.TRACE.Printf("write holdings: id %d addr %d qty %d val %0x", req.UnitId, req.Addr, req.Quantity, asBytes(req.Args))
+		return req.Args, h.write(req.Addr, req.Args)
+	}
+
+	h.log.TRACE.Printf("read holdings: id %d addr %d qty %d", req.UnitId, req.Addr, req.Quantity)
+	return h.read(req.Addr, req.Quantity)
+}
@@ -0,0 +1,110 @@
+package modbus
+
+import (
+	"testing"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testSite struct {
+	site.API
+	loadpoints []loadpoint.API
+}
+
+func (s *testSite) Loadpoints() []loadpoint.API {
+	return s.loadpoints
+}
+
+func TestServerRegisters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetChargePower().Return(11000.0).AnyTimes()
+	lp.EXPECT().GetStatus().Return(api.StatusC).AnyTimes()
+	lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+	lp.EXPECT().GetLimitSoc().Return(80).AnyTimes()
+	lp.EXPECT().GetMaxCurrent().Return(16.0).AnyTimes()
+	lp.EXPECT().ActivePhases().Return(3).AnyTimes()
+
+	h := &server{
+		log:  util.NewLogger("foo"),
+		site: &testSite{loadpoints: []loadpoint.API{lp}},
+		values: siteValues{
+			GridPower:  -1500,
+			PVPower:    5000,
+			BatterySoc: 55,
+		},
+	}
+
+	regs, err := h.read(SunSpecBase, 2+2+SiteModelLength+2+LoadpointLength+2)
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint16{
+		0x5375, 0x6e53,
+		SiteModelID, SiteModelLength, 0xFFFF, 0xFA24, 0, 5000, 0, 0, 55, 0, 0, 1,
+		LoadpointModelID, LoadpointLength, 0, 11000, 3, 3, 80, 160, 3, 0,
+		0xFFFF, 0,
+	}, regs)
+
+	_, err = h.read(SunSpecBase, uint16(len(regs)+1))
+	assert.Equal(t, mbserver.ErrIllegalDataAddress, err)
+}
+
+func TestServerWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+
+	h := &server{
+		log:  util.NewLogger("foo"),
+		site: &testSite{loadpoints: []loadpoint.API{lp}},
+	}
+
+	first := uint16(SunSpecBase + 2 + 2 + SiteModelLength + 2)
+
+	// nothing applied if any register is not writable
+	_, err := h.HandleHoldingRegisters(&mbserver.HoldingRegistersRequest{
+		Addr: first + lpMode, Quantity: 3, IsWrite: true, Args: []uint16{1, 0, 100},
+	})
+	assert.Equal(t, mbserver.ErrIllegalDataAddress, err, "limit soc is read-only")
+
+	// mode and max current
+	lp.EXPECT().SetMode(api.ModeNow)
+	lp.EXPECT().SetMaxCurrent(10.0).Return(nil)
+
+	_, err = h.HandleHoldingRegisters(&mbserver.HoldingRegistersRequest{
+		Addr: first + lpMode, Quantity: 1, IsWrite: true, Args: []uint16{1},
+	})
+	require.NoError(t, err)
+
+	_, err = h.HandleHoldingRegisters(&mbserver.HoldingRegistersRequest{
+		Addr: first + lpMaxCurrent, Quantity: 1, IsWrite: true, Args: []uint16{100},
+	})
+	require.NoError(t, err)
+
+	// invalid mode
+	_, err = h.HandleHoldingRegisters(&mbserver.HoldingRegistersRequest{
+		Addr: first + lpMode, Quantity: 1, IsWrite: true, Args: []uint16{uint16(len(modes))},
+	})
+	assert.Equal(t, mbserver.ErrIllegalDataValue, err)
+
+	// read-only
+	h.readOnly = ReadOnlyDeny
+	_, err = h.HandleHoldingRegisters(&mbserver.HoldingRegistersRequest{
+		Addr: first + lpMode, Quantity: 1, IsWrite: true, Args: []uint16{0},
+	})
+	assert.Equal(t, mbserver.ErrIllegalFunction, err)
+
+	h.readOnly = ReadOnlyTrue
+	_, err = h.HandleHoldingRegisters(&mbserver.HoldingRegistersRequest{
+		Addr: first + lpMode, Quantity: 1, IsWrite: true, Args: []uint16{0},
+	})
+	require.NoError(t, err)
+}
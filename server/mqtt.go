@@ -11,6 +11,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/prioritizer"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/plugin/mqtt"
@@ -29,6 +30,7 @@ type MQTT struct {
 	log       *util.Logger
 	Handler   *mqtt.Client
 	root      string
+	discovery string // home assistant discovery prefix
 	publisher func(topic string, retained bool, payload string)
 }
 
@@ -204,6 +206,8 @@ func (m *MQTT) listenSiteSetters(topic string, site site.API) error {
 		{"bufferSoc", floatSetter(site.SetBufferSoc)},
 		{"bufferStartSoc", floatSetter(site.SetBufferStartSoc)},
 		{"batteryDischargeControl", boolSetter(site.SetBatteryDischargeControl)},
+		{"batteryOptimizer", boolSetter(site.SetBatteryOptimizer)},
+		{"prioritization", setterFunc(prioritizer.StrategyString, site.SetPrioritization)},
 		{"prioritySoc", floatSetter(site.SetPrioritySoc)},
 		{"residualPower", floatSetter(site.SetResidualPower)},
 		{"smartCostLimit", floatPtrSetter(pass(func(limit *float64) {
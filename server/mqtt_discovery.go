@@ -0,0 +1,236 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// haEntity maps an evcc topic to a Home Assistant entity
+type haEntity struct {
+	component   string // sensor, select, number or switch
+	key         string // topic relative to the device's root topic
+	name        string
+	deviceClass string
+	stateClass  string
+	unit        string
+	options     []string
+	min, max    float64
+	template    string
+}
+
+func powerSensor(key, name string) haEntity {
+	return haEntity{component: "sensor", key: key, name: name, deviceClass: "power", stateClass: "measurement", unit: "W"}
+}
+
+func energySensor(key, name, unit string) haEntity {
+	return haEntity{component: "sensor", key: key, name: name, deviceClass: "energy", stateClass: "total_increasing", unit: unit}
+}
+
+func socSensor(key, name string) haEntity {
+	return haEntity{component: "sensor", key: key, name: name, deviceClass: "battery", stateClass: "measurement", unit: "%"}
+}
+
+var (
+	haSiteEntities = []haEntity{
+		powerSensor(keys.PvPower, "PV power"),
+		powerSensor(keys.HomePower, "Home power"),
+		powerSensor(keys.Grid+"/power", "Grid power"),
+		powerSensor(keys.BatteryPower, "Battery power"),
+		socSensor(keys.BatterySoc, "Battery SoC"),
+		energySensor(keys.PvEnergy, "PV energy", "kWh"),
+		energySensor(keys.Grid+"/energy", "Grid energy", "kWh"),
+	}
+
+	haLoadpointEntities = []haEntity{
+		powerSensor(keys.ChargePower, "Charge power"),
+		energySensor(keys.ChargedEnergy, "Charged energy", "Wh"),
+		energySensor(keys.ChargeTotalImport, "Charge meter total import", "kWh"),
+		socSensor(keys.VehicleSoc, "Vehicle SoC"),
+		{component: "select", key: keys.Mode, name: "Mode", options: []string{
+			string(api.ModeOff), string(api.ModePV), string(api.ModeMinPV), string(api.ModeNow),
+		}},
+		{component: "number", key: keys.LimitSoc, name: "Limit SoC", unit: "%", max: 100},
+		{component: "number", key: keys.MinCurrent, name: "Min current", deviceClass: "current", unit: "A", min: 6, max: 64},
+		{component: "number", key: keys.MaxCurrent, name: "Max current", deviceClass: "current", unit: "A", min: 6, max: 64},
+		{component: "switch", key: keys.BatteryBoost, name: "Battery boost"},
+	}
+
+	// vehicle values are omitted when not set
+	haVehicleEntities = []haEntity{
+		{component: "number", key: keys.MinSoc, name: "Min SoC", unit: "%", max: 100, template: "{{ value | int(0) }}"},
+		{component: "number", key: keys.LimitSoc, name: "Limit SoC", unit: "%", max: 100, template: "{{ value | int(0) }}"},
+	}
+)
+
+type haDevice struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	Model        string   `json:"model"`
+	SwVersion    string   `json:"sw_version,omitempty"`
+	ViaDevice    string   `json:"via_device,omitempty"`
+}
+
+type haConfig struct {
+	Name              string   `json:"name"`
+	HasEntityName     bool     `json:"has_entity_name"`
+	UniqueID          string   `json:"unique_id"`
+	StateTopic        string   `json:"state_topic"`
+	ValueTemplate     string   `json:"value_template,omitempty"`
+	CommandTopic      string   `json:"command_topic,omitempty"`
+	DeviceClass       string   `json:"device_class,omitempty"`
+	StateClass        string   `json:"state_class,omitempty"`
+	UnitOfMeasurement string   `json:"unit_of_measurement,omitempty"`
+	Options           []string `json:"options,omitempty"`
+	Min               *float64 `json:"min,omitempty"`
+	Max               *float64 `json:"max,omitempty"`
+	Mode              string   `json:"mode,omitempty"`
+	PayloadOn         string   `json:"payload_on,omitempty"`
+	PayloadOff        string   `json:"payload_off,omitempty"`
+	Device            haDevice `json:"device"`
+}
+
+var haInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// haID converts a topic or device name into a valid discovery object id
+func haID(s string) string {
+	return haInvalidChars.ReplaceAllString(s, "_")
+}
+
+// Discover publishes Home Assistant MQTT discovery messages below the prefix
+// and removes them when loadpoints or vehicles are deleted.
+func (m *MQTT) Discover(prefix string, site site.API) {
+	m.discovery = prefix
+
+	m.discoverSite(site.GetTitle())
+
+	lps := site.Loadpoints()
+	for _, dev := range config.Loadpoints().Devices() {
+		if id := slices.Index(lps, dev.Instance()); id >= 0 {
+			m.discoverLoadpoint(id+1, dev.Config().Name, dev.Instance())
+		}
+	}
+
+	for _, dev := range config.Vehicles().Devices() {
+		m.discoverVehicle(dev.Config().Name, dev.Instance())
+	}
+
+	config.Loadpoints().Subscribe(m.updateLoadpointDiscovery)
+	config.Vehicles().Subscribe(m.updateVehicleDiscovery)
+}
+
+// haNode is the discovery node id shared by all entities of this instance
+func (m *MQTT) haNode() string {
+	return haID(m.root)
+}
+
+func (m *MQTT) haTopic(component, object string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/config", m.discovery, component, m.haNode(), object)
+}
+
+func (m *MQTT) publishDiscovery(object, topic string, device haDevice, entities []haEntity) {
+	for _, e := range entities {
+		id := object + "_" + haID(e.key)
+
+		conf := haConfig{
+			Name:              e.name,
+			HasEntityName:     true,
+			UniqueID:          m.haNode() + "_" + id,
+			StateTopic:        topic + "/" + e.key,
+			ValueTemplate:     e.template,
+			DeviceClass:       e.deviceClass,
+			StateClass:        e.stateClass,
+			UnitOfMeasurement: e.unit,
+			Options:           e.options,
+			Device:            device,
+		}
+
+		switch e.component {
+		case "number":
+			conf.Min, conf.Max, conf.Mode = &e.min, &e.max, "box"
+		case "switch":
+			conf.PayloadOn, conf.PayloadOff = "true", "false"
+		}
+
+		if e.component != "sensor" {
+			conf.CommandTopic = conf.StateTopic + "/set"
+		}
+
+		b, err := json.Marshal(conf)
+		if err != nil {
+			m.log.ERROR.Printf("discovery: %v", err)
+			continue
+		}
+
+		m.publisher(m.haTopic(e.component, id), true, string(b))
+	}
+}
+
+// removeDiscovery removes the entities by publishing empty configs
+func (m *MQTT) removeDiscovery(object string, entities []haEntity) {
+	for _, e := range entities {
+		m.publisher(m.haTopic(e.component, object+"_"+haID(e.key)), true, "")
+	}
+}
+
+func (m *MQTT) haDevice(object, name, model string) haDevice {
+	res := haDevice{
+		Identifiers:  []string{m.haNode() + "_" + object},
+		Name:         name,
+		Manufacturer: "evcc",
+		Model:        model,
+	}
+
+	if object == "site" {
+		res.SwVersion = util.Version
+	} else {
+		res.ViaDevice = m.haNode() + "_site"
+	}
+
+	return res
+}
+
+func (m *MQTT) discoverSite(title string) {
+	if title == "" {
+		title = "evcc"
+	}
+
+	device := m.haDevice("site", title, "Site")
+	m.publishDiscovery("site", m.root+"/site", device, haSiteEntities)
+}
+
+func (m *MQTT) discoverLoadpoint(id int, name string, lp loadpoint.API) {
+	object := "loadpoint_" + haID(name)
+	device := m.haDevice(object, lp.GetTitle(), "Loadpoint")
+	m.publishDiscovery(object, fmt.Sprintf("%s/loadpoints/%d", m.root, id), device, haLoadpointEntities)
+}
+
+func (m *MQTT) discoverVehicle(name string, v api.Vehicle) {
+	object := "vehicle_" + haID(name)
+	device := m.haDevice(object, v.GetTitle(), "Vehicle")
+	m.publishDiscovery(object, fmt.Sprintf("%s/vehicles/%s", m.root, name), device, haVehicleEntities)
+}
+
+// updateLoadpointDiscovery removes deleted loadpoints. New loadpoints require a restart and are discovered on startup.
+func (m *MQTT) updateLoadpointDiscovery(op config.Operation, dev config.Device[loadpoint.API]) {
+	if op == config.OpDelete {
+		m.removeDiscovery("loadpoint_"+haID(dev.Config().Name), haLoadpointEntities)
+	}
+}
+
+// updateVehicleDiscovery removes deleted vehicles. Vehicle setters are registered on startup,
+// hence new vehicles require a restart and are discovered on startup.
+func (m *MQTT) updateVehicleDiscovery(op config.Operation, dev config.Device[api.Vehicle]) {
+	if op == config.OpDelete {
+		m.removeDiscovery("vehicle_"+haID(dev.Config().Name), haVehicleEntities)
+	}
+}
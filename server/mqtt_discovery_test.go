@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMqttDiscovery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	published := make(map[string]string)
+
+	m := &MQTT{
+		root:      "evcc",
+		discovery: "homeassistant",
+		publisher: func(topic string, retained bool, payload string) {
+			assert.True(t, retained)
+			published[topic] = payload
+		},
+	}
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetTitle().Return("Garage")
+
+	m.discoverLoadpoint(2, "db:5", lp)
+	require.Len(t, published, len(haLoadpointEntities))
+
+	var conf haConfig
+	require.NoError(t, json.Unmarshal([]byte(published["homeassistant/select/evcc/loadpoint_db_5_mode/config"]), &conf))
+	assert.Equal(t, "evcc/loadpoints/2/mode", conf.StateTopic)
+	assert.Equal(t, "evcc/loadpoints/2/mode/set", conf.CommandTopic)
+	assert.Equal(t, []string{"off", "pv", "minpv", "now"}, conf.Options)
+	assert.Equal(t, "Garage", conf.Device.Name)
+	assert.Equal(t, "evcc_site", conf.Device.ViaDevice)
+
+	conf = haConfig{}
+	require.NoError(t, json.Unmarshal([]byte(published["homeassistant/sensor/evcc/loadpoint_db_5_chargePower/config"]), &conf))
+	assert.Equal(t, "power", conf.DeviceClass)
+	assert.Equal(t, "W", conf.UnitOfMeasurement)
+	assert.Empty(t, conf.CommandTopic)
+
+	v := api.NewMockVehicle(ctrl)
+	v.EXPECT().GetTitle().Return("Blue car")
+
+	m.discoverVehicle("db:7", v)
+	assert.Contains(t, published["homeassistant/number/evcc/vehicle_db_7_minSoc/config"], `"command_topic":"evcc/vehicles/db:7/minSoc/set"`)
+
+	// new vehicles have no setters until restart
+	n := len(published)
+	m.updateVehicleDiscovery(config.OpAdd, config.NewStaticDevice(config.Named{Name: "db:8"}, api.Vehicle(v)))
+	assert.Len(t, published, n)
+
+	// deleting removes all entities
+	m.updateLoadpointDiscovery(config.OpDelete, config.NewStaticDevice(config.Named{Name: "db:5"}, loadpoint.API(lp)))
+	m.updateVehicleDiscovery(config.OpDelete, config.NewStaticDevice(config.Named{Name: "db:7"}, api.Vehicle(v)))
+
+	for topic, payload := range published {
+		assert.Empty(t, payload, topic)
+	}
+}
@@ -0,0 +1,176 @@
+package tariff
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/history"
+	"github.com/evcc-io/evcc/tariff/clearsky"
+	"github.com/evcc-io/evcc/util"
+	"github.com/jinzhu/now"
+)
+
+const (
+	clearSkySlot     = 15 * time.Minute
+	clearSkyHistory  = 14 * 24 * time.Hour // production history used for scaling
+	clearSkyMinScale = 0.1
+	clearSkyMaxScale = 1.5
+)
+
+// ClearSky is an offline solar forecast based on plant geometry and a clear-sky irradiance model,
+// optionally scaled by the production of the pv meters
+type ClearSky struct {
+	mu     sync.Mutex
+	log    *util.Logger
+	clock  clock.Clock
+	plant  clearsky.Plant
+	learn  bool
+	meters []string
+	scale  float64
+	scaled time.Time
+	rates  api.Rates // cached rates
+	key    clearSkyKey
+}
+
+// clearSkyKey identifies the day and scale the cached rates were computed for
+type clearSkyKey struct {
+	day   time.Time
+	scale float64
+}
+
+var _ api.Tariff = (*ClearSky)(nil)
+
+func init() {
+	registry.Add("clearsky", NewClearSkyFromConfig)
+}
+
+func NewClearSkyFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		Lat, Lon   float64
+		Dec        float64 // tilt
+		Az         float64 // azimuth
+		Kwp        float64
+		Efficiency float64
+		Learn      bool
+		Meters     []string
+	}{
+		Efficiency: 85,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Kwp <= 0 {
+		return nil, errors.New("missing kwp")
+	}
+
+	if cc.Lat < -90 || cc.Lat > 90 || cc.Lon < -180 || cc.Lon > 180 || (cc.Lat == 0 && cc.Lon == 0) {
+		return nil, errors.New("invalid location")
+	}
+
+	t := &ClearSky{
+		log:   util.NewLogger("clearsky"),
+		clock: clock.New(),
+		plant: clearsky.Plant{
+			Lat:        cc.Lat,
+			Lon:        cc.Lon,
+			Tilt:       cc.Dec,
+			Azimuth:    cc.Az,
+			Peak:       cc.Kwp * 1e3,
+			Efficiency: cc.Efficiency / 100,
+		},
+		learn:  cc.Learn,
+		meters: cc.Meters,
+		scale:  1,
+	}
+
+	return t, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *ClearSky) Rates() (api.Rates, error) {
+	scale := t.learnedScale()
+
+	start := now.With(t.clock.Now()).BeginningOfDay()
+	end := start.AddDate(0, 0, 3)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := clearSkyKey{day: start, scale: scale}
+	if t.rates != nil && t.key == key {
+		return slices.Clone(t.rates), nil
+	}
+
+	res := make(api.Rates, 0, int(end.Sub(start)/clearSkySlot))
+	for ts := start; ts.Before(end); ts = ts.Add(clearSkySlot) {
+		res = append(res, api.Rate{
+			Start: ts,
+			End:   ts.Add(clearSkySlot),
+			Value: scale * t.plant.Energy(ts, ts.Add(clearSkySlot), clearSkySlot) / clearSkySlot.Hours(),
+		})
+	}
+
+	t.rates = res
+	t.key = key
+
+	return slices.Clone(res), nil
+}
+
+// learnedScale returns the ratio of actual to clear-sky production, updated daily
+func (t *ClearSky) learnedScale() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.learn || db.Instance == nil {
+		return 1
+	}
+
+	today := now.With(t.clock.Now()).BeginningOfDay()
+	if !t.scaled.Before(today) {
+		return t.scale
+	}
+	t.scaled = today
+
+	series, err := history.Get("pv", history.Day, today.Add(-clearSkyHistory), today)
+	if err != nil {
+		t.log.ERROR.Println("history:", err)
+		return t.scale
+	}
+
+	days := make(map[time.Time]float64)
+	for _, s := range series {
+		if len(t.meters) > 0 && !slices.Contains(t.meters, s.Name) {
+			continue
+		}
+		for _, e := range s.Data {
+			days[e.Timestamp] += e.Import
+		}
+	}
+
+	var actual, model float64
+	for day, energy := range days {
+		if energy > 0 {
+			actual += energy
+			model += t.plant.Energy(day, day.AddDate(0, 0, 1), clearSkySlot)
+		}
+	}
+
+	if model > 0 {
+		t.scale = min(clearSkyMaxScale, max(clearSkyMinScale, actual/model))
+		t.log.DEBUG.Printf("scale: %.2f (%d days)", t.scale, len(days))
+	}
+
+	return t.scale
+}
+
+// Type implements the api.Tariff interface
+func (t *ClearSky) Type() api.TariffType {
+	return api.TariffTypeSolar
+}
@@ -0,0 +1,83 @@
+package clearsky
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	solarConstant = 1353 // W/m² extraterrestrial irradiance used by the Meinel model
+	albedo        = 0.2  // ground reflectance
+)
+
+// Irradiance is the clear-sky irradiance in W/m²
+type Irradiance struct {
+	DNI float64 // direct normal
+	DHI float64 // diffuse horizontal
+	GHI float64 // global horizontal
+}
+
+// ClearSky returns the clear-sky irradiance for the given sun position using
+// Kasten-Young air mass and the Meinel direct beam model.
+func ClearSky(pos Position) Irradiance {
+	if pos.Elevation <= 0 {
+		return Irradiance{}
+	}
+
+	zenith := math.Pi/2 - pos.Elevation
+	zdeg := zenith / deg
+
+	am := 1 / (math.Cos(zenith) + 0.50572*math.Pow(96.07995-zdeg, -1.6364))
+	dni := solarConstant * math.Pow(0.7, math.Pow(am, 0.678))
+	dhi := 0.1 * dni
+
+	return Irradiance{
+		DNI: dni,
+		DHI: dhi,
+		GHI: dni*math.Cos(zenith) + dhi,
+	}
+}
+
+// Plant is the geometry and size of a pv plant
+type Plant struct {
+	Lat, Lon   float64 // location in degrees
+	Tilt       float64 // degrees, 0 = horizontal, 90 = vertical
+	Azimuth    float64 // degrees, -90 = east, 0 = south, 90 = west
+	Peak       float64 // peak power in W
+	Efficiency float64 // system efficiency including inverter and cabling losses (0..1)
+}
+
+// PlaneOfArray returns the irradiance on the plant's modules in W/m²
+func (p Plant) PlaneOfArray(pos Position, irr Irradiance) float64 {
+	tilt := p.Tilt * deg
+	zenith := math.Pi/2 - pos.Elevation
+
+	// angle of incidence between sun and module normal
+	cosAoi := math.Cos(zenith)*math.Cos(tilt) + math.Sin(zenith)*math.Sin(tilt)*math.Cos(pos.Azimuth-p.Azimuth*deg)
+
+	beam := irr.DNI * max(0, cosAoi)
+	diffuse := irr.DHI * (1 + math.Cos(tilt)) / 2
+	reflected := irr.GHI * albedo * (1 - math.Cos(tilt)) / 2
+
+	return beam + diffuse + reflected
+}
+
+// Power returns the plant's clear-sky power in W at given time
+func (p Plant) Power(ts time.Time) float64 {
+	pos := SunPosition(ts, p.Lat, p.Lon)
+	if pos.Elevation <= 0 {
+		return 0
+	}
+
+	return p.Peak * p.PlaneOfArray(pos, ClearSky(pos)) / 1e3 * p.Efficiency
+}
+
+// Energy returns the plant's clear-sky energy in Wh between from and to, integrated in given steps
+func (p Plant) Energy(from, to time.Time, step time.Duration) float64 {
+	var res float64
+	for ts := from; ts.Before(to); ts = ts.Add(step) {
+		d := min(step, to.Sub(ts))
+		res += p.Power(ts.Add(d/2)) * d.Hours()
+	}
+	return res
+}
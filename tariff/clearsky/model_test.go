@@ -0,0 +1,44 @@
+package clearsky
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSunPosition(t *testing.T) {
+	// Munich, summer solstice, solar noon is about 11:16 UTC
+	pos := SunPosition(time.Date(2025, 6, 21, 11, 16, 0, 0, time.UTC), 48.14, 11.58)
+	assert.InDelta(t, 65.3, pos.Elevation/deg, 0.5)
+	assert.InDelta(t, 0, pos.Azimuth/deg, 2)
+
+	// morning sun is in the east
+	pos = SunPosition(time.Date(2025, 6, 21, 5, 0, 0, 0, time.UTC), 48.14, 11.58)
+	assert.Greater(t, pos.Elevation, 0.0)
+	assert.Less(t, pos.Azimuth, -math.Pi/2)
+
+	// night
+	pos = SunPosition(time.Date(2025, 6, 21, 22, 0, 0, 0, time.UTC), 48.14, 11.58)
+	assert.Less(t, pos.Elevation, 0.0)
+}
+
+func TestPlant(t *testing.T) {
+	south := Plant{Lat: 48.14, Lon: 11.58, Tilt: 30, Azimuth: 0, Peak: 10e3, Efficiency: 0.85}
+	north := south
+	north.Azimuth = 180
+
+	noon := time.Date(2025, 3, 20, 11, 15, 0, 0, time.UTC)
+
+	p := south.Power(noon)
+	assert.Greater(t, p, 6e3)
+	assert.Less(t, p, south.Peak)
+	assert.Less(t, north.Power(noon), p)
+
+	assert.Equal(t, 0.0, south.Power(noon.Add(12*time.Hour)))
+
+	// daily clear-sky yield of a 10kWp plant in spring
+	day := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+	assert.InDelta(t, 55e3, south.Energy(day, day.AddDate(0, 0, 1), 15*time.Minute), 15e3)
+}
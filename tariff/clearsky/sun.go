@@ -0,0 +1,49 @@
+package clearsky
+
+import (
+	"math"
+	"time"
+)
+
+const deg = math.Pi / 180
+
+// Position is the sun's position in the sky
+type Position struct {
+	Elevation float64 // radians above horizon
+	Azimuth   float64 // radians, 0 = south, negative = east, positive = west
+}
+
+// SunPosition returns the sun's position at given time and location in degrees
+// using the NOAA general solar position approximation.
+func SunPosition(ts time.Time, lat, lon float64) Position {
+	ts = ts.UTC()
+
+	// fractional year
+	hour := float64(ts.Hour()) + float64(ts.Minute())/60 + float64(ts.Second())/3600
+	gamma := 2 * math.Pi / 365 * (float64(ts.YearDay()-1) + (hour-12)/24)
+
+	// equation of time in minutes and declination in radians
+	eqtime := 229.18 * (0.000075 + 0.001868*math.Cos(gamma) - 0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) - 0.040849*math.Sin(2*gamma))
+	decl := 0.006918 - 0.399912*math.Cos(gamma) + 0.070257*math.Sin(gamma) -
+		0.006758*math.Cos(2*gamma) + 0.000907*math.Sin(2*gamma) -
+		0.002697*math.Cos(3*gamma) + 0.00148*math.Sin(3*gamma)
+
+	// true solar time in minutes and hour angle
+	tst := hour*60 + eqtime + 4*lon
+	ha := (tst/4 - 180) * deg
+
+	phi := lat * deg
+
+	cosZenith := math.Sin(phi)*math.Sin(decl) + math.Cos(phi)*math.Cos(decl)*math.Cos(ha)
+	cosZenith = min(1, max(-1, cosZenith))
+	zenith := math.Acos(cosZenith)
+
+	// azimuth from south, positive towards west
+	az := math.Atan2(math.Sin(ha), math.Cos(ha)*math.Sin(phi)-math.Tan(decl)*math.Cos(phi))
+
+	return Position{
+		Elevation: math.Pi/2 - zenith,
+		Azimuth:   az,
+	}
+}
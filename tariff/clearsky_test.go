@@ -0,0 +1,80 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearSky(t *testing.T) {
+	res, err := NewClearSkyFromConfig(map[string]any{
+		"lat": 48.14, "lon": 11.58, "dec": 30, "az": 0, "kwp": 10,
+	})
+	require.NoError(t, err)
+
+	cs := res.(*ClearSky)
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 6, 21, 10, 0, 0, 0, time.Local))
+	cs.clock = clock
+
+	rr, err := cs.Rates()
+	require.NoError(t, err)
+	require.Len(t, rr, 3*96)
+
+	var peak float64
+	for _, r := range rr {
+		peak = max(peak, r.Value)
+	}
+	assert.Greater(t, peak, 6e3)
+	assert.Equal(t, 0.0, rr[0].Value) // midnight
+
+	// rates are cached until the next day and not modified by callers
+	cs.plant.Peak = 0
+	rr[50].Value = -1
+	cached, err := cs.Rates()
+	require.NoError(t, err)
+	assert.Greater(t, cached[50].Value, 0.0)
+
+	cs.plant.Peak = 10e3
+	clock.Add(24 * time.Hour)
+	next, err := cs.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, rr[96].Start, next[0].Start)
+
+	_, err = NewClearSkyFromConfig(map[string]any{"lat": 48.14, "lon": 11.58})
+	assert.Error(t, err)
+}
+
+func TestClearSkyLearn(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, history.Init())
+
+	res, err := NewClearSkyFromConfig(map[string]any{
+		"lat": 48.14, "lon": 11.58, "dec": 30, "az": 0, "kwp": 10, "learn": true,
+	})
+	require.NoError(t, err)
+
+	cs := res.(*ClearSky)
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 6, 21, 10, 0, 0, 0, time.Local))
+	cs.clock = clock
+
+	// two days producing half the modeled energy
+	for i := 1; i <= 2; i++ {
+		day := time.Date(2025, 6, 21-i, 0, 0, 0, 0, time.Local)
+		modeled := cs.plant.Energy(day, day.AddDate(0, 0, 1), clearSkySlot)
+		require.NoError(t, history.Persist("pv", "roof", day.Add(12*time.Hour), modeled/2, 0))
+	}
+
+	assert.InDelta(t, 0.5, cs.learnedScale(), 0.01)
+
+	// meters not configured for the plant are ignored
+	cs.meters = []string{"garage"}
+	cs.scaled = time.Time{}
+	assert.Equal(t, 0.5, cs.learnedScale()) // no data keeps previous scale
+}
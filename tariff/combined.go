@@ -1,10 +1,6 @@
 package tariff
 
 import (
-	"errors"
-	"slices"
-	"time"
-
 	"github.com/evcc-io/evcc/api"
 )
 
@@ -18,46 +14,40 @@ func NewCombined(tariffs []api.Tariff) api.Tariff {
 	}
 }
 
+// Rates sums the tariffs' rates. Rates of different period lengths are split at their boundaries.
 func (t *combined) Rates() (api.Rates, error) {
-	var keys []time.Time
+	rates := make([]api.Rates, 0, len(t.tariffs))
 	for _, t := range t.tariffs {
 		rr, err := t.Rates()
 		if err != nil {
 			return nil, err
 		}
 
-		for _, r := range rr {
-			if !slices.ContainsFunc(keys, r.Start.Equal) {
-				keys = append(keys, r.Start)
-			}
-		}
+		rr = append(api.Rates(nil), rr...)
+		rr.Sort()
+		rates = append(rates, rr)
 	}
 
 	var res api.Rates
-	keys = slices.SortedFunc(slices.Values(keys), time.Time.Compare)
-
-	for _, ts := range keys {
-		var rate api.Rate
-
-		for _, t := range t.tariffs {
-			r, err := At(t, ts)
-			if err != nil {
-				continue
-			}
+	ts := boundaries(rates...)
 
-			if rate.Start.IsZero() {
-				rate = r
-				continue
-			}
+	for i := 1; i < len(ts); i++ {
+		rate := api.Rate{
+			Start: ts[i-1],
+			End:   ts[i],
+		}
 
-			if !r.End.Equal(rate.End) {
-				return nil, errors.New("combined tariffs must have the same period length")
+		var found bool
+		for _, rr := range rates {
+			if r, err := rr.At(rate.Start); err == nil {
+				rate.Value += r.Value
+				found = true
 			}
-
-			rate.Value += r.Value
 		}
 
-		res = append(res, rate)
+		if found {
+			res = append(res, rate)
+		}
 	}
 
 	return res, nil
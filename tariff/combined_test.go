@@ -40,3 +40,22 @@ func TestCombined(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, api.Rates{rate(1, 1), rate(2, 4), rate(3, 3)}, rr)
 }
+
+func TestCombinedPeriods(t *testing.T) {
+	clock := clock.NewMock()
+	rate := func(start, end int, val float64) api.Rate {
+		return api.Rate{
+			Start: clock.Now().Add(time.Duration(start) * 15 * time.Minute),
+			End:   clock.Now().Add(time.Duration(end) * 15 * time.Minute),
+			Value: val,
+		}
+	}
+
+	a := &tariff{api.Rates{rate(0, 1, 1), rate(1, 2, 2), rate(2, 3, 3)}}
+	b := &tariff{api.Rates{rate(0, 4, 10)}}
+	c := &combined{[]api.Tariff{a, b}}
+
+	rr, err := c.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(0, 1, 11), rate(1, 2, 12), rate(2, 3, 13), rate(3, 4, 10)}, rr)
+}
@@ -0,0 +1,203 @@
+package tariff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// Composed is a tariff composed of multiple price components, e.g. spot price, grid fees, levies and VAT
+type Composed struct {
+	components []component
+}
+
+// component is either a tariff, a constant price or a factor applied to the sum of all preceding components
+type component struct {
+	name   string
+	tariff api.Tariff
+	price  float64
+	factor float64
+}
+
+var (
+	_ api.Tariff           = (*Composed)(nil)
+	_ api.TariffComponents = (*Composed)(nil)
+)
+
+func init() {
+	registry.AddCtx("composed", NewComposedFromConfig)
+}
+
+func NewComposedFromConfig(ctx context.Context, other map[string]interface{}) (api.Tariff, error) {
+	var cc struct {
+		Components []struct {
+			Name   string
+			Tariff config.Typed
+			Price  float64
+			Factor float64
+		}
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	t := new(Composed)
+
+	for i, c := range cc.Components {
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("component %d", i+1)
+		}
+
+		comp := component{
+			name:   c.Name,
+			price:  c.Price,
+			factor: c.Factor,
+		}
+
+		switch {
+		case c.Tariff.Type != "":
+			tariff, err := NewFromConfig(ctx, c.Tariff.Type, c.Tariff.Other)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", c.Name, err)
+			}
+			comp.tariff = tariff
+
+		case c.Price == 0 && c.Factor == 0:
+			return nil, fmt.Errorf("%s: missing tariff, price or factor", c.Name)
+		}
+
+		// factor scales tariffs and prices, defaults to 1
+		if comp.factor == 0 {
+			comp.factor = 1
+		}
+
+		t.components = append(t.components, comp)
+	}
+
+	if t.tariffs() == 0 {
+		return nil, errors.New("missing tariff component")
+	}
+
+	return t, nil
+}
+
+// tariffs returns the number of tariff components
+func (t *Composed) tariffs() int {
+	var res int
+	for _, c := range t.components {
+		if c.tariff != nil {
+			res++
+		}
+	}
+	return res
+}
+
+// multiplicative returns true if the component is applied as factor to the preceding sum
+func (c component) multiplicative() bool {
+	return c.tariff == nil && c.price == 0
+}
+
+// compose returns the composed rates and the rates of each component.
+// Rates are split at the boundaries of all tariff components' rates. Periods not covered by all tariffs are skipped.
+func (t *Composed) compose() (api.Rates, []api.TariffComponent, error) {
+	rates := make([]api.Rates, len(t.components))
+
+	var bounds []api.Rates
+	for i, c := range t.components {
+		if c.tariff == nil {
+			continue
+		}
+
+		rr, err := c.tariff.Rates()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", c.name, err)
+		}
+
+		rr = append(api.Rates(nil), rr...)
+		rr.Sort()
+
+		rates[i] = rr
+		bounds = append(bounds, rr)
+	}
+
+	var res api.Rates
+	components := make([]api.TariffComponent, len(t.components))
+	for i, c := range t.components {
+		components[i].Name = c.name
+	}
+
+	ts := boundaries(bounds...)
+
+NEXT:
+	for i := 1; i < len(ts); i++ {
+		values := make([]float64, len(t.components))
+
+		var total float64
+		for j, c := range t.components {
+			var v float64
+
+			switch {
+			case c.tariff != nil:
+				r, err := rates[j].At(ts[i-1])
+				if err != nil {
+					continue NEXT
+				}
+				v = c.factor * r.Value
+
+			case c.multiplicative():
+				v = total * (c.factor - 1)
+
+			default:
+				v = c.factor * c.price
+			}
+
+			values[j] = v
+			total += v
+		}
+
+		res = append(res, api.Rate{Start: ts[i-1], End: ts[i], Value: total})
+
+		for j, v := range values {
+			components[j].Rates = append(components[j].Rates, api.Rate{Start: ts[i-1], End: ts[i], Value: v})
+		}
+	}
+
+	return res, components, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *Composed) Rates() (api.Rates, error) {
+	res, _, err := t.compose()
+	return res, err
+}
+
+// Components implements the api.TariffComponents interface
+func (t *Composed) Components() ([]api.TariffComponent, error) {
+	_, res, err := t.compose()
+	return res, err
+}
+
+// Type implements the api.Tariff interface
+func (t *Composed) Type() api.TariffType {
+	res := api.TariffTypePriceStatic
+
+	for _, c := range t.components {
+		if c.tariff == nil {
+			continue
+		}
+
+		switch c.tariff.Type() {
+		case api.TariffTypePriceForecast:
+			return api.TariffTypePriceForecast
+		case api.TariffTypePriceDynamic:
+			res = api.TariffTypePriceDynamic
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,106 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposed(t *testing.T) {
+	clock := clock.NewMock()
+	rate := func(start, end int, val float64) api.Rate {
+		return api.Rate{
+			Start: clock.Now().Add(time.Duration(start) * 15 * time.Minute),
+			End:   clock.Now().Add(time.Duration(end) * 15 * time.Minute),
+			Value: val,
+		}
+	}
+
+	spot := &tariff{api.Rates{rate(3, 4, 0.2), rate(0, 1, 0.1), rate(1, 2, 0.2), rate(2, 3, 0.3), rate(4, 5, 0.1)}}
+	fee := &tariff{api.Rates{rate(0, 2, 0.05), rate(2, 6, 0.1)}}
+
+	c := &Composed{[]component{
+		{name: "spot", tariff: spot, factor: 1},
+		{name: "gridfee", tariff: fee, factor: 1},
+		{name: "levies", price: 0.02, factor: 1},
+		{name: "vat", factor: 1.5},
+	}}
+
+	rr, err := c.Rates()
+	require.NoError(t, err)
+	require.Len(t, rr, 5)
+
+	for i, exp := range []float64{0.255, 0.405, 0.63, 0.48, 0.33} {
+		assert.Equal(t, rate(i, i+1, 0).Start, rr[i].Start)
+		assert.Equal(t, rate(i, i+1, 0).End, rr[i].End)
+		assert.InDelta(t, exp, rr[i].Value, 1e-9)
+	}
+
+	cc, err := c.Components()
+	require.NoError(t, err)
+	require.Len(t, cc, 4)
+
+	assert.Equal(t, []string{"spot", "gridfee", "levies", "vat"}, []string{cc[0].Name, cc[1].Name, cc[2].Name, cc[3].Name})
+
+	// components add up to composed rate
+	for i, r := range rr {
+		var sum float64
+		for _, c := range cc {
+			sum += c.Rates[i].Value
+		}
+		assert.InDelta(t, r.Value, sum, 1e-9)
+	}
+
+	assert.InDelta(t, 0.1, cc[1].Rates[2].Value, 1e-9)
+	assert.InDelta(t, 0.21, cc[3].Rates[2].Value, 1e-9)
+}
+
+func TestComposedGaps(t *testing.T) {
+	clock := clock.NewMock()
+	rate := func(start, end int, val float64) api.Rate {
+		return api.Rate{
+			Start: clock.Now().Add(time.Duration(start) * time.Hour),
+			End:   clock.Now().Add(time.Duration(end) * time.Hour),
+			Value: val,
+		}
+	}
+
+	a := &tariff{api.Rates{rate(0, 1, 1), rate(2, 3, 2)}}
+	b := &tariff{api.Rates{rate(0, 3, 10)}}
+
+	c := &Composed{[]component{
+		{name: "a", tariff: a, factor: 1},
+		{name: "b", tariff: b, factor: 2},
+	}}
+
+	rr, err := c.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(0, 1, 21), rate(2, 3, 22)}, rr)
+}
+
+func TestComposedConfig(t *testing.T) {
+	_, err := NewComposedFromConfig(t.Context(), map[string]any{
+		"components": []map[string]any{
+			{"name": "levies", "price": 0.1},
+		},
+	})
+	assert.Error(t, err)
+
+	c, err := NewComposedFromConfig(t.Context(), map[string]any{
+		"components": []map[string]any{
+			{"name": "fee", "tariff": map[string]any{"type": "fixed", "price": 0.1}},
+			{"name": "vat", "factor": 1.2},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypePriceStatic, c.Type())
+
+	rr, err := c.Rates()
+	require.NoError(t, err)
+	require.NotEmpty(t, rr)
+	assert.InDelta(t, 0.12, rr[0].Value, 1e-9)
+}
@@ -2,6 +2,7 @@ package tariff
 
 import (
 	"errors"
+	"slices"
 	"strings"
 	"time"
 
@@ -71,3 +72,17 @@ func mergeRatesAfter(data *util.Monitor[api.Rates], new api.Rates, now time.Time
 func beginningOfDay() time.Time {
 	return now.With(time.Now()).BeginningOfDay()
 }
+
+// boundaries returns the sorted, distinct start and end times of all rates
+func boundaries(rates ...api.Rates) []time.Time {
+	var res []time.Time
+	for _, rr := range rates {
+		for _, r := range rr {
+			res = append(res, r.Start, r.End)
+		}
+	}
+
+	slices.SortFunc(res, time.Time.Compare)
+
+	return slices.CompactFunc(res, time.Time.Equal)
+}
@@ -21,13 +21,21 @@ type CachingProxy struct {
 	typ    string
 	config map[string]any
 
-	tariff api.Tariff
+	tariff  api.Tariff
+	observe func(start time.Time, err error) // tariff api health
+
+	// rate history
+	usage     string
+	name      string
+	persisted api.Rates
+	pruned    time.Time
 }
 
 var _ api.Tariff = (*CachingProxy)(nil)
 
-// NewCachedFromConfig creates a proxy that controls tariff instantiation and caching
-func NewCachedFromConfig(ctx context.Context, typ string, other map[string]any) (api.Tariff, error) {
+// NewCachedFromConfig creates a proxy that controls tariff instantiation and caching.
+// If usage is not empty, all fetched rates are persisted to the rate history by the tariff's configured name.
+func NewCachedFromConfig(ctx context.Context, name, usage, typ string, other map[string]any) (api.Tariff, error) {
 	tariffType := typ
 	if typ == "template" {
 		if template, ok := other["template"].(string); ok {
@@ -40,6 +48,8 @@ func NewCachedFromConfig(ctx context.Context, typ string, other map[string]any)
 		typ:    typ,
 		config: other,
 		key:    tariffType + "-" + cacheKey(typ, other),
+		usage:  usage,
+		name:   name,
 	}
 
 	// check if we have cached data until end of tomorrow
@@ -75,6 +85,14 @@ func NewCachedFromConfig(ctx context.Context, typ string, other map[string]any)
 	return p, nil
 }
 
+// SetObserver sets the function observing tariff api requests
+func (p *CachingProxy) SetObserver(fun func(start time.Time, err error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.observe = fun
+}
+
 func (p *CachingProxy) createInstance() {
 	t, err := NewFromConfig(p.ctx, p.typ, p.config)
 	if err != nil {
@@ -97,7 +115,12 @@ func (p *CachingProxy) Rates() (api.Rates, error) {
 		p.createInstance()
 	}
 
+	start := time.Now()
 	res, err := p.tariff.Rates()
+	if p.observe != nil {
+		p.observe(start, err)
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -106,9 +129,29 @@ func (p *CachingProxy) Rates() (api.Rates, error) {
 		err = p.cachePut(p.tariff.Type(), res)
 	}
 
+	if p.usage != "" && !slices.Equal(p.persisted, res) {
+		p.historyPut(res)
+	}
+
 	return res, err
 }
 
+// Components delegates to the tariff if it provides a breakdown of its rates
+func (p *CachingProxy) Components() ([]api.TariffComponent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.tariff == nil {
+		p.createInstance()
+	}
+
+	if tc, ok := p.tariff.(api.TariffComponents); ok {
+		return tc.Components()
+	}
+
+	return nil, api.ErrNotAvailable
+}
+
 // Type returns the tariff type
 func (p *CachingProxy) Type() api.TariffType {
 	p.mu.Lock()
@@ -3,9 +3,13 @@ package tariff
 import (
 	"crypto/sha256"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/server/db/cache"
+	"github.com/evcc-io/evcc/server/db/rates"
+	"github.com/evcc-io/evcc/util"
 )
 
 type cached struct {
@@ -28,3 +32,22 @@ func cacheGet(key string) (*cached, error) {
 	var res cached
 	return &res, cache.Get(key, &res)
 }
+
+// historyPut persists the rates to the rate history and prunes expired rates at most hourly
+func (p *CachingProxy) historyPut(rr api.Rates) {
+	log := util.NewLogger("tariff")
+
+	if err := rates.Persist(p.usage, p.name, rr); err != nil {
+		log.ERROR.Printf("rate history: %v", err)
+		return
+	}
+
+	p.persisted = slices.Clone(rr)
+
+	if time.Since(p.pruned) >= time.Hour {
+		if err := rates.Prune(time.Now()); err != nil {
+			log.ERROR.Printf("rate history: %v", err)
+		}
+		p.pruned = time.Now()
+	}
+}
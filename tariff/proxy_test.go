@@ -0,0 +1,36 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/rates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingProxyHistory(t *testing.T) {
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, rates.Init())
+
+	start := time.Now().Truncate(time.Hour)
+	rate := func(val float64) api.Rates {
+		return api.Rates{{Start: start, End: start.Add(time.Hour), Value: val}}
+	}
+
+	// two planes of the same tariff type
+	east := &CachingProxy{usage: "solar", name: "solar-solcast-0"}
+	west := &CachingProxy{usage: "solar", name: "solar-solcast-1"}
+
+	east.historyPut(rate(1))
+	west.historyPut(rate(2))
+
+	for name, val := range map[string]float64{"solar-solcast-0": 1, "solar-solcast-1": 2} {
+		rr, err := rates.Get("solar", name, start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, rr, 1)
+		assert.Equal(t, val, rr[0].Value, name)
+	}
+}
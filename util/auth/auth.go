@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/evcc-io/evcc/core/keys"
@@ -31,6 +32,16 @@ type Auth interface {
 	GenerateJwtToken(time.Duration) (string, error)
 	ValidateJwtToken(string) (bool, error)
 	IsAdminPasswordConfigured() bool
+	Authenticate(string) (*Principal, error)
+	HasUsers() bool
+	Users() ([]User, error)
+	SaveUser(User, string) error
+	DeleteUser(string) error
+	IsUserPasswordValid(string, string) bool
+	GenerateUserJwtToken(string, time.Duration) (string, error)
+	Tokens(string) ([]Token, error)
+	CreateToken(*Principal, Token) (string, Token, error)
+	RevokeToken(uint) error
 	SetAuthMode(AuthMode)
 	GetAuthMode() AuthMode
 }
@@ -120,11 +131,33 @@ func (a *auth) getJwtSecret() ([]byte, error) {
 	return []byte(jwtSecret), nil
 }
 
+// jwtClaims are the JWT claims. The generation ties user tokens to the user's current password.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Generation uint `json:"gen,omitempty"`
+}
+
 // GenerateJwtToken generates an admin user JWT token with the given lifetime
 func (a *auth) GenerateJwtToken(lifetime time.Duration) (string, error) {
-	claims := &jwt.RegisteredClaims{
-		Subject:   admin,
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(lifetime)),
+	return a.generateJwtToken(admin, 0, lifetime)
+}
+
+// GenerateUserJwtToken generates a JWT token for the given user with the given lifetime
+func (a *auth) GenerateUserJwtToken(name string, lifetime time.Duration) (string, error) {
+	user, err := a.user(name)
+	if err != nil {
+		return "", err
+	}
+	return a.generateJwtToken(name, user.Generation, lifetime)
+}
+
+func (a *auth) generateJwtToken(subject string, generation uint, lifetime time.Duration) (string, error) {
+	claims := &jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(lifetime)),
+		},
+		Generation: generation,
 	}
 
 	if jwtSecret, err := a.getJwtSecret(); err != nil {
@@ -135,24 +168,59 @@ func (a *auth) GenerateJwtToken(lifetime time.Duration) (string, error) {
 	}
 }
 
-// ValidateJwtToken validates the given JWT token
-func (a *auth) ValidateJwtToken(tokenString string) (bool, error) {
+// parseJwt validates the given JWT token and returns its claims
+func (a *auth) parseJwt(tokenString string, opts ...jwt.ParserOption) (jwtClaims, error) {
+	var claims jwtClaims
+
 	jwtSecret, err := a.getJwtSecret()
 	if err != nil {
-		return false, err
+		return claims, err
 	}
 
 	// read token
-	var claims jwt.RegisteredClaims
-	if _, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+	_, err = jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
 		return jwtSecret, nil
-	}, jwt.WithSubject(admin)); err != nil {
+	}, opts...)
+
+	return claims, err
+}
+
+// ValidateJwtToken validates the given admin JWT token
+func (a *auth) ValidateJwtToken(tokenString string) (bool, error) {
+	if _, err := a.parseJwt(tokenString, jwt.WithSubject(admin)); err != nil {
 		return false, err
 	}
 
 	return true, nil
 }
 
+// Authenticate returns the principal identified by the given JWT or api token
+func (a *auth) Authenticate(token string) (*Principal, error) {
+	if strings.HasPrefix(token, TokenPrefix) {
+		return a.authenticateToken(token)
+	}
+
+	claims, err := a.parseJwt(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == admin {
+		return a.principal(admin)
+	}
+
+	user, err := a.user(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Generation != claims.Generation {
+		return nil, ErrTokenRevoked
+	}
+
+	return user.principal(), nil
+}
+
 func (a *auth) SetAuthMode(authMode AuthMode) {
 	a.authMode = authMode
 }
@@ -0,0 +1,390 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// TokenPrefix identifies api tokens as opposed to JWTs
+const TokenPrefix = "evcc_"
+
+var (
+	ErrUnknownUser  = errors.New("unknown user")
+	ErrUnknownToken = errors.New("unknown token")
+	ErrTokenExpired = errors.New("token expired")
+	ErrTokenRevoked = errors.New("token revoked")
+	ErrScope        = errors.New("scope exceeds permissions")
+)
+
+// Role is the role of a user or the scope of an api token
+type Role string
+
+const (
+	RoleViewer Role = "viewer" // read access
+	RoleDriver Role = "driver" // control of permitted loadpoints and vehicles
+	RoleAdmin  Role = "admin"  // full access including configuration and system
+)
+
+// ParseRole validates the role
+func ParseRole(s string) (Role, error) {
+	if r := Role(strings.ToLower(s)); r.rank() > 0 {
+		return r, nil
+	}
+	return "", fmt.Errorf("invalid role: %s", s)
+}
+
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleDriver:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Includes checks if the role grants the permissions of the required role
+func (r Role) Includes(required Role) bool {
+	return r.rank() > 0 && r.rank() >= required.rank()
+}
+
+// Principal is an authenticated caller with its effective permissions.
+// Access to loadpoints and vehicles is limited to the listed ones unless all are granted explicitly.
+type Principal struct {
+	User          string   `json:"user"`
+	Role          Role     `json:"role"`
+	AllLoadpoints bool     `json:"allLoadpoints"`
+	Loadpoints    []string `json:"loadpoints,omitempty"` // loadpoint config names
+	AllVehicles   bool     `json:"allVehicles"`
+	Vehicles      []string `json:"vehicles,omitempty"`
+	Token         uint     `json:"token,omitempty"` // api token id if authenticated by token
+}
+
+// Allows checks if the principal has the required role
+func (p *Principal) Allows(role Role) bool {
+	return p != nil && p.Role.Includes(role)
+}
+
+// AllowsLoadpoint checks if the principal may access the loadpoint by config name. Admins may access all loadpoints.
+func (p *Principal) AllowsLoadpoint(name string) bool {
+	return p.Allows(RoleAdmin) || p != nil && (p.AllLoadpoints || slices.Contains(p.Loadpoints, name))
+}
+
+// AllowsVehicle checks if the principal may access the vehicle by name. Admins may access all vehicles.
+func (p *Principal) AllowsVehicle(name string) bool {
+	return p.Allows(RoleAdmin) || p != nil && (p.AllVehicles || slices.Contains(p.Vehicles, name))
+}
+
+// User is a user account
+type User struct {
+	Name          string    `json:"name" gorm:"primarykey"`
+	Password      string    `json:"-"`
+	Generation    uint      `json:"-"` // incremented on password change to invalidate issued JWTs
+	Role          Role      `json:"role"`
+	AllLoadpoints bool      `json:"allLoadpoints"`
+	Loadpoints    []string  `json:"loadpoints,omitempty" gorm:"serializer:json"` // loadpoint config names
+	AllVehicles   bool      `json:"allVehicles"`
+	Vehicles      []string  `json:"vehicles,omitempty" gorm:"serializer:json"`
+	Created       time.Time `json:"created"`
+}
+
+// TableName implements gorm's tabler interface
+func (User) TableName() string {
+	return "auth_users"
+}
+
+func (u User) principal() *Principal {
+	return &Principal{
+		User:          u.Name,
+		Role:          u.Role,
+		AllLoadpoints: u.AllLoadpoints,
+		Loadpoints:    u.Loadpoints,
+		AllVehicles:   u.AllVehicles,
+		Vehicles:      u.Vehicles,
+	}
+}
+
+// Token is a long-lived api token. The secret is only stored as hash.
+// If all loadpoints or vehicles are granted, the token inherits the owner's restriction.
+type Token struct {
+	ID            uint       `json:"id" gorm:"primarykey"`
+	Name          string     `json:"name"`
+	User          string     `json:"user" gorm:"column:owner;index"`
+	Role          Role       `json:"role"`
+	AllLoadpoints bool       `json:"allLoadpoints"`
+	Loadpoints    []string   `json:"loadpoints,omitempty" gorm:"serializer:json"` // loadpoint config names
+	AllVehicles   bool       `json:"allVehicles"`
+	Vehicles      []string   `json:"vehicles,omitempty" gorm:"serializer:json"`
+	Hash          string     `json:"-" gorm:"uniqueIndex"`
+	Created       time.Time  `json:"created"`
+	Expires       *time.Time `json:"expires,omitempty"`
+	Used          *time.Time `json:"used,omitempty"`
+}
+
+// TableName implements gorm's tabler interface
+func (Token) TableName() string {
+	return "auth_tokens"
+}
+
+// Init creates the user and token tables
+func Init() error {
+	return db.Instance.AutoMigrate(new(User), new(Token))
+}
+
+func tokenHash(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// restrict narrows the owner's restriction to the requested one.
+// Returns false if the requested restriction is not a subset of the owner's.
+func restrict[T comparable](ownerAll bool, owner []T, all bool, requested []T) (bool, []T, bool) {
+	if all {
+		return ownerAll, owner, true
+	}
+
+	for _, v := range requested {
+		if !ownerAll && !slices.Contains(owner, v) {
+			return false, nil, false
+		}
+	}
+
+	return false, requested, true
+}
+
+// narrow returns the principal's permissions reduced to the token's scope
+func (p *Principal) narrow(t Token) (*Principal, error) {
+	if !p.Role.Includes(t.Role) {
+		return nil, ErrScope
+	}
+
+	allLoadpoints, loadpoints, ok := restrict(p.AllLoadpoints, p.Loadpoints, t.AllLoadpoints, t.Loadpoints)
+	if !ok {
+		return nil, ErrScope
+	}
+
+	allVehicles, vehicles, ok := restrict(p.AllVehicles, p.Vehicles, t.AllVehicles, t.Vehicles)
+	if !ok {
+		return nil, ErrScope
+	}
+
+	return &Principal{
+		User:          p.User,
+		Role:          t.Role,
+		AllLoadpoints: allLoadpoints,
+		Loadpoints:    loadpoints,
+		AllVehicles:   allVehicles,
+		Vehicles:      vehicles,
+		Token:         t.ID,
+	}, nil
+}
+
+// HasUsers checks if any user accounts are configured
+func (a *auth) HasUsers() bool {
+	if db.Instance == nil {
+		return false
+	}
+
+	var count int64
+	return db.Instance.Model(new(User)).Count(&count).Error == nil && count > 0
+}
+
+// Users returns all user accounts
+func (a *auth) Users() ([]User, error) {
+	var res []User
+	err := db.Instance.Order("name").Find(&res).Error
+	return res, err
+}
+
+func (a *auth) user(name string) (User, error) {
+	var res User
+	err := db.Instance.Where("name = ?", name).First(&res).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = ErrUnknownUser
+	}
+	return res, err
+}
+
+// SaveUser creates or updates a user account. The password is only updated if not empty.
+// Changing the password invalidates the user's issued JWTs.
+func (a *auth) SaveUser(user User, password string) error {
+	if user.Name == "" || strings.EqualFold(user.Name, admin) {
+		return fmt.Errorf("invalid user name: %s", user.Name)
+	}
+
+	role, err := ParseRole(string(user.Role))
+	if err != nil {
+		return err
+	}
+	user.Role = role
+
+	existing, err := a.user(user.Name)
+	switch {
+	case err == nil:
+		user.Password = existing.Password
+		user.Generation = existing.Generation
+		user.Created = existing.Created
+	case errors.Is(err, ErrUnknownUser):
+		if password == "" {
+			return errors.New("password cannot be empty")
+		}
+		user.Created = time.Now()
+	default:
+		return err
+	}
+
+	if password != "" {
+		if user.Password, err = a.hashPassword(password); err != nil {
+			return err
+		}
+		user.Generation++
+	}
+
+	return db.Instance.Save(&user).Error
+}
+
+// DeleteUser deletes the user account and revokes its api tokens
+func (a *auth) DeleteUser(name string) error {
+	return db.Instance.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("name = ?", name).Delete(new(User))
+		if res.Error == nil && res.RowsAffected == 0 {
+			return ErrUnknownUser
+		}
+		if res.Error != nil {
+			return res.Error
+		}
+		return tx.Where("owner = ?", name).Delete(new(Token)).Error
+	})
+}
+
+// IsUserPasswordValid checks if the given password matches the user's password
+func (a *auth) IsUserPasswordValid(name, password string) bool {
+	user, err := a.user(name)
+	if err != nil {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) == nil
+}
+
+// principal returns the current permissions of the named user
+func (a *auth) principal(name string) (*Principal, error) {
+	if name == admin {
+		return &Principal{User: admin, Role: RoleAdmin, AllLoadpoints: true, AllVehicles: true}, nil
+	}
+
+	user, err := a.user(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.principal(), nil
+}
+
+// Tokens returns the api tokens of the given user or all tokens if user is empty
+func (a *auth) Tokens(user string) ([]Token, error) {
+	tx := db.Instance.Order("id")
+	if user != "" {
+		tx = tx.Where("owner = ?", user)
+	}
+
+	var res []Token
+	err := tx.Find(&res).Error
+	return res, err
+}
+
+// CreateToken creates an api token owned by the principal. The token's scope must not exceed the owner's permissions.
+// The secret is returned only once.
+func (a *auth) CreateToken(owner *Principal, token Token) (string, Token, error) {
+	if token.Role == "" {
+		token.Role = owner.Role
+	}
+
+	role, err := ParseRole(string(token.Role))
+	if err != nil {
+		return "", Token{}, err
+	}
+	token.Role = role
+
+	if _, err := owner.narrow(token); err != nil {
+		return "", Token{}, err
+	}
+
+	key, err := a.generateRandomKey(32)
+	if err != nil {
+		return "", Token{}, err
+	}
+
+	secret := TokenPrefix + key
+
+	token.ID = 0
+	token.User = owner.User
+	token.Hash = tokenHash(secret)
+	token.Created = time.Now()
+	token.Used = nil
+
+	if err := db.Instance.Create(&token).Error; err != nil {
+		return "", Token{}, err
+	}
+
+	return secret, token, nil
+}
+
+// RevokeToken deletes the api token
+func (a *auth) RevokeToken(id uint) error {
+	res := db.Instance.Delete(new(Token), id)
+	if res.Error == nil && res.RowsAffected == 0 {
+		return ErrUnknownToken
+	}
+	return res.Error
+}
+
+// authenticateToken returns the principal of the api token narrowed to the owner's current permissions
+func (a *auth) authenticateToken(secret string) (*Principal, error) {
+	if db.Instance == nil {
+		return nil, ErrUnknownToken
+	}
+
+	var token Token
+	err := db.Instance.Where("hash = ?", tokenHash(secret)).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUnknownToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if token.Expires != nil && now.After(*token.Expires) {
+		return nil, ErrTokenExpired
+	}
+
+	owner, err := a.principal(token.User)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := owner.narrow(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// track usage with minute resolution to limit writes
+	if token.Used == nil || now.Sub(*token.Used) > time.Minute {
+		_ = db.Instance.Model(&token).Update("used", now).Error
+	}
+
+	return res, nil
+}
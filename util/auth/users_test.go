@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupUsers(t *testing.T) Auth {
+	t.Helper()
+
+	require.NoError(t, db.NewInstance("sqlite", ":memory:"))
+	require.NoError(t, Init())
+
+	ctrl := gomock.NewController(t)
+	mock := settings.NewMockAPI(ctrl)
+	mock.EXPECT().String(keys.JwtSecret).Return("somesecret", nil).AnyTimes()
+
+	return NewMock(mock)
+}
+
+func TestRoleIncludes(t *testing.T) {
+	assert.True(t, RoleAdmin.Includes(RoleDriver))
+	assert.True(t, RoleDriver.Includes(RoleDriver))
+	assert.False(t, RoleViewer.Includes(RoleDriver))
+	assert.False(t, Role("").Includes(""))
+
+	_, err := ParseRole("superuser")
+	assert.Error(t, err)
+}
+
+func TestUsers(t *testing.T) {
+	a := setupUsers(t)
+
+	assert.False(t, a.HasUsers())
+	assert.Error(t, a.SaveUser(User{Name: "admin", Role: RoleAdmin}, "secret"), "reserved name")
+	assert.Error(t, a.SaveUser(User{Name: "alice", Role: RoleDriver}, ""), "missing password")
+
+	require.NoError(t, a.SaveUser(User{Name: "alice", Role: RoleDriver, Loadpoints: []string{"lp-2"}, AllVehicles: true}, "secret"))
+	assert.True(t, a.HasUsers())
+	assert.True(t, a.IsUserPasswordValid("alice", "secret"))
+	assert.False(t, a.IsUserPasswordValid("alice", "wrong"))
+
+	// jwt carries the user's current permissions
+	jwt, err := a.GenerateUserJwtToken("alice", time.Hour)
+	require.NoError(t, err)
+
+	p, err := a.Authenticate(jwt)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", p.User)
+	assert.True(t, p.Allows(RoleDriver))
+	assert.False(t, p.Allows(RoleAdmin))
+	assert.True(t, p.AllowsLoadpoint("lp-2"))
+	assert.False(t, p.AllowsLoadpoint("lp-1"))
+	assert.True(t, p.AllowsVehicle("any"))
+
+	// update keeps password
+	require.NoError(t, a.SaveUser(User{Name: "alice", Role: RoleViewer}, ""))
+	assert.True(t, a.IsUserPasswordValid("alice", "secret"))
+
+	// cleared restriction grants no access
+	p, err = a.Authenticate(jwt)
+	require.NoError(t, err)
+	assert.False(t, p.Allows(RoleDriver))
+	assert.False(t, p.AllowsLoadpoint("lp-2"))
+	assert.False(t, p.AllowsVehicle("any"))
+
+	// password change invalidates issued jwts
+	require.NoError(t, a.SaveUser(User{Name: "alice", Role: RoleViewer}, "changed"))
+	_, err = a.Authenticate(jwt)
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+
+	jwt, err = a.GenerateUserJwtToken("alice", time.Hour)
+	require.NoError(t, err)
+	_, err = a.Authenticate(jwt)
+	require.NoError(t, err)
+
+	// admin jwt is not a user jwt
+	ok, _ := a.ValidateJwtToken(jwt)
+	assert.False(t, ok)
+
+	require.NoError(t, a.DeleteUser("alice"))
+	_, err = a.Authenticate(jwt)
+	assert.ErrorIs(t, err, ErrUnknownUser)
+	assert.ErrorIs(t, a.DeleteUser("alice"), ErrUnknownUser)
+}
+
+func TestTokens(t *testing.T) {
+	a := setupUsers(t)
+
+	require.NoError(t, a.SaveUser(User{Name: "bob", Role: RoleDriver, AllLoadpoints: true, Vehicles: []string{"car1", "car2"}}, "secret"))
+
+	owner, err := a.Authenticate(must(a.GenerateUserJwtToken("bob", time.Hour)))
+	require.NoError(t, err)
+
+	// scope must not exceed owner's permissions
+	_, _, err = a.CreateToken(owner, Token{Role: RoleAdmin})
+	assert.ErrorIs(t, err, ErrScope)
+	_, _, err = a.CreateToken(owner, Token{Vehicles: []string{"car3"}})
+	assert.ErrorIs(t, err, ErrScope)
+
+	secret, token, err := a.CreateToken(owner, Token{Name: "home automation", AllLoadpoints: true, Vehicles: []string{"car1"}})
+	require.NoError(t, err)
+	assert.Contains(t, secret, TokenPrefix)
+	assert.Equal(t, "bob", token.User)
+	assert.Equal(t, RoleDriver, token.Role)
+
+	p, err := a.Authenticate(secret)
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, p.Token)
+	assert.True(t, p.AllowsVehicle("car1"))
+	assert.False(t, p.AllowsVehicle("car2"))
+	assert.True(t, p.AllowsLoadpoint("lp-1"))
+
+	_, err = a.Authenticate(TokenPrefix + "invalid")
+	assert.ErrorIs(t, err, ErrUnknownToken)
+
+	// owner downgrade limits the token
+	require.NoError(t, a.SaveUser(User{Name: "bob", Role: RoleViewer}, ""))
+	_, err = a.Authenticate(secret)
+	assert.ErrorIs(t, err, ErrScope)
+
+	tokens, err := a.Tokens("bob")
+	require.NoError(t, err)
+	assert.Len(t, tokens, 1)
+
+	require.NoError(t, a.RevokeToken(token.ID))
+	_, err = a.Authenticate(secret)
+	assert.ErrorIs(t, err, ErrUnknownToken)
+	assert.ErrorIs(t, a.RevokeToken(token.ID), ErrUnknownToken)
+
+	// expired
+	expired := time.Now().Add(-time.Minute)
+	secret, _, err = a.CreateToken(&Principal{User: admin, Role: RoleAdmin}, Token{Role: RoleViewer, Expires: &expired})
+	require.NoError(t, err)
+	_, err = a.Authenticate(secret)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
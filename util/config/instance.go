@@ -64,3 +64,13 @@ func Instances[T any](devices []Device[T]) []T {
 	}
 	return res
 }
+
+// InstanceName returns the config name of the device instance or empty if not found
+func InstanceName[T comparable](h Handler[T], instance T) string {
+	for _, dev := range h.Devices() {
+		if dev.Instance() == instance {
+			return dev.Config().Name
+		}
+	}
+	return ""
+}
@@ -30,7 +30,8 @@ var LogAreaPadding = 6
 type Logger struct {
 	*jww.Notepad
 	*Redactor
-	lp int
+	area string
+	lp   int
 }
 
 // NewLogger creates a logger with the given log area and adds it to the registry
@@ -66,6 +67,7 @@ func newLogger(area string, lp int) *Logger {
 	logger := &Logger{
 		Notepad:  notepad,
 		Redactor: redactor,
+		area:     area,
 		lp:       lp,
 	}
 
@@ -79,6 +81,11 @@ func newLogger(area string, lp int) *Logger {
 	return logger
 }
 
+// Area returns the logger's log area
+func (l *Logger) Area() string {
+	return l.area
+}
+
 // Redact adds items for redaction
 func (l *Logger) Redact(items ...string) *Logger {
 	l.Redactor.Redact(items...)
@@ -54,7 +54,10 @@ func (c *Connection) exec(fun func() ([]byte, error)) ([]byte, error) {
 	return c.WithLogger(c.logical, func() ([]byte, error) {
 		time.Sleep(c.delay)
 
+		start := time.Now()
 		b, err := fun()
+		observe(c.Addr(), start, err)
+
 		if err != nil {
 			c.Connection.Close()
 		}
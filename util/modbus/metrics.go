@@ -0,0 +1,49 @@
+package modbus
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	reqMetric *prometheus.SummaryVec
+	resMetric *prometheus.CounterVec
+)
+
+func init() {
+	labels := []string{"connection"}
+
+	reqMetric = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: "evcc",
+		Subsystem: "modbus",
+		Name:      "request_duration_seconds",
+		Help:      "A summary of Modbus request durations",
+		Objectives: map[float64]float64{
+			0.5:  0.05,  // 50th percentile with a max. absolute error of 0.05
+			0.9:  0.01,  // 90th percentile with a max. absolute error of 0.01
+			0.99: 0.001, // 99th percentile with a max. absolute error of 0.001
+		},
+	}, labels)
+
+	resMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "evcc",
+		Subsystem: "modbus",
+		Name:      "request_total",
+		Help:      "Total count of Modbus requests",
+	}, append(labels, "status"))
+
+	prometheus.MustRegister(reqMetric, resMetric)
+}
+
+// observe records duration and result of a request
+func observe(connection string, start time.Time, err error) {
+	reqMetric.WithLabelValues(connection).Observe(time.Since(start).Seconds())
+
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+
+	resMetric.WithLabelValues(connection, status).Add(1)
+}
@@ -26,7 +26,7 @@ var (
 )
 
 func init() {
-	labels := []string{"host"}
+	labels := []string{"host", "device"}
 
 	reqMetric = prometheus.NewSummaryVec(prometheus.SummaryOpts{
 		Namespace: "evcc",
@@ -114,10 +114,10 @@ func (r *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	startTime := time.Now()
 	resp, err := r.base.RoundTrip(req)
 
-	reqMetric.WithLabelValues(req.URL.Hostname()).Observe(time.Since(startTime).Seconds())
+	reqMetric.WithLabelValues(req.URL.Hostname(), r.log.Area()).Observe(time.Since(startTime).Seconds())
 
 	if err == nil {
-		resMetric.WithLabelValues(req.URL.Hostname(), strconv.Itoa(resp.StatusCode)).Add(1)
+		resMetric.WithLabelValues(req.URL.Hostname(), r.log.Area(), strconv.Itoa(resp.StatusCode)).Add(1)
 
 		if LogHeaders {
 			if body, err := httputil.DumpResponse(resp, true); err == nil {
@@ -133,7 +133,7 @@ func (r *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 			}
 		}
 	} else {
-		resMetric.WithLabelValues(req.URL.Hostname(), "999").Add(1)
+		resMetric.WithLabelValues(req.URL.Hostname(), r.log.Area(), "999").Add(1)
 	}
 
 	if bld.Len() > 0 {
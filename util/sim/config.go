@@ -0,0 +1,70 @@
+package sim
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config is the simulation configuration
+type Config struct {
+	Speed   float64 // clock acceleration, e.g. 60 runs one simulated hour per minute.
+	Start   string  // simulated time of day at startup (hh:mm), defaults to current time
+	Profile string  // csv file with time of day (hh:mm), pv and home power in W
+	Voltage float64
+	Vehicle Vehicle
+}
+
+// Vehicle is the simulated vehicle configuration
+type Vehicle struct {
+	Title      string
+	Capacity   float64 // kWh
+	Soc        float64 // soc when plugged in
+	Phases     int
+	MaxCurrent float64 // on-board charger limit
+	Taper      float64 // soc above which charge power declines
+	Arrival    string  // time of day (hh:mm) the vehicle is plugged in, always connected if empty
+	Departure  string  // time of day (hh:mm) the vehicle is unplugged
+}
+
+func (cc *Config) defaults() {
+	if cc.Speed == 0 {
+		cc.Speed = 1
+	}
+	if cc.Voltage == 0 {
+		cc.Voltage = 230
+	}
+	if cc.Vehicle.Title == "" {
+		cc.Vehicle.Title = "Simulated vehicle"
+	}
+	if cc.Vehicle.Capacity == 0 {
+		cc.Vehicle.Capacity = 60
+	}
+	if cc.Vehicle.Soc == 0 {
+		cc.Vehicle.Soc = 20
+	}
+	if cc.Vehicle.Phases == 0 {
+		cc.Vehicle.Phases = 3
+	}
+	if cc.Vehicle.MaxCurrent == 0 {
+		cc.Vehicle.MaxCurrent = 16
+	}
+	if cc.Vehicle.Taper == 0 {
+		cc.Vehicle.Taper = 80
+	}
+}
+
+// timeOfDay parses hh:mm into the duration since midnight
+func timeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day: %s", s)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// sinceMidnight returns the duration since midnight of ts
+func sinceMidnight(ts time.Time) time.Duration {
+	y, m, d := ts.Date()
+	return ts.Sub(time.Date(y, m, d, 0, 0, 0, 0, ts.Location()))
+}
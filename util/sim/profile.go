@@ -0,0 +1,117 @@
+package sim
+
+import (
+	_ "embed" // for csv
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed profile.csv
+var defaultProfile string
+
+const day = 24 * time.Hour
+
+// sample is a profile value at a given time of day
+type sample struct {
+	offset   time.Duration
+	pv, home float64
+}
+
+// profile is a daily pv and home power profile
+type profile []sample
+
+// loadProfile reads a profile from file or uses the default profile if empty
+func loadProfile(file string) (profile, error) {
+	if file == "" {
+		return parseProfile(strings.NewReader(defaultProfile))
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseProfile(f)
+}
+
+// parseProfile parses csv rows of time of day (hh:mm), pv and home power in W
+func parseProfile(r io.Reader) (profile, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var res profile
+
+	for i, rec := range records {
+		offset, err := timeOfDay(rec[0])
+		if err != nil {
+			// header
+			if i == 0 {
+				continue
+			}
+			return nil, err
+		}
+
+		var vals [2]float64
+		for j, s := range rec[1:] {
+			if vals[j], err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+		}
+
+		res = append(res, sample{offset: offset, pv: vals[0], home: vals[1]})
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("empty profile")
+	}
+
+	slices.SortFunc(res, func(a, b sample) int {
+		return int(a.offset - b.offset)
+	})
+
+	return res, nil
+}
+
+// at returns the linearly interpolated pv and home power at given time of day
+func (p profile) at(ts time.Time) (float64, float64) {
+	offset := sinceMidnight(ts)
+
+	// first sample after offset, wrapping around midnight
+	i := slices.IndexFunc(p, func(s sample) bool {
+		return s.offset > offset
+	})
+
+	var prev, next sample
+	switch i {
+	case -1:
+		prev, next = p[len(p)-1], p[0]
+		next.offset += day
+	case 0:
+		prev, next = p[len(p)-1], p[0]
+		prev.offset -= day
+	default:
+		prev, next = p[i-1], p[i]
+	}
+
+	if next.offset == prev.offset {
+		return prev.pv, prev.home
+	}
+
+	f := float64(offset-prev.offset) / float64(next.offset-prev.offset)
+
+	return prev.pv + f*(next.pv-prev.pv), prev.home + f*(next.home-prev.home)
+}
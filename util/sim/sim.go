@@ -0,0 +1,387 @@
+package sim
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+const (
+	step = time.Minute            // maximum simulated duration integrated at once
+	tick = 100 * time.Millisecond // real time interval for advancing the simulated clock
+)
+
+var (
+	mu          sync.Mutex
+	simulations = make(map[string]*Simulation)
+	simClock    clock.Clock // clock of the first simulation
+)
+
+// Simulation models a vehicle plugged into a charger at a site with pv production and house load.
+// Simulated time is provided by the simulation's clock, all values are updated on access.
+type Simulation struct {
+	mu      sync.Mutex
+	log     *util.Logger
+	clock   clock.Clock
+	voltage float64
+	profile profile
+	vehicle Vehicle
+
+	arrival, departure time.Duration
+
+	updated   time.Time // simulated time of last update
+	connected bool      // vehicle is plugged in according to arrival and departure
+	enabled   bool
+	current   float64
+	phases    int
+	soc       float64
+
+	chargePower, pvPower, homePower float64
+
+	chargeEnergy, pvEnergy, homeEnergy, importEnergy, exportEnergy float64 // kWh
+}
+
+// Register creates a named simulation running on a clock accelerated by the configured speed
+func Register(name string, cc Config) (*Simulation, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if name == "" {
+		name = "default"
+	}
+
+	if _, ok := simulations[name]; ok {
+		return nil, fmt.Errorf("duplicate simulation: %s", name)
+	}
+
+	cc.defaults()
+
+	start := time.Now()
+	if cc.Start != "" {
+		offset, err := timeOfDay(cc.Start)
+		if err != nil {
+			return nil, err
+		}
+
+		y, m, d := start.Date()
+		start = time.Date(y, m, d, 0, 0, 0, 0, start.Location()).Add(offset)
+	}
+
+	clock := clock.NewMock()
+	clock.Set(start)
+
+	s, err := New(util.NewLogger("sim-"+name), clock, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	go accelerate(clock, cc.Speed)
+
+	simulations[name] = s
+	if simClock == nil {
+		simClock = clock
+	}
+
+	return s, nil
+}
+
+// accelerate advances the simulated clock by speed times the elapsed real time
+func accelerate(clock *clock.Mock, speed float64) {
+	for range time.Tick(tick) {
+		clock.Add(time.Duration(speed * float64(tick)))
+	}
+}
+
+// Clock returns the simulated clock of the first registered simulation or nil if no simulation is registered
+func Clock() clock.Clock {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return simClock
+}
+
+// Instance returns a registered simulation
+func Instance(name string) (*Simulation, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if name == "" {
+		name = "default"
+	}
+
+	s, ok := simulations[name]
+	if !ok {
+		return nil, fmt.Errorf("simulation not configured: %s", name)
+	}
+
+	return s, nil
+}
+
+// New creates a simulation driven by the given simulated clock
+func New(log *util.Logger, clock clock.Clock, cc Config) (*Simulation, error) {
+	cc.defaults()
+
+	profile, err := loadProfile(cc.Profile)
+	if err != nil {
+		return nil, fmt.Errorf("profile: %w", err)
+	}
+
+	s := &Simulation{
+		log:     log,
+		clock:   clock,
+		voltage: cc.Voltage,
+		profile: profile,
+		vehicle: cc.Vehicle,
+		phases:  3,
+		soc:     cc.Vehicle.Soc,
+	}
+
+	now := clock.Now()
+
+	if (cc.Vehicle.Arrival == "") != (cc.Vehicle.Departure == "") {
+		return nil, fmt.Errorf("vehicle: arrival and departure must be configured together")
+	}
+
+	s.connected = true
+	if cc.Vehicle.Arrival != "" {
+		if s.arrival, err = timeOfDay(cc.Vehicle.Arrival); err != nil {
+			return nil, err
+		}
+		if s.departure, err = timeOfDay(cc.Vehicle.Departure); err != nil {
+			return nil, err
+		}
+		s.connected = s.connectedAt(now)
+	}
+
+	s.updated = now
+	s.pvPower, s.homePower = s.profile.at(now)
+
+	return s, nil
+}
+
+// Now returns the simulated time
+func (s *Simulation) Now() time.Time {
+	return s.clock.Now()
+}
+
+// connectedAt returns if the vehicle is plugged in at given simulated time
+func (s *Simulation) connectedAt(ts time.Time) bool {
+	if s.arrival == s.departure {
+		return true
+	}
+
+	offset := sinceMidnight(ts)
+	if s.arrival < s.departure {
+		return offset >= s.arrival && offset < s.departure
+	}
+
+	// overnight
+	return offset >= s.arrival || offset < s.departure
+}
+
+// chargePowerAt returns the charge power for the current charger and vehicle state
+func (s *Simulation) chargePowerAt() float64 {
+	if !s.connected || !s.enabled || s.soc >= 100 {
+		return 0
+	}
+
+	phases := min(s.phases, s.vehicle.Phases)
+	power := min(s.current, s.vehicle.MaxCurrent) * s.voltage * float64(phases)
+
+	// linear decline above taper soc, leaving at least 10% to reach full soc
+	if s.soc > s.vehicle.Taper && s.vehicle.Taper < 100 {
+		power *= max(0.1, (100-s.soc)/(100-s.vehicle.Taper))
+	}
+
+	return power
+}
+
+// update advances the simulation to the current simulated time
+func (s *Simulation) update() {
+	now := s.Now()
+
+	for s.updated.Before(now) {
+		ts := s.updated.Add(step)
+		if ts.After(now) {
+			ts = now
+		}
+		dt := ts.Sub(s.updated).Hours()
+
+		if connected := s.connectedAt(ts); connected != s.connected {
+			s.connected = connected
+
+			if connected {
+				s.soc = s.vehicle.Soc
+				s.log.DEBUG.Printf("vehicle arrived at %s", ts.Format("15:04"))
+			} else {
+				s.log.DEBUG.Printf("vehicle departed at %s", ts.Format("15:04"))
+			}
+		}
+
+		s.chargePower = s.chargePowerAt()
+		s.pvPower, s.homePower = s.profile.at(ts)
+
+		charged := min(s.chargePower*dt/1e3, (100-s.soc)*s.vehicle.Capacity/100)
+		s.chargeEnergy += charged
+		s.soc += 100 * charged / s.vehicle.Capacity
+
+		s.pvEnergy += s.pvPower * dt / 1e3
+		s.homeEnergy += s.homePower * dt / 1e3
+
+		if grid := (s.homePower-s.pvPower)*dt/1e3 + charged; grid > 0 {
+			s.importEnergy += grid
+		} else {
+			s.exportEnergy -= grid
+		}
+
+		s.updated = ts
+	}
+
+	// reflect state changes immediately
+	s.chargePower = s.chargePowerAt()
+}
+
+func (s *Simulation) gridPower() float64 {
+	return s.homePower + s.chargePower - s.pvPower
+}
+
+// Status returns the charger status
+func (s *Simulation) Status() api.ChargeStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+
+	switch {
+	case !s.connected:
+		return api.StatusA
+	case s.chargePower > 0:
+		return api.StatusC
+	default:
+		return api.StatusB
+	}
+}
+
+// Enabled returns the charger enabled state
+func (s *Simulation) Enabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.enabled
+}
+
+// Enable enables or disables the charger
+func (s *Simulation) Enable(enable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+	s.enabled = enable
+	s.chargePower = s.chargePowerAt()
+}
+
+// SetCurrent sets the charger's max current
+func (s *Simulation) SetCurrent(current float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+	s.current = current
+	s.chargePower = s.chargePowerAt()
+}
+
+// SetPhases sets the charger's phases
+func (s *Simulation) SetPhases(phases int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+	s.phases = phases
+	s.chargePower = s.chargePowerAt()
+}
+
+// Connected returns if the vehicle is connected to the charger
+func (s *Simulation) Connected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+
+	return s.connected
+}
+
+// Vehicle returns the vehicle configuration
+func (s *Simulation) Vehicle() Vehicle {
+	return s.vehicle
+}
+
+// Soc returns the vehicle soc
+func (s *Simulation) Soc() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+
+	return s.soc
+}
+
+// Charge returns the charge power and total charged energy
+func (s *Simulation) Charge() (float64, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+
+	return s.chargePower, s.chargeEnergy
+}
+
+// Currents returns the charge phase currents
+func (s *Simulation) Currents() (float64, float64, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+
+	var res [3]float64
+	if phases := min(s.phases, s.vehicle.Phases); phases > 0 {
+		for i := range phases {
+			res[i] = s.chargePower / s.voltage / float64(phases)
+		}
+	}
+
+	return res[0], res[1], res[2]
+}
+
+// PV returns the pv power and total produced energy
+func (s *Simulation) PV() (float64, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+
+	return s.pvPower, s.pvEnergy
+}
+
+// Home returns the house load and total consumed energy
+func (s *Simulation) Home() (float64, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+
+	return s.homePower, s.homeEnergy
+}
+
+// Grid returns the grid power and total import and export energy
+func (s *Simulation) Grid() (float64, float64, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+
+	return s.gridPower(), s.importEnergy, s.exportEnergy
+}
@@ -0,0 +1,101 @@
+package sim
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile(t *testing.T) {
+	p, err := parseProfile(strings.NewReader("time,pv,home\n12:00,1000,200\n06:00,0,400\n"))
+	require.NoError(t, err)
+
+	midnight := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		offset   time.Duration
+		pv, home float64
+	}{
+		{6 * time.Hour, 0, 400},
+		{9 * time.Hour, 500, 300},
+		{12 * time.Hour, 1000, 200},
+		{21 * time.Hour, 500, 300}, // wraps around midnight
+		{3 * time.Hour, 1000.0 / 6, 1100.0 / 3},
+	} {
+		pv, home := p.at(midnight.Add(tc.offset))
+		assert.InDelta(t, tc.pv, pv, 1e-6, tc.offset)
+		assert.InDelta(t, tc.home, home, 1e-6, tc.offset)
+	}
+}
+
+func TestSimulationDay(t *testing.T) {
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 6, 1, 16, 0, 0, 0, time.Local))
+
+	s, err := New(util.NewLogger("foo"), clock, Config{
+		Vehicle: Vehicle{
+			Capacity:  50,
+			Soc:       30,
+			Arrival:   "17:00",
+			Departure: "07:00",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "16:00", s.Now().Format("15:04"))
+	assert.Equal(t, api.StatusA, s.Status())
+
+	s.Enable(true)
+	s.SetCurrent(16)
+
+	// arrival
+	clock.Add(90 * time.Minute)
+	assert.Equal(t, api.StatusC, s.Status())
+
+	power, _ := s.Charge()
+	assert.Equal(t, 16*230*3.0, power)
+
+	// charged overnight
+	clock.Add(13 * time.Hour)
+	assert.Equal(t, "06:30", s.Now().Format("15:04"))
+	assert.Equal(t, 100.0, s.Soc())
+	assert.Equal(t, api.StatusB, s.Status())
+
+	_, energy := s.Charge()
+	assert.InDelta(t, 35, energy, 0.01)
+
+	// departure
+	clock.Add(time.Hour)
+	assert.Equal(t, api.StatusA, s.Status())
+
+	// energy balance over full day
+	clock.Add(9 * time.Hour)
+
+	_, pv := s.PV()
+	_, home := s.Home()
+	_, imported, exported := s.Grid()
+	assert.InDelta(t, home+energy-pv, imported-exported, 1e-6)
+	assert.Greater(t, exported, 0.0)
+}
+
+func TestRegister(t *testing.T) {
+	s, err := Register("register", Config{Speed: 3600, Start: "08:00"})
+	require.NoError(t, err)
+
+	assert.Same(t, s.clock, Clock())
+	assert.Equal(t, "08:00", s.Now().Format("15:04"))
+
+	// one simulated hour per real second
+	assert.Eventually(t, func() bool {
+		return s.Now().Hour() >= 9
+	}, 5*time.Second, tick)
+
+	_, err = Register("register", Config{})
+	assert.Error(t, err)
+}
@@ -15,6 +15,9 @@ remotestart: {{ .remotestart }}
 {{- if .metervalues }}
 metervalues: {{ .metervalues }}
 {{- end }}
+{{- if and .schedule (ne .schedule "false") }}
+schedule: {{ .schedule }}
+{{- end }}
 {{- if and .meterinterval (ne .meterinterval "10s") }}
 meterinterval: {{ .meterinterval }}
 {{- end }}
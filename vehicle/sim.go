@@ -0,0 +1,57 @@
+package vehicle
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/sim"
+)
+
+// Sim is a simulated vehicle for local testing
+type Sim struct {
+	*embed
+	sim *sim.Simulation
+}
+
+func init() {
+	registry.Add("sim", NewSimFromConfig)
+}
+
+// NewSimFromConfig creates a simulated vehicle from generic config
+func NewSimFromConfig(other map[string]interface{}) (api.Vehicle, error) {
+	var cc struct {
+		embed `mapstructure:",squash"`
+		Sim   string
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	s, err := sim.Instance(cc.Sim)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle := s.Vehicle()
+	cc.embed.fromVehicle(vehicle.Title, vehicle.Capacity)
+	if cc.embed.Phases_ == 0 {
+		cc.embed.Phases_ = vehicle.Phases
+	}
+
+	return &Sim{
+		embed: &cc.embed,
+		sim:   s,
+	}, nil
+}
+
+// Soc implements the api.Vehicle interface
+func (v *Sim) Soc() (float64, error) {
+	return v.sim.Soc(), nil
+}
+
+var _ api.ChargeState = (*Sim)(nil)
+
+// Status implements the api.ChargeState interface
+func (v *Sim) Status() (api.ChargeStatus, error) {
+	return v.sim.Status(), nil
+}